@@ -1,14 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 	"time-management/internal"
+	"time-management/internal/shared/config"
+	"time-management/internal/shared/logging"
 )
 
 func main() {
-	server := internal.NewServer()
-	err := server.ListenAndServe()
+	cfg, err := config.Load()
 	if err != nil {
-		panic(fmt.Sprintf("cannot start server: %s", err))
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	slog.SetDefault(logging.New(cfg.Log, os.Stdout))
+
+	// Stop on Ctrl+C and on the SIGTERM sent by container runtimes
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := internal.Run(ctx, cfg); err != nil {
+		slog.Error("server stopped", "error", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+	"time-management/internal"
+	"time-management/internal/migration"
+	"time-management/internal/shared/config"
+)
+
+func main() {
+	steps := flag.Int("steps", 1, "number of migrations to roll back with down")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: migrate [-steps n] up|down|status")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Migrations only need the database, so the other settings may still be missing
+	cfg, err := config.Read()
+	exitOnError(err)
+	exitOnError(cfg.ValidateDatabase())
+
+	db, err := internal.InitializeDB(cfg.Database)
+	if err != nil {
+		panic(fmt.Sprintf("cannot connect to database: %s", err))
+	}
+	defer internal.CloseDB()
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		panic(fmt.Sprintf("cannot load migrations: %s", err))
+	}
+
+	ctx := context.Background()
+	switch flag.Arg(0) {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("applied", applied)
+		exitOnError(err)
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
+		printMigrations("rolled back", rolledBack)
+		exitOnError(err)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		exitOnError(err)
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + time.Unix(int64(status.AppliedAt), 0).Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, state)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printMigrations(action string, migrations []migration.Migration) {
+	for _, m := range migrations {
+		fmt.Printf("%s %04d_%s\n", action, m.Version, m.Name)
+	}
+	if len(migrations) == 0 {
+		fmt.Printf("no migrations %s\n", action)
+	}
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
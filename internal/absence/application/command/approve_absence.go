@@ -0,0 +1,40 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+)
+
+type ApproveAbsenceCommand struct {
+	Id        string
+	DecidedBy string
+}
+
+type ApproveAbsenceHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+// Handle approves a pending absence. Reports may have been created since the absence was
+// requested, so they are checked again.
+func (h *ApproveAbsenceHandler) Handle(ctx context.Context, cmd ApproveAbsenceCommand) (*domain.Absence, error) {
+	if cmd.DecidedBy == "" {
+		return nil, util.NewValidationError(domain.ErrMissingActingUser)
+	}
+
+	absence, err := h.Repo.GetById(ctx, cmd.Id, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReports(ctx, h.Repo, absence); err != nil {
+		return nil, err
+	}
+
+	return h.Repo.Decide(ctx, &domain.Decision{
+		AbsenceId: cmd.Id,
+		ToStatus:  domain.Approved,
+		DecidedBy: cmd.DecidedBy,
+		DecidedAt: uint64(time.Now().Unix()),
+	})
+}
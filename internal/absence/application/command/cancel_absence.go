@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+)
+
+type CancelAbsenceCommand struct {
+	Id     string
+	UserId string
+}
+
+type CancelAbsenceHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+// Handle withdraws a pending absence of the user, decided absences cannot be cancelled
+func (h *CancelAbsenceHandler) Handle(ctx context.Context, cmd CancelAbsenceCommand) (*domain.Absence, error) {
+	if cmd.UserId == "" {
+		return nil, util.NewValidationError(domain.ErrMissingActingUser)
+	}
+
+	return h.Repo.Decide(ctx, &domain.Decision{
+		AbsenceId: cmd.Id,
+		ToStatus:  domain.Cancelled,
+		DecidedBy: cmd.UserId,
+		DecidedAt: uint64(time.Now().Unix()),
+		OwnerId:   cmd.UserId,
+	})
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+)
+
+type DenyAbsenceCommand struct {
+	Id        string
+	DecidedBy string
+	Reason    string
+}
+
+type DenyAbsenceHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+func (h *DenyAbsenceHandler) Handle(ctx context.Context, cmd DenyAbsenceCommand) (*domain.Absence, error) {
+	if cmd.DecidedBy == "" {
+		return nil, util.NewValidationError(domain.ErrMissingActingUser)
+	}
+
+	reason := strings.TrimSpace(cmd.Reason)
+	if reason == "" {
+		return nil, util.NewFieldError("reason", domain.ErrReasonRequired)
+	}
+	if len([]rune(reason)) > domain.MaxTextLength {
+		return nil, util.NewFieldError("reason", domain.ErrReasonTooLong)
+	}
+
+	return h.Repo.Decide(ctx, &domain.Decision{
+		AbsenceId: cmd.Id,
+		ToStatus:  domain.Denied,
+		DecidedBy: cmd.DecidedBy,
+		Reason:    &reason,
+		DecidedAt: uint64(time.Now().Unix()),
+	})
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+)
+
+// checkAbsences rejects an absence taking leave on a day the employee already has a pending
+// or approved absence for
+func checkAbsences(ctx context.Context, repo domain.AbsenceRepository, absence *domain.Absence) error {
+	existing, err := repo.GetInRange(ctx, domain.AbsenceFilter{
+		UserId:   absence.User.Id,
+		Statuses: []domain.Status{domain.Pending, domain.Approved},
+		From:     absence.StartDate,
+		To:       absence.EndDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, other := range existing {
+		if other.Id != absence.Id && absence.Overlaps(&other) {
+			return util.NewConflictError(fmt.Errorf("%w: %s", domain.ErrOverlappingAbsence, other.Id))
+		}
+	}
+
+	return nil
+}
+
+// checkReports rejects a full day absence on days the employee reported work for. A half
+// day leaves the other half of the day to work, so it may share its date with reports.
+func checkReports(ctx context.Context, repo domain.AbsenceRepository, absence *domain.Absence) error {
+	if absence.HalfDay != nil {
+		return nil
+	}
+
+	dates, err := repo.GetReportDates(ctx, absence.User.Id, absence.StartDate, absence.EndDate)
+	if err != nil {
+		return err
+	}
+
+	if shared := absence.SharedDates(dates); len(shared) > 0 {
+		return util.NewConflictError(
+			fmt.Errorf("%w: %s", domain.ErrOverlappingReports, strings.Join(shared, ", ")),
+		)
+	}
+
+	return nil
+}
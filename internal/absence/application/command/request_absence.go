@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+)
+
+type RequestAbsenceCommand struct {
+	EmployeeId string
+	Type       string
+	StartDate  string
+	EndDate    string
+	HalfDay    string
+	Note       string
+}
+
+type RequestAbsenceHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+// Handle records a pending absence. It is rejected when it overlaps another pending or
+// approved absence of the employee, or full days the employee reported work for.
+func (h *RequestAbsenceHandler) Handle(ctx context.Context, cmd RequestAbsenceCommand) (*domain.Absence, error) {
+	if cmd.EmployeeId == "" || len(cmd.EmployeeId) >= 50 {
+		return nil, util.NewFieldError("employee_id", domain.ErrWrongEmployeeId)
+	}
+
+	leaveType, err := domain.ParseLeaveType(cmd.Type)
+	if err != nil {
+		return nil, util.NewFieldError("type", err)
+	}
+	halfDay, err := domain.ParseHalfDay(cmd.HalfDay)
+	if err != nil {
+		return nil, util.NewFieldError("half_day", err)
+	}
+
+	var note *string
+	if trimmed := strings.TrimSpace(cmd.Note); trimmed != "" {
+		if len([]rune(trimmed)) > domain.MaxTextLength {
+			return nil, util.NewFieldError("note", domain.ErrNoteTooLong)
+		}
+		note = &trimmed
+	}
+
+	absence := domain.NewAbsence(
+		uuid.New().String(),
+		cmd.EmployeeId,
+		leaveType,
+		cmd.StartDate,
+		cmd.EndDate,
+		halfDay,
+		note,
+		uint64(time.Now().Unix()),
+	)
+	if _, err := time.Parse(domain.DateLayout, cmd.StartDate); err != nil {
+		return nil, util.NewFieldError("start_date", domain.ErrInvalidDate)
+	}
+	if _, err := time.Parse(domain.DateLayout, cmd.EndDate); err != nil {
+		return nil, util.NewFieldError("end_date", domain.ErrInvalidDate)
+	}
+	if err := absence.Validate(); err != nil {
+		if err == domain.ErrHalfDayNeedsSingleDay {
+			return nil, util.NewFieldError("half_day", err)
+		}
+		return nil, util.NewValidationError(err)
+	}
+
+	active, err := h.Repo.IsEmployeeActive(ctx, cmd.EmployeeId)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, util.NewValidationError(domain.ErrEmployeeInactive)
+	}
+
+	if err := checkAbsences(ctx, h.Repo, absence); err != nil {
+		return nil, err
+	}
+	if err := checkReports(ctx, h.Repo, absence); err != nil {
+		return nil, err
+	}
+
+	return h.Repo.Create(ctx, absence)
+}
@@ -0,0 +1,20 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/absence/domain"
+)
+
+// GetAbsenceQuery looks up an absence, restricted to the absences of UserId unless it is empty
+type GetAbsenceQuery struct {
+	Id     string
+	UserId string
+}
+
+type GetAbsenceHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+func (h *GetAbsenceHandler) Handle(ctx context.Context, query GetAbsenceQuery) (*domain.Absence, error) {
+	return h.Repo.GetById(ctx, query.Id, query.UserId)
+}
@@ -0,0 +1,30 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/pagination"
+)
+
+type GetAbsencesQuery struct {
+	Filter domain.AbsenceFilter
+	Page   pagination.Request
+}
+
+type GetAbsencesHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+func (h *GetAbsencesHandler) Handle(
+	ctx context.Context,
+	query GetAbsencesQuery,
+) (*pagination.Page[domain.Absence], error) {
+	absences, total, err := h.Repo.GetAll(ctx, query.Filter, query.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewPage(absences, total, query.Page)
+
+	return &page, nil
+}
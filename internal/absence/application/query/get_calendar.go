@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+	"time"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+)
+
+type GetCalendarQuery struct {
+	// UserId limits the calendar to one employee, every employee when empty
+	UserId string
+	From   string
+	To     string
+}
+
+type GetCalendarHandler struct {
+	Repo domain.AbsenceRepository
+}
+
+// Handle lists the pending and approved absences on each weekday from From to To
+func (h *GetCalendarHandler) Handle(ctx context.Context, query GetCalendarQuery) (*domain.Calendar, error) {
+	if query.From == "" || query.To == "" {
+		return nil, util.NewValidationError(domain.ErrDateRangeRequired)
+	}
+	from, err := time.Parse(domain.DateLayout, query.From)
+	if err != nil {
+		return nil, util.NewFieldError("from", domain.ErrInvalidDate)
+	}
+	to, err := time.Parse(domain.DateLayout, query.To)
+	if err != nil {
+		return nil, util.NewFieldError("to", domain.ErrInvalidDate)
+	}
+	if from.After(to) {
+		return nil, util.NewValidationError(domain.ErrInvalidDateRange)
+	}
+	if to.Sub(from) >= domain.MaxRangeDays*24*time.Hour {
+		return nil, util.NewValidationError(domain.ErrDateRangeTooLong)
+	}
+
+	absences, err := h.Repo.GetInRange(ctx, domain.AbsenceFilter{
+		UserId:   query.UserId,
+		Statuses: []domain.Status{domain.Pending, domain.Approved},
+		From:     query.From,
+		To:       query.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewCalendar(from, to, absences), nil
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the format of absence dates in requests, responses and storage
+const DateLayout = "2006-01-02"
+
+// MaxTextLength is the longest note or decision reason that can be given
+const MaxTextLength = 500
+
+// MaxRangeDays is the longest date range an absence or a calendar can cover
+const MaxRangeDays = 366
+
+// LeaveType is the kind of leave an absence is taken as
+type LeaveType string
+
+const (
+	Vacation LeaveType = "vacation"
+	Sick     LeaveType = "sick"
+	Unpaid   LeaveType = "unpaid"
+)
+
+func ParseLeaveType(value string) (LeaveType, error) {
+	switch LeaveType(value) {
+	case Vacation, Sick, Unpaid:
+		return LeaveType(value), nil
+	default:
+		return "", ErrInvalidLeaveType
+	}
+}
+
+// Status is where an absence stands in its approval
+type Status string
+
+const (
+	Pending   Status = "pending"
+	Approved  Status = "approved"
+	Denied    Status = "denied"
+	Cancelled Status = "cancelled"
+)
+
+// transitions lists the statuses each status can move to. Only pending absences are
+// decided or cancelled, decided ones are final.
+var transitions = map[Status][]Status{
+	Pending: {Approved, Denied, Cancelled},
+}
+
+func ParseStatus(value string) (Status, error) {
+	switch Status(value) {
+	case Pending, Approved, Denied, Cancelled:
+		return Status(value), nil
+	default:
+		return "", ErrInvalidStatus
+	}
+}
+
+// CheckTransition returns ErrInvalidStatusTransition when from cannot move to to
+func CheckTransition(from, to Status) error {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, from, to)
+}
+
+// HalfDay tells which half of a single date a half day absence takes
+type HalfDay string
+
+const (
+	Morning   HalfDay = "morning"
+	Afternoon HalfDay = "afternoon"
+)
+
+func ParseHalfDay(value string) (*HalfDay, error) {
+	switch HalfDay(value) {
+	case "":
+		return nil, nil
+	case Morning, Afternoon:
+		half := HalfDay(value)
+		return &half, nil
+	default:
+		return nil, ErrInvalidHalfDay
+	}
+}
+
+type User struct {
+	Id        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// Absence is leave an employee takes from StartDate to EndDate, both included. Saturdays
+// and Sundays within the range are not counted as leave.
+type Absence struct {
+	Id        string    `json:"id"`
+	User      User      `json:"user"`
+	Type      LeaveType `json:"type"`
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	HalfDay   *HalfDay  `json:"half_day,omitempty"`
+	Note      *string   `json:"note,omitempty"`
+	Status    Status    `json:"status"`
+	// DecidedBy, DecisionReason and DecidedAt are set once a manager approved or denied
+	// the absence, or the employee cancelled it
+	DecidedBy      *User   `json:"decided_by,omitempty"`
+	DecisionReason *string `json:"decision_reason,omitempty"`
+	DecidedAt      *uint64 `json:"decided_at,omitempty"`
+	CreatedAt      uint64  `json:"created_at"`
+}
+
+// NewAbsence Factory method to create a pending Absence
+func NewAbsence(
+	id, userId string,
+	leaveType LeaveType,
+	startDate, endDate string,
+	halfDay *HalfDay,
+	note *string,
+	createdAt uint64,
+) *Absence {
+	return &Absence{
+		Id:        id,
+		User:      User{Id: userId},
+		Type:      leaveType,
+		StartDate: startDate,
+		EndDate:   endDate,
+		HalfDay:   halfDay,
+		Note:      note,
+		Status:    Pending,
+		CreatedAt: createdAt,
+	}
+}
+
+// Validate checks the dates of the absence
+func (a *Absence) Validate() error {
+	start, err := time.Parse(DateLayout, a.StartDate)
+	if err != nil {
+		return ErrInvalidDate
+	}
+	end, err := time.Parse(DateLayout, a.EndDate)
+	if err != nil {
+		return ErrInvalidDate
+	}
+	if start.After(end) {
+		return ErrInvalidDateRange
+	}
+	if end.Sub(start) >= MaxRangeDays*24*time.Hour {
+		return ErrDateRangeTooLong
+	}
+	if a.HalfDay != nil && !start.Equal(end) {
+		return ErrHalfDayNeedsSingleDay
+	}
+	if a.Days() == 0 {
+		return ErrNoWorkDays
+	}
+
+	return nil
+}
+
+// Days counts the leave taken, weekdays only, half days as 0.5
+func (a *Absence) Days() float64 {
+	days := float64(len(a.Dates()))
+	if a.HalfDay != nil {
+		days /= 2
+	}
+
+	return days
+}
+
+// Dates lists the weekdays the absence covers
+func (a *Absence) Dates() []string {
+	return a.DatesBetween(a.StartDate, a.EndDate)
+}
+
+// DatesBetween lists the weekdays the absence covers from first to last, both included,
+// without walking the days of the absence outside of them
+func (a *Absence) DatesBetween(first, last string) []string {
+	start, err := time.Parse(DateLayout, max(a.StartDate, first))
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse(DateLayout, min(a.EndDate, last))
+	if err != nil {
+		return nil
+	}
+
+	var dates []string
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if IsWeekday(day) {
+			dates = append(dates, day.Format(DateLayout))
+		}
+	}
+
+	return dates
+}
+
+// IsWeekday reports whether the date is a Monday to Friday
+func IsWeekday(date time.Time) bool {
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+// MarshalJSON adds the number of leave days
+func (a Absence) MarshalJSON() ([]byte, error) {
+	type absence Absence
+	return json.Marshal(struct {
+		absence
+		Days float64 `json:"days"`
+	}{
+		absence: absence(a),
+		Days:    a.Days(),
+	})
+}
+
+// Overlaps reports whether both absences take leave on the same weekday. Half days on the
+// same date only overlap when they take the same half.
+func (a *Absence) Overlaps(other *Absence) bool {
+	if a.HalfDay != nil && other.HalfDay != nil && *a.HalfDay != *other.HalfDay {
+		return false
+	}
+
+	return len(a.SharedDates(other.DatesBetween(a.StartDate, a.EndDate))) > 0
+}
+
+// SharedDates returns the dates of the absence that are also in dates
+func (a *Absence) SharedDates(dates []string) []string {
+	set := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		set[date] = true
+	}
+
+	var shared []string
+	for _, date := range a.Dates() {
+		if set[date] {
+			shared = append(shared, date)
+		}
+	}
+
+	return shared
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+)
+
+// AbsenceFilter narrows absence lists; zero values are ignored. From and To are dates,
+// both included, absences overlapping them match.
+type AbsenceFilter struct {
+	UserId   string
+	Type     LeaveType
+	Statuses []Status
+	From     string
+	To       string
+}
+
+type AbsenceRepository interface {
+	Create(ctx context.Context, absence *Absence) (*Absence, error)
+	GetAll(ctx context.Context, filter AbsenceFilter, page pagination.Request) ([]Absence, int, error)
+	// GetInRange returns every absence matching the filter, ordered by start date and user
+	GetInRange(ctx context.Context, filter AbsenceFilter) ([]Absence, error)
+	// GetById returns the absence, of the given user only unless userId is empty
+	GetById(ctx context.Context, id, userId string) (*Absence, error)
+	// IsEmployeeActive reports whether the user exists and is active
+	IsEmployeeActive(ctx context.Context, userId string) (bool, error)
+	// GetReportDates returns the work dates between from and to the user has reports for
+	// that were not denied
+	GetReportDates(ctx context.Context, userId, from, to string) ([]string, error)
+	// Decide moves the absence to the status of the decision when the transition is allowed
+	Decide(ctx context.Context, decision *Decision) (*Absence, error)
+}
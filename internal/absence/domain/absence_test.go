@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func halfDay(value HalfDay) *HalfDay {
+	return &value
+}
+
+func TestAbsence_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		absence *Absence
+		err     error
+	}{
+		{"week", NewAbsence("abs1", "usr1", Vacation, "2024-03-04", "2024-03-08", nil, nil, 0), nil},
+		{"half day", NewAbsence("abs1", "usr1", Sick, "2024-03-04", "2024-03-04", halfDay(Morning), nil, 0), nil},
+		{"invalid date", NewAbsence("abs1", "usr1", Vacation, "2024-02-30", "2024-03-08", nil, nil, 0), ErrInvalidDate},
+		{"end before start", NewAbsence("abs1", "usr1", Vacation, "2024-03-08", "2024-03-04", nil, nil, 0), ErrInvalidDateRange},
+		{"half day range", NewAbsence("abs1", "usr1", Vacation, "2024-03-04", "2024-03-05", halfDay(Afternoon), nil, 0), ErrHalfDayNeedsSingleDay},
+		{"weekend only", NewAbsence("abs1", "usr1", Vacation, "2024-03-09", "2024-03-10", nil, nil, 0), ErrNoWorkDays},
+		{"range too long", NewAbsence("abs1", "usr1", Unpaid, "0001-01-01", "9999-12-31", nil, nil, 0), ErrDateRangeTooLong},
+		{"leap year", NewAbsence("abs1", "usr1", Unpaid, "2024-01-01", "2024-12-31", nil, nil, 0), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.absence.Validate())
+		})
+	}
+}
+
+func TestAbsence_Days(t *testing.T) {
+	// Friday to Tuesday, the weekend in between is not leave
+	absence := NewAbsence("abs1", "usr1", Vacation, "2024-03-08", "2024-03-12", nil, nil, 0)
+	half := NewAbsence("abs2", "usr1", Vacation, "2024-03-08", "2024-03-08", halfDay(Morning), nil, 0)
+
+	// Assertions
+	assert.Equal(t, []string{"2024-03-08", "2024-03-11", "2024-03-12"}, absence.Dates())
+	assert.Equal(t, 3.0, absence.Days())
+	assert.Equal(t, 0.5, half.Days())
+	assert.Equal(t, []string{"2024-03-11"}, absence.DatesBetween("2024-03-09", "2024-03-11"))
+	assert.Empty(t, absence.DatesBetween("2024-03-13", "2024-03-31"))
+}
+
+func TestAbsence_Overlaps(t *testing.T) {
+	week := NewAbsence("abs1", "usr1", Vacation, "2024-03-04", "2024-03-08", nil, nil, 0)
+	morning := NewAbsence("abs2", "usr1", Vacation, "2024-03-11", "2024-03-11", halfDay(Morning), nil, 0)
+
+	tests := []struct {
+		name     string
+		other    *Absence
+		overlaps bool
+	}{
+		{"shared weekday", NewAbsence("abs3", "usr1", Sick, "2024-03-08", "2024-03-11", nil, nil, 0), true},
+		{"weekend only", NewAbsence("abs3", "usr1", Sick, "2024-03-09", "2024-03-10", nil, nil, 0), false},
+		{"other half", NewAbsence("abs3", "usr1", Sick, "2024-03-11", "2024-03-11", halfDay(Afternoon), nil, 0), false},
+		{"same half", NewAbsence("abs3", "usr1", Sick, "2024-03-11", "2024-03-11", halfDay(Morning), nil, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := week
+			if tt.other.HalfDay != nil {
+				target = morning
+			}
+			assert.Equal(t, tt.overlaps, target.Overlaps(tt.other))
+		})
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	assert.NoError(t, CheckTransition(Pending, Approved))
+	assert.NoError(t, CheckTransition(Pending, Cancelled))
+	assert.ErrorIs(t, CheckTransition(Approved, Denied), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, CheckTransition(Denied, Cancelled), ErrInvalidStatusTransition)
+}
+
+func TestNewCalendar(t *testing.T) {
+	from, _ := time.Parse(DateLayout, "2024-03-06")
+	to, _ := time.Parse(DateLayout, "2024-03-11")
+	absences := []Absence{
+		*NewAbsence("abs1", "usr1", Vacation, "2024-03-04", "2024-03-08", nil, nil, 0),
+		*NewAbsence("abs2", "usr2", Sick, "2024-03-07", "2024-03-07", halfDay(Afternoon), nil, 0),
+	}
+
+	// Execute test
+	calendar := NewCalendar(from, to, absences)
+
+	// Assertions
+	assert.Equal(t, "2024-03-06", calendar.From)
+	assert.Equal(t, "2024-03-11", calendar.To)
+	assert.Len(t, calendar.Days, 3)
+	assert.Equal(t, "2024-03-06", calendar.Days[0].Date)
+	assert.Equal(t, "2024-03-07", calendar.Days[1].Date)
+	assert.Len(t, calendar.Days[1].Absences, 2)
+	assert.Equal(t, Afternoon, *calendar.Days[1].Absences[1].HalfDay)
+	assert.Equal(t, "2024-03-08", calendar.Days[2].Date)
+}
+
+func TestNewCalendar_LongAbsence(t *testing.T) {
+	from, _ := time.Parse(DateLayout, "2024-03-04")
+	to, _ := time.Parse(DateLayout, "2024-03-10")
+	// Stored before ranges were limited, only the week asked for is walked
+	absences := []Absence{*NewAbsence("abs1", "usr1", Unpaid, "0001-01-01", "9999-12-31", nil, nil, 0)}
+
+	// Execute test
+	calendar := NewCalendar(from, to, absences)
+
+	// Assertions
+	assert.Len(t, calendar.Days, 5)
+	assert.Equal(t, "2024-03-04", calendar.Days[0].Date)
+	assert.Equal(t, "2024-03-08", calendar.Days[4].Date)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// CalendarEntry is an absence as shown on a day of the calendar
+type CalendarEntry struct {
+	AbsenceId string    `json:"absence_id"`
+	User      User      `json:"user"`
+	Type      LeaveType `json:"type"`
+	HalfDay   *HalfDay  `json:"half_day,omitempty"`
+	Status    Status    `json:"status"`
+}
+
+type CalendarDay struct {
+	Date     string          `json:"date"`
+	Absences []CalendarEntry `json:"absences"`
+}
+
+// Calendar lists who is absent on each weekday of a date range, days nobody is absent
+// are left out
+type Calendar struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Days []CalendarDay `json:"days"`
+}
+
+// NewCalendar spreads the absences over the days of the range they cover
+func NewCalendar(from, to time.Time, absences []Absence) *Calendar {
+	first, last := from.Format(DateLayout), to.Format(DateLayout)
+	index := map[string]int{}
+	calendar := &Calendar{From: first, To: last, Days: []CalendarDay{}}
+
+	for _, absence := range absences {
+		for _, date := range absence.DatesBetween(first, last) {
+			i, ok := index[date]
+			if !ok {
+				i = len(calendar.Days)
+				index[date] = i
+				calendar.Days = append(calendar.Days, CalendarDay{Date: date})
+			}
+			calendar.Days[i].Absences = append(calendar.Days[i].Absences, CalendarEntry{
+				AbsenceId: absence.Id,
+				User:      absence.User,
+				Type:      absence.Type,
+				HalfDay:   absence.HalfDay,
+				Status:    absence.Status,
+			})
+		}
+	}
+
+	sort.Slice(calendar.Days, func(i, j int) bool { return calendar.Days[i].Date < calendar.Days[j].Date })
+
+	return calendar
+}
@@ -0,0 +1,12 @@
+package domain
+
+// Decision moves a pending absence to its final status
+type Decision struct {
+	AbsenceId string
+	ToStatus  Status
+	DecidedBy string
+	Reason    *string
+	DecidedAt uint64
+	// OwnerId limits the decision to absences of this user, for employees cancelling their own
+	OwnerId string
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "errors"
+
+var (
+	ErrAbsenceNotFound         = errors.New("absence not found")
+	ErrWrongEmployeeId         = errors.New("wrong employee id: employee does not exist")
+	ErrEmployeeInactive        = errors.New("employee is deactivated and cannot request leave")
+	ErrMissingActingUser       = errors.New("acting user required")
+	ErrInvalidLeaveType        = errors.New("invalid leave type: expected vacation, sick or unpaid")
+	ErrInvalidStatus           = errors.New("invalid absence status: expected pending, approved, denied or cancelled")
+	ErrInvalidHalfDay          = errors.New("invalid half day: expected morning or afternoon")
+	ErrHalfDayNeedsSingleDay   = errors.New("half days can only be requested for a single date")
+	ErrInvalidDate             = errors.New("invalid date: expected format YYYY-MM-DD")
+	ErrInvalidDateRange        = errors.New("invalid date range: start must not be after end")
+	ErrDateRangeRequired       = errors.New("date range required: from and to must be set")
+	ErrDateRangeTooLong        = errors.New("date range too long: at most 366 days")
+	ErrNoWorkDays              = errors.New("the absence covers no work day")
+	ErrNoteTooLong             = errors.New("note too long: at most 500 characters")
+	ErrReasonRequired          = errors.New("reason required: an absence cannot be denied without a reason")
+	ErrReasonTooLong           = errors.New("reason too long: at most 500 characters")
+	ErrInvalidStatusTransition = errors.New("invalid absence status transition")
+	ErrOverlappingAbsence      = errors.New("overlaps another absence of the employee")
+	ErrOverlappingReports      = errors.New("overlaps reports of the employee")
+)
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrAbsenceNotFound:         "absence_not_found",
+	ErrWrongEmployeeId:         "employee_id_invalid",
+	ErrEmployeeInactive:        "employee_inactive",
+	ErrMissingActingUser:       "acting_user_required",
+	ErrInvalidLeaveType:        "leave_type_invalid",
+	ErrInvalidStatus:           "absence_status_invalid",
+	ErrInvalidHalfDay:          "half_day_invalid",
+	ErrHalfDayNeedsSingleDay:   "half_day_needs_single_day",
+	ErrInvalidDate:             "date_invalid",
+	ErrInvalidDateRange:        "date_range_invalid",
+	ErrDateRangeRequired:       "date_range_required",
+	ErrDateRangeTooLong:        "date_range_too_long",
+	ErrNoWorkDays:              "no_work_days",
+	ErrNoteTooLong:             "note_too_long",
+	ErrReasonRequired:          "reason_required",
+	ErrReasonTooLong:           "reason_too_long",
+	ErrInvalidStatusTransition: "status_transition_invalid",
+	ErrOverlappingAbsence:      "absence_overlap",
+	ErrOverlappingReports:      "absence_overlaps_reports",
+}
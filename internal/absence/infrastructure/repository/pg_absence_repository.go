@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time-management/internal/absence/domain"
+	reportDomain "time-management/internal/report/domain"
+	reportPg "time-management/internal/report/infrastructure/repository"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+	userPg "time-management/internal/user/infrastructure/repository"
+)
+
+const TableName = "absences"
+
+var selectAbsences = fmt.Sprintf(`
+	SELECT
+		a.id, a.type, to_char(a.start_date, 'YYYY-MM-DD'), to_char(a.end_date, 'YYYY-MM-DD'),
+		a.half_day, a.note, a.status, a.decision_reason, a.decided_at, a.created_at,
+		u.id, u.first_name, u.last_name, u.email,
+		d.id, d.first_name, d.last_name, d.email
+	FROM %s a
+	JOIN %s u ON a.user_id = u.id
+	LEFT JOIN %s d ON a.decided_by = d.id
+`, TableName, userPg.TableName, userPg.TableName)
+
+// sortColumns maps the sortable absence fields to their columns
+var sortColumns = map[string]string{
+	"start_date": "a.start_date",
+	"end_date":   "a.end_date",
+	"created_at": "a.created_at",
+	"type":       "a.type",
+	"status":     "a.status",
+}
+
+type PgAbsenceRepository struct {
+	DB *sql.DB
+}
+
+func NewPgAbsenceRepository(db *sql.DB) *PgAbsenceRepository {
+	return &PgAbsenceRepository{DB: db}
+}
+
+func (r *PgAbsenceRepository) Create(ctx context.Context, absence *domain.Absence) (*domain.Absence, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, user_id, type, start_date, end_date, half_day, note, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, TableName)
+
+	_, err := r.DB.ExecContext(ctx, query,
+		absence.Id, absence.User.Id, absence.Type, absence.StartDate, absence.EndDate,
+		absence.HalfDay, absence.Note, absence.Status, absence.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(ctx, absence.Id, "")
+}
+
+func (r *PgAbsenceRepository) GetAll(
+	ctx context.Context,
+	filter domain.AbsenceFilter,
+	page pagination.Request,
+) ([]domain.Absence, int, error) {
+	where, args := buildAbsenceFilter(filter)
+
+	orderBy, err := page.OrderBy(sortColumns, "a.start_date DESC, a.created_at DESC", "a.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s a %s`, TableName, where)
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`%s %s %s LIMIT $%d OFFSET $%d`, selectAbsences, where, orderBy, len(args)+1, len(args)+2)
+	absences, err := r.query(ctx, query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return absences, total, nil
+}
+
+func (r *PgAbsenceRepository) GetInRange(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error) {
+	where, args := buildAbsenceFilter(filter)
+
+	return r.query(ctx, selectAbsences+" "+where+" ORDER BY a.start_date, u.id, a.id", args...)
+}
+
+func (r *PgAbsenceRepository) GetById(ctx context.Context, id, userId string) (*domain.Absence, error) {
+	query := selectAbsences + " WHERE a.id = $1"
+	args := []any{id}
+	if userId != "" {
+		query += " AND a.user_id = $2"
+		args = append(args, userId)
+	}
+
+	absence, err := scanAbsence(r.DB.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrAbsenceNotFound)
+		}
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+func (r *PgAbsenceRepository) IsEmployeeActive(ctx context.Context, userId string) (bool, error) {
+	query := fmt.Sprintf(`SELECT active FROM %s WHERE id = $1`, userPg.TableName)
+
+	var active sql.NullBool
+	err := r.DB.QueryRowContext(ctx, query, userId).Scan(&active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, util.NewValidationError(domain.ErrWrongEmployeeId)
+		}
+		return false, err
+	}
+
+	return active.Valid && active.Bool, nil
+}
+
+func (r *PgAbsenceRepository) GetReportDates(ctx context.Context, userId, from, to string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT DISTINCT to_char(work_date, 'YYYY-MM-DD')
+		FROM %s
+		WHERE user_id = $1 AND work_date BETWEEN $2 AND $3 AND status <> $4
+		ORDER BY 1
+	`, reportPg.TableName)
+
+	rows, err := r.DB.QueryContext(ctx, query, userId, from, to, int(reportDomain.Denied))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dates := []string{}
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, rows.Err()
+}
+
+// HasFullDayAbsence reports whether the user has an approved full day absence on the work
+// date. Weekends are not taken as leave, so an absence spanning one leaves it free to work.
+func (r *PgAbsenceRepository) HasFullDayAbsence(
+	ctx context.Context,
+	userId string,
+	workDate reportDomain.Date,
+) (bool, error) {
+	if !domain.IsWeekday(workDate.Time) {
+		return false, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT EXISTS(
+			SELECT 1 FROM %s
+			WHERE user_id = $1 AND status = $2 AND half_day IS NULL AND $3 BETWEEN start_date AND end_date
+		)
+	`, TableName)
+
+	var absent bool
+	err := r.DB.QueryRowContext(ctx, query, userId, domain.Approved, workDate).Scan(&absent)
+	if err != nil {
+		return false, err
+	}
+
+	return absent, nil
+}
+
+// Decide locks the absence, checks the transition is allowed and records the decision in
+// one transaction. A non-empty OwnerId restricts the decision to absences of that user.
+func (r *PgAbsenceRepository) Decide(ctx context.Context, decision *domain.Decision) (*domain.Absence, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT status FROM %s WHERE id = $1`, TableName)
+	args := []any{decision.AbsenceId}
+	if decision.OwnerId != "" {
+		query += ` AND user_id = $2`
+		args = append(args, decision.OwnerId)
+	}
+	query += ` FOR UPDATE`
+
+	var from domain.Status
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&from); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrAbsenceNotFound)
+		}
+		return nil, err
+	}
+
+	if err := domain.CheckTransition(from, decision.ToStatus); err != nil {
+		tx.Rollback()
+		return nil, util.NewConflictError(err)
+	}
+
+	query = fmt.Sprintf(`
+		UPDATE %s SET status = $1, decided_by = $2, decision_reason = $3, decided_at = $4
+		WHERE id = $5
+	`, TableName)
+
+	_, err = tx.ExecContext(ctx, query,
+		decision.ToStatus, decision.DecidedBy, decision.Reason, decision.DecidedAt, decision.AbsenceId,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetById(ctx, decision.AbsenceId, "")
+}
+
+func (r *PgAbsenceRepository) query(ctx context.Context, query string, args ...any) ([]domain.Absence, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	absences := []domain.Absence{}
+	for rows.Next() {
+		absence, err := scanAbsence(rows)
+		if err != nil {
+			return nil, err
+		}
+		absences = append(absences, *absence)
+	}
+
+	return absences, rows.Err()
+}
+
+func buildAbsenceFilter(filter domain.AbsenceFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("a.user_id = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("a.type = $%d", len(args)))
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "a.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.From != "" {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("a.end_date >= $%d", len(args)))
+	}
+	if filter.To != "" {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("a.start_date <= $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAbsence(row rowScanner) (*domain.Absence, error) {
+	var absence domain.Absence
+	var halfDay, note, reason sql.NullString
+	var decidedAt sql.NullInt64
+	var deciderId, deciderFirstName, deciderLastName, deciderEmail sql.NullString
+
+	err := row.Scan(
+		&absence.Id, &absence.Type, &absence.StartDate, &absence.EndDate,
+		&halfDay, &note, &absence.Status, &reason, &decidedAt, &absence.CreatedAt,
+		&absence.User.Id, &absence.User.FirstName, &absence.User.LastName, &absence.User.Email,
+		&deciderId, &deciderFirstName, &deciderLastName, &deciderEmail,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if halfDay.Valid {
+		half := domain.HalfDay(halfDay.String)
+		absence.HalfDay = &half
+	}
+	if note.Valid {
+		absence.Note = &note.String
+	}
+	if reason.Valid {
+		absence.DecisionReason = &reason.String
+	}
+	if decidedAt.Valid {
+		at := uint64(decidedAt.Int64)
+		absence.DecidedAt = &at
+	}
+	if deciderId.Valid {
+		absence.DecidedBy = &domain.User{
+			Id:        deciderId.String,
+			FirstName: deciderFirstName.String,
+			LastName:  deciderLastName.String,
+			Email:     deciderEmail.String,
+		}
+	}
+
+	return &absence, nil
+}
@@ -0,0 +1,233 @@
+package repository_test
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time-management/internal/absence/domain"
+	"time-management/internal/absence/infrastructure/repository"
+	reportDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+)
+
+var absenceColumns = []string{
+	"id", "type", "start_date", "end_date", "half_day", "note", "status", "decision_reason", "decided_at", "created_at",
+	"id", "first_name", "last_name", "email",
+	"id", "first_name", "last_name", "email",
+}
+
+func pendingAbsenceRow(rows *sqlmock.Rows) *sqlmock.Rows {
+	return rows.AddRow(
+		"abs1", "vacation", "2024-03-04", "2024-03-08", nil, "Family trip", "pending", nil, nil, 1700000000,
+		"usr1", "Jane", "Doe", "jane@example.com",
+		nil, nil, nil, nil,
+	)
+}
+
+func TestPgAbsenceRepository_Create(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	note := "Family trip"
+	absence := domain.NewAbsence("abs1", "usr1", domain.Vacation, "2024-03-04", "2024-03-08", nil, &note, 1700000000)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO absences (id, user_id, type, start_date, end_date, half_day, note, status, created_at)`)).
+		WithArgs("abs1", "usr1", domain.Vacation, "2024-03-04", "2024-03-08", nil, &note, domain.Pending, uint64(1700000000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE a.id = $1`)).
+		WithArgs("abs1").
+		WillReturnRows(pendingAbsenceRow(sqlmock.NewRows(absenceColumns)))
+
+	// Execute test
+	created, err := repo.Create(context.Background(), absence)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", created.User.FirstName)
+	assert.Equal(t, domain.Pending, created.Status)
+	assert.Equal(t, 5.0, created.Days())
+	assert.Nil(t, created.DecidedBy)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgAbsenceRepository_GetAll(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	filter := domain.AbsenceFilter{
+		UserId:   "usr1",
+		Type:     domain.Vacation,
+		Statuses: []domain.Status{domain.Pending, domain.Approved},
+		From:     "2024-03-01",
+		To:       "2024-03-31",
+	}
+	where := `WHERE a.user_id = $1 AND a.type = $2 AND a.status IN ($3, $4) AND a.end_date >= $5 AND a.start_date <= $6`
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM absences a `+where)).
+		WithArgs("usr1", domain.Vacation, domain.Pending, domain.Approved, "2024-03-01", "2024-03-31").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(where+` ORDER BY a.start_date DESC, a.created_at DESC, a.id LIMIT $7 OFFSET $8`)).
+		WithArgs("usr1", domain.Vacation, domain.Pending, domain.Approved, "2024-03-01", "2024-03-31", 20, 0).
+		WillReturnRows(pendingAbsenceRow(sqlmock.NewRows(absenceColumns)))
+
+	// Execute test
+	absences, total, err := repo.GetAll(context.Background(), filter, pagination.Request{Limit: 20})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, absences, 1)
+	assert.Equal(t, "Family trip", *absences[0].Note)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgAbsenceRepository_GetById_NotFound(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE a.id = $1 AND a.user_id = $2`)).
+		WithArgs("abs9", "usr1").
+		WillReturnRows(sqlmock.NewRows(absenceColumns))
+
+	// Execute test
+	absence, err := repo.GetById(context.Background(), "abs9", "usr1")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrAbsenceNotFound)
+	var notFoundErr *util.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Nil(t, absence)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgAbsenceRepository_GetReportDates(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM reports`)).
+		WithArgs("usr1", "2024-03-04", "2024-03-08", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"work_date"}).AddRow("2024-03-05").AddRow("2024-03-06"))
+
+	// Execute test
+	dates, err := repo.GetReportDates(context.Background(), "usr1", "2024-03-04", "2024-03-08")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2024-03-05", "2024-03-06"}, dates)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgAbsenceRepository_HasFullDayAbsence(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`WHERE user_id = $1 AND status = $2 AND half_day IS NULL AND $3 BETWEEN start_date AND end_date`,
+	)).
+		WithArgs("usr1", domain.Approved, "2024-03-05").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	// Execute test
+	workDate, _ := reportDomain.ParseDate("2024-03-05")
+	absent, err := repo.HasFullDayAbsence(context.Background(), "usr1", workDate)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, absent)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgAbsenceRepository_HasFullDayAbsence_Weekend(t *testing.T) {
+	_, repo := setupMockAndRepo(t)
+
+	// A Saturday is not taken as leave, nothing is queried
+	workDate, _ := reportDomain.ParseDate("2024-03-09")
+	absent, err := repo.HasFullDayAbsence(context.Background(), "usr1", workDate)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, absent)
+}
+
+func TestPgAbsenceRepository_Decide(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	decision := &domain.Decision{
+		AbsenceId: "abs1",
+		ToStatus:  domain.Approved,
+		DecidedBy: "mgr1",
+		DecidedAt: 1700000100,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM absences WHERE id = $1 FOR UPDATE`)).
+		WithArgs("abs1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE absences SET status = $1, decided_by = $2, decision_reason = $3, decided_at = $4`)).
+		WithArgs(domain.Approved, "mgr1", nil, uint64(1700000100), "abs1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE a.id = $1`)).
+		WithArgs("abs1").
+		WillReturnRows(sqlmock.NewRows(absenceColumns).AddRow(
+			"abs1", "vacation", "2024-03-04", "2024-03-08", nil, nil, "approved", nil, 1700000100, 1700000000,
+			"usr1", "Jane", "Doe", "jane@example.com",
+			"mgr1", "John", "Smith", "john@example.com",
+		))
+
+	// Execute test
+	absence, err := repo.Decide(context.Background(), decision)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Approved, absence.Status)
+	assert.Equal(t, "John", absence.DecidedBy.FirstName)
+	assert.Equal(t, uint64(1700000100), *absence.DecidedAt)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgAbsenceRepository_Decide_AlreadyDecided(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	decision := &domain.Decision{
+		AbsenceId: "abs1",
+		ToStatus:  domain.Cancelled,
+		DecidedBy: "usr1",
+		DecidedAt: 1700000100,
+		OwnerId:   "usr1",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM absences WHERE id = $1 AND user_id = $2 FOR UPDATE`)).
+		WithArgs("abs1", "usr1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("denied"))
+	mock.ExpectRollback()
+
+	// Execute test
+	absence, err := repo.Decide(context.Background(), decision)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Nil(t, absence)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *repository.PgAbsenceRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	repo := repository.NewPgAbsenceRepository(db)
+	return mock, repo
+}
+
+// assertMockExpectations is a helper to ensure all expectations of the mock are met
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time-management/internal/absence/application/command"
+	"time-management/internal/absence/application/query"
+	absDomain "time-management/internal/absence/domain"
+	"time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+type AbsenceHandler struct {
+	RequestAbsenceHandler command.RequestAbsenceHandler
+	ApproveAbsenceHandler command.ApproveAbsenceHandler
+	DenyAbsenceHandler    command.DenyAbsenceHandler
+	CancelAbsenceHandler  command.CancelAbsenceHandler
+	GetAbsencesHandler    query.GetAbsencesHandler
+	GetAbsenceHandler     query.GetAbsenceHandler
+	GetCalendarHandler    query.GetCalendarHandler
+}
+
+func NewAbsenceHandler(repository absDomain.AbsenceRepository) *AbsenceHandler {
+	return &AbsenceHandler{
+		RequestAbsenceHandler: command.RequestAbsenceHandler{Repo: repository},
+		ApproveAbsenceHandler: command.ApproveAbsenceHandler{Repo: repository},
+		DenyAbsenceHandler:    command.DenyAbsenceHandler{Repo: repository},
+		CancelAbsenceHandler:  command.CancelAbsenceHandler{Repo: repository},
+		GetAbsencesHandler:    query.GetAbsencesHandler{Repo: repository},
+		GetAbsenceHandler:     query.GetAbsenceHandler{Repo: repository},
+		GetCalendarHandler:    query.GetCalendarHandler{Repo: repository},
+	}
+}
+
+// RequestAbsence records a pending absence of the user. Managers request leave for an
+// employee by passing the employee id in the path.
+func (h *AbsenceHandler) RequestAbsence(w http.ResponseWriter, r *http.Request) error {
+	employeeId := chi.URLParam(r, "employee_id")
+	if employeeId == "" {
+		user, ok := r.Context().Value("user").(*domain.User)
+		if !ok || user == nil {
+			return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+		}
+		employeeId = user.Id
+	}
+
+	var req struct {
+		Type      string `json:"type"`
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		HalfDay   string `json:"half_day"`
+		Note      string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := command.RequestAbsenceCommand{
+		EmployeeId: employeeId,
+		Type:       req.Type,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		HalfDay:    req.HalfDay,
+		Note:       req.Note,
+	}
+	absence, err := h.RequestAbsenceHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusCreated, absence)
+}
+
+func (h *AbsenceHandler) ApproveAbsence(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	cmd := command.ApproveAbsenceCommand{Id: chi.URLParam(r, "id"), DecidedBy: user.Id}
+	absence, err := h.ApproveAbsenceHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absence)
+}
+
+func (h *AbsenceHandler) DenyAbsence(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := command.DenyAbsenceCommand{Id: chi.URLParam(r, "id"), DecidedBy: user.Id, Reason: req.Reason}
+	absence, err := h.DenyAbsenceHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absence)
+}
+
+func (h *AbsenceHandler) CancelAbsence(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	cmd := command.CancelAbsenceCommand{Id: chi.URLParam(r, "id"), UserId: user.Id}
+	absence, err := h.CancelAbsenceHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absence)
+}
+
+func (h *AbsenceHandler) GetOwnAbsences(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	filter, page, err := parseListRequest(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	filter.UserId = user.Id
+
+	absences, err := h.GetAbsencesHandler.Handle(r.Context(), query.GetAbsencesQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absences)
+}
+
+func (h *AbsenceHandler) GetOwnAbsence(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	absenceQuery := query.GetAbsenceQuery{Id: chi.URLParam(r, "id"), UserId: user.Id}
+	absence, err := h.GetAbsenceHandler.Handle(r.Context(), absenceQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absence)
+}
+
+// GetAbsences lists the absences of every user, filtered by user_id, type, status, from and to
+func (h *AbsenceHandler) GetAbsences(w http.ResponseWriter, r *http.Request) error {
+	filter, page, err := parseListRequest(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	absences, err := h.GetAbsencesHandler.Handle(r.Context(), query.GetAbsencesQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absences)
+}
+
+func (h *AbsenceHandler) GetAbsence(w http.ResponseWriter, r *http.Request) error {
+	absence, err := h.GetAbsenceHandler.Handle(r.Context(), query.GetAbsenceQuery{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, absence)
+}
+
+// GetOwnCalendar lists the days from the from to the to query parameter the user is absent on
+func (h *AbsenceHandler) GetOwnCalendar(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	calendarQuery := query.GetCalendarQuery{
+		UserId: user.Id,
+		From:   r.URL.Query().Get("from"),
+		To:     r.URL.Query().Get("to"),
+	}
+	calendar, err := h.GetCalendarHandler.Handle(r.Context(), calendarQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, calendar)
+}
+
+// GetCalendar lists who is absent on each day from the from to the to query parameter,
+// optionally for a single user_id
+func (h *AbsenceHandler) GetCalendar(w http.ResponseWriter, r *http.Request) error {
+	calendarQuery := query.GetCalendarQuery{
+		UserId: r.URL.Query().Get("user_id"),
+		From:   r.URL.Query().Get("from"),
+		To:     r.URL.Query().Get("to"),
+	}
+	calendar, err := h.GetCalendarHandler.Handle(r.Context(), calendarQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, calendar)
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"time"
+	"time-management/internal/absence/domain"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+)
+
+// parseListRequest reads the filter and pagination query parameters of the absence list endpoints
+func parseListRequest(r *http.Request) (domain.AbsenceFilter, pagination.Request, error) {
+	page, err := pagination.FromRequest(r)
+	if err != nil {
+		return domain.AbsenceFilter{}, pagination.Request{}, err
+	}
+
+	filter, err := parseAbsenceFilter(r)
+	if err != nil {
+		return domain.AbsenceFilter{}, pagination.Request{}, err
+	}
+
+	return filter, page, nil
+}
+
+// parseAbsenceFilter reads the user_id, type, status, from and to query parameters. From
+// and to are dates, both included, absences overlapping them are listed.
+func parseAbsenceFilter(r *http.Request) (domain.AbsenceFilter, error) {
+	values := r.URL.Query()
+	filter := domain.AbsenceFilter{UserId: values.Get("user_id")}
+
+	if value := values.Get("type"); value != "" {
+		leaveType, err := domain.ParseLeaveType(value)
+		if err != nil {
+			return domain.AbsenceFilter{}, util.NewFieldError("type", err)
+		}
+		filter.Type = leaveType
+	}
+	if value := values.Get("status"); value != "" {
+		status, err := domain.ParseStatus(value)
+		if err != nil {
+			return domain.AbsenceFilter{}, util.NewFieldError("status", err)
+		}
+		filter.Statuses = []domain.Status{status}
+	}
+	if from := values.Get("from"); from != "" {
+		if _, err := time.Parse(domain.DateLayout, from); err != nil {
+			return domain.AbsenceFilter{}, util.NewFieldError("from", domain.ErrInvalidDate)
+		}
+		filter.From = from
+	}
+	if to := values.Get("to"); to != "" {
+		if _, err := time.Parse(domain.DateLayout, to); err != nil {
+			return domain.AbsenceFilter{}, util.NewFieldError("to", domain.ErrInvalidDate)
+		}
+		filter.To = to
+	}
+	if filter.From != "" && filter.To != "" && filter.From > filter.To {
+		return domain.AbsenceFilter{}, util.NewValidationError(domain.ErrInvalidDateRange)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+	"time"
+	"time-management/internal/compliance/domain"
+	"time-management/internal/shared/util"
+)
+
+type CheckWorkDayQuery struct {
+	UserId string
+	Date   string
+	// Period is work about to be stored, it is evaluated with the stored work
+	Period *domain.WorkPeriod
+	// ExcludeReportId leaves out the stored version of a report that is being replaced
+	ExcludeReportId string
+	WithTimeEntries bool
+}
+
+// CheckWorkDayHandler evaluates the rules a change of the work on a date can break
+type CheckWorkDayHandler struct {
+	Repo  domain.WorkPeriodRepository
+	Rules domain.RuleSet
+}
+
+// Handle returns the violations concerning the date. The weeks around it are loaded, so
+// the weekly limit and the rest before and after the day can be judged.
+func (h *CheckWorkDayHandler) Handle(ctx context.Context, query CheckWorkDayQuery) ([]domain.Violation, error) {
+	date, err := time.Parse(domain.DateLayout, query.Date)
+	if err != nil {
+		return nil, util.NewFieldError("work_date", domain.ErrInvalidDate)
+	}
+
+	from, to := domain.Window(date, date.AddDate(0, 0, 1))
+	periods, err := h.Repo.GetWorkPeriods(ctx, domain.WorkPeriodFilter{
+		UserId:          query.UserId,
+		From:            from.Format(domain.DateLayout),
+		To:              to.Format(domain.DateLayout),
+		ExcludeReportId: query.ExcludeReportId,
+		WithTimeEntries: query.WithTimeEntries,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if query.Period != nil {
+		periods = append(periods, *query.Period)
+	}
+
+	var violations []domain.Violation
+	for _, v := range h.Rules.Evaluate(domain.GroupWorkDays(periods)) {
+		if v.Concerns(query.Date) {
+			violations = append(violations, v)
+		}
+	}
+
+	return violations, nil
+}
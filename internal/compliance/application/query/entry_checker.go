@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+	"time"
+	"time-management/internal/compliance/domain"
+	teDomain "time-management/internal/timeentry/domain"
+)
+
+// EntryChecker checks the day of a closed time entry against the working time rules.
+// Entries not converted yet are counted next to the reports of the user.
+type EntryChecker struct {
+	Days CheckWorkDayHandler
+}
+
+func (c *EntryChecker) CheckEntry(ctx context.Context, entry *teDomain.TimeEntry) ([]teDomain.ComplianceWarning, error) {
+	date := time.Unix(int64(entry.ClockedInAt), 0).In(time.Local).Format(domain.DateLayout)
+
+	violations, err := c.Days.Handle(ctx, CheckWorkDayQuery{
+		UserId:          entry.User.Id,
+		Date:            date,
+		WithTimeEntries: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []teDomain.ComplianceWarning
+	for _, v := range violations {
+		warnings = append(warnings, teDomain.ComplianceWarning{
+			Rule:    string(v.Rule),
+			Period:  v.Period,
+			Message: v.Message,
+		})
+	}
+
+	return warnings, nil
+}
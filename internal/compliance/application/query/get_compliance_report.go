@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"time"
+	"time-management/internal/compliance/domain"
+	"time-management/internal/shared/util"
+)
+
+// maxReportDays limits how long a period a single compliance report can cover
+const maxReportDays = 366
+
+type GetComplianceReportQuery struct {
+	UserId string
+	From   string
+	To     string
+}
+
+type GetComplianceReportHandler struct {
+	Repo  domain.WorkPeriodRepository
+	Rules domain.RuleSet
+}
+
+// Handle evaluates reports and unconverted time entries, the weeks the range touches are
+// loaded in full so the weekly limit is judged on whole weeks
+func (h *GetComplianceReportHandler) Handle(
+	ctx context.Context,
+	query GetComplianceReportQuery,
+) (*domain.ComplianceReport, error) {
+	if query.From == "" || query.To == "" {
+		return nil, util.NewValidationError(domain.ErrDateRangeRequired)
+	}
+	from, err := time.Parse(domain.DateLayout, query.From)
+	if err != nil {
+		return nil, util.NewFieldError("from", domain.ErrInvalidDate)
+	}
+	to, err := time.Parse(domain.DateLayout, query.To)
+	if err != nil {
+		return nil, util.NewFieldError("to", domain.ErrInvalidDate)
+	}
+	if from.After(to) {
+		return nil, util.NewValidationError(domain.ErrInvalidDateRange)
+	}
+	if to.Sub(from).Hours()/24 >= maxReportDays {
+		return nil, util.NewValidationError(domain.ErrDateRangeTooLong)
+	}
+
+	windowFrom, windowTo := domain.Window(from, to)
+	periods, err := h.Repo.GetWorkPeriods(ctx, domain.WorkPeriodFilter{
+		UserId:          query.UserId,
+		From:            windowFrom.Format(domain.DateLayout),
+		To:              windowTo.Format(domain.DateLayout),
+		WithTimeEntries: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	days := domain.GroupWorkDays(periods)
+
+	return domain.NewComplianceReport(h.Rules, query.From, query.To, days, h.Rules.Evaluate(days)), nil
+}
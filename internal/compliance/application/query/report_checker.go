@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time-management/internal/compliance/domain"
+	reportDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+// ReportChecker checks reports against the working time rules. Only reports are taken
+// into account, time entries are counted once they are converted.
+type ReportChecker struct {
+	Days CheckWorkDayHandler
+}
+
+func (c *ReportChecker) CheckReport(
+	ctx context.Context,
+	report *reportDomain.Report,
+) ([]reportDomain.ComplianceWarning, error) {
+	date := report.WorkDate.String()
+	start, end := domain.ShiftTimes(date, report.StartTime, report.EndTime)
+
+	violations, err := c.Days.Handle(ctx, CheckWorkDayQuery{
+		UserId: report.User.Id,
+		Date:   date,
+		Period: &domain.WorkPeriod{
+			User:    domain.User{Id: report.User.Id},
+			Date:    date,
+			Minutes: report.TotalMinutes(),
+			Start:   start,
+			End:     end,
+		},
+		ExcludeReportId: report.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reportWarnings(violations)
+}
+
+func (c *ReportChecker) CheckStoredReport(
+	ctx context.Context,
+	reportId string,
+) ([]reportDomain.ComplianceWarning, error) {
+	period, err := c.Days.Repo.GetReportPeriod(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+	// Missing reports are left to the command to reject
+	if period == nil {
+		return nil, nil
+	}
+
+	violations, err := c.Days.Handle(ctx, CheckWorkDayQuery{UserId: period.User.Id, Date: period.Date})
+	if err != nil {
+		return nil, err
+	}
+
+	return reportWarnings(violations)
+}
+
+// reportWarnings rejects the report when it breaks a hard rule and returns the other
+// violations as warnings
+func reportWarnings(violations []domain.Violation) ([]reportDomain.ComplianceWarning, error) {
+	var fields []util.FieldError
+	var warnings []reportDomain.ComplianceWarning
+	for _, v := range violations {
+		if v.Severity == domain.SeverityError {
+			fields = append(fields, util.FieldError{Field: "work_date", Err: v.Err()})
+			continue
+		}
+		warnings = append(warnings, reportDomain.ComplianceWarning{
+			Rule:    string(v.Rule),
+			Period:  v.Period,
+			Message: v.Message,
+		})
+	}
+
+	if len(fields) > 0 {
+		err := fmt.Errorf("%w: %s", domain.ErrComplianceViolation, fields[0].Err)
+		return nil, util.NewValidationError(err, fields...)
+	}
+
+	return warnings, nil
+}
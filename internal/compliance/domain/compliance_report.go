@@ -0,0 +1,41 @@
+package domain
+
+// ComplianceReport lists the violations of each employee within a date range
+type ComplianceReport struct {
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	RuleSet   RuleSet              `json:"rule_set"`
+	Employees []EmployeeViolations `json:"employees"`
+}
+
+type EmployeeViolations struct {
+	User       User        `json:"user"`
+	Violations []Violation `json:"violations"`
+}
+
+// NewComplianceReport keeps the violations within the range and groups them by employee,
+// employees without violations are left out
+func NewComplianceReport(rules RuleSet, from, to string, days []WorkDay, violations []Violation) *ComplianceReport {
+	users := map[string]User{}
+	for _, day := range days {
+		users[day.User.Id] = day.User
+	}
+
+	report := &ComplianceReport{From: from, To: to, RuleSet: rules, Employees: []EmployeeViolations{}}
+	index := map[string]int{}
+	for _, v := range violations {
+		if !v.Within(from, to) {
+			continue
+		}
+
+		i, ok := index[v.UserId]
+		if !ok {
+			i = len(report.Employees)
+			index[v.UserId] = i
+			report.Employees = append(report.Employees, EmployeeViolations{User: users[v.UserId]})
+		}
+		report.Employees[i].Violations = append(report.Employees[i].Violations, v)
+	}
+
+	return report
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "errors"
+
+var (
+	ErrComplianceViolation = errors.New("working time rules violated")
+	ErrMaxDailyExceeded    = errors.New("daily working time limit exceeded")
+	ErrMaxWeeklyExceeded   = errors.New("weekly working time limit exceeded")
+	ErrBreakTooShort       = errors.New("break too short")
+	ErrRestTooShort        = errors.New("rest between working days too short")
+	ErrInvalidBreakRule    = errors.New("invalid break rule: expected minutes worked:break minutes")
+	ErrUnknownRule         = errors.New("unknown working time rule")
+	ErrUnknownRuleSet      = errors.New("unknown working time rule set")
+	ErrInvalidDate         = errors.New("invalid date: expected format YYYY-MM-DD")
+	ErrDateRangeRequired   = errors.New("date range required: from and to must be set")
+	ErrInvalidDateRange    = errors.New("invalid date range: from must not be after to")
+	ErrDateRangeTooLong    = errors.New("date range too long: at most 366 days")
+)
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrComplianceViolation: "compliance_violation",
+	ErrMaxDailyExceeded:    "max_daily_exceeded",
+	ErrMaxWeeklyExceeded:   "max_weekly_exceeded",
+	ErrBreakTooShort:       "break_too_short",
+	ErrRestTooShort:        "rest_too_short",
+	ErrInvalidBreakRule:    "break_rule_invalid",
+	ErrUnknownRule:         "rule_unknown",
+	ErrUnknownRuleSet:      "rule_set_unknown",
+	ErrInvalidDate:         "date_invalid",
+	ErrDateRangeRequired:   "date_range_required",
+	ErrInvalidDateRange:    "date_range_invalid",
+	ErrDateRangeTooLong:    "date_range_too_long",
+}
+
+// ruleErrors are the errors violations of each rule wrap
+var ruleErrors = map[Rule]error{
+	RuleMaxDaily:  ErrMaxDailyExceeded,
+	RuleMaxWeekly: ErrMaxWeeklyExceeded,
+	RuleBreak:     ErrBreakTooShort,
+	RuleMinRest:   ErrRestTooShort,
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rule names a working time rule
+type Rule string
+
+const (
+	RuleMaxDaily  Rule = "max_daily"
+	RuleMaxWeekly Rule = "max_weekly"
+	RuleBreak     Rule = "break"
+	RuleMinRest   Rule = "min_rest"
+)
+
+// Severity tells whether a violation rejects a report or only warns about it
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// BreakRule requires breaks of BreakMinutes in total once more than AfterMinutes were
+// worked on a day
+type BreakRule struct {
+	AfterMinutes uint64 `json:"after_minutes"`
+	BreakMinutes uint64 `json:"break_minutes"`
+}
+
+// RuleSet holds the working time limits of an organisation. Zero limits are not checked.
+type RuleSet struct {
+	Name             string      `json:"name"`
+	MaxDailyMinutes  uint64      `json:"max_daily_minutes"`
+	MaxWeeklyMinutes uint64      `json:"max_weekly_minutes"`
+	MinRestMinutes   uint64      `json:"min_rest_minutes"`
+	Breaks           []BreakRule `json:"breaks"`
+	// Hard lists the rules whose violations are errors, the others are warnings
+	Hard []Rule `json:"hard"`
+}
+
+// RuleSets are the presets deployments pick from. They follow the EU working time
+// directive and the German Arbeitszeitgesetz as a starting point, not as legal advice.
+var RuleSets = map[string]RuleSet{
+	"none": {Name: "none"},
+	"eu": {
+		Name:             "eu",
+		MaxWeeklyMinutes: 48 * 60,
+		MinRestMinutes:   11 * 60,
+		Breaks:           []BreakRule{{AfterMinutes: 6 * 60, BreakMinutes: 15}},
+	},
+	"de": {
+		Name:             "de",
+		MaxDailyMinutes:  10 * 60,
+		MaxWeeklyMinutes: 48 * 60,
+		MinRestMinutes:   11 * 60,
+		Breaks: []BreakRule{
+			{AfterMinutes: 6 * 60, BreakMinutes: 30},
+			{AfterMinutes: 9 * 60, BreakMinutes: 45},
+		},
+		Hard: []Rule{RuleMaxDaily},
+	},
+}
+
+// Enabled reports whether the rule set checks anything at all
+func (rs RuleSet) Enabled() bool {
+	return rs.MaxDailyMinutes > 0 || rs.MaxWeeklyMinutes > 0 || rs.MinRestMinutes > 0 || len(rs.Breaks) > 0
+}
+
+// Severity returns the severity of violations of the rule
+func (rs RuleSet) Severity(rule Rule) Severity {
+	for _, hard := range rs.Hard {
+		if hard == rule {
+			return SeverityError
+		}
+	}
+
+	return SeverityWarning
+}
+
+// RequiredBreak is the break time due after working the given minutes on a day
+func (rs RuleSet) RequiredBreak(workedMinutes uint64) uint64 {
+	var required uint64
+	for _, b := range rs.Breaks {
+		if workedMinutes > b.AfterMinutes && b.BreakMinutes > required {
+			required = b.BreakMinutes
+		}
+	}
+
+	return required
+}
+
+// ParseBreakRules parses break rules written as minutes worked:break minutes, separated
+// by commas, e.g. 360:30,540:45
+func ParseBreakRules(value string) ([]BreakRule, error) {
+	var rules []BreakRule
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		after, length, ok := strings.Cut(part, ":")
+		afterMinutes, err := strconv.ParseUint(strings.TrimSpace(after), 10, 64)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidBreakRule, part)
+		}
+		breakMinutes, err := strconv.ParseUint(strings.TrimSpace(length), 10, 64)
+		if err != nil || breakMinutes == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidBreakRule, part)
+		}
+
+		rules = append(rules, BreakRule{AfterMinutes: afterMinutes, BreakMinutes: breakMinutes})
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].AfterMinutes < rules[j].AfterMinutes })
+
+	return rules, nil
+}
+
+// ParseRules parses a comma separated list of rule names
+func ParseRules(value string) ([]Rule, error) {
+	var rules []Rule
+	for _, part := range strings.Split(value, ",") {
+		rule := Rule(strings.TrimSpace(part))
+		switch rule {
+		case "":
+		case RuleMaxDaily, RuleMaxWeekly, RuleBreak, RuleMinRest:
+			rules = append(rules, rule)
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownRule, rule)
+		}
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(date, clock string) *time.Time {
+	t, _ := time.ParseInLocation(DateLayout+" 15:04", date+" "+clock, time.Local)
+	return &t
+}
+
+func TestRuleSet_Evaluate_DailyBreakAndRest(t *testing.T) {
+	rules := RuleSets["de"]
+	user := User{Id: "usr1"}
+	days := GroupWorkDays([]WorkPeriod{
+		{User: user, Date: "2024-03-04", Minutes: 660, Start: at("2024-03-04", "07:00"), End: at("2024-03-04", "18:30")},
+		{User: user, Date: "2024-03-05", Minutes: 240, Start: at("2024-03-05", "04:00"), End: at("2024-03-05", "08:00")},
+	})
+
+	// Execute test
+	violations := rules.Evaluate(days)
+
+	// Assertions
+	assert.Len(t, violations, 3)
+	assert.Equal(t, RuleMaxDaily, violations[0].Rule)
+	assert.Equal(t, SeverityError, violations[0].Severity)
+	assert.Equal(t, uint64(660), violations[0].Actual)
+	assert.Equal(t, RuleBreak, violations[1].Rule)
+	assert.Equal(t, SeverityWarning, violations[1].Severity)
+	assert.Equal(t, uint64(30), violations[1].Actual)
+	assert.Equal(t, uint64(45), violations[1].Limit)
+	assert.Equal(t, RuleMinRest, violations[2].Rule)
+	assert.Equal(t, "2024-03-05", violations[2].Period)
+	assert.Equal(t, uint64(570), violations[2].Actual)
+	assert.ErrorIs(t, violations[0].Err(), ErrMaxDailyExceeded)
+}
+
+func TestRuleSet_Evaluate_Weekly(t *testing.T) {
+	rules := RuleSet{MaxWeeklyMinutes: 48 * 60}
+	var periods []WorkPeriod
+	for _, date := range []string{"2024-03-04", "2024-03-05", "2024-03-06", "2024-03-07", "2024-03-08", "2024-03-09"} {
+		periods = append(periods, WorkPeriod{User: User{Id: "usr1"}, Date: date, Minutes: 540})
+	}
+
+	// Execute test
+	violations := rules.Evaluate(GroupWorkDays(periods))
+
+	// Assertions
+	assert.Len(t, violations, 1)
+	assert.Equal(t, RuleMaxWeekly, violations[0].Rule)
+	assert.Equal(t, "2024-W10", violations[0].Period)
+	assert.Equal(t, uint64(3240), violations[0].Actual)
+	assert.True(t, violations[0].Concerns("2024-03-10"))
+	assert.False(t, violations[0].Concerns("2024-03-11"))
+	assert.True(t, violations[0].Within("2024-03-08", "2024-03-31"))
+}
+
+func TestGroupWorkDays_KeepsTimesOnlyWhenComplete(t *testing.T) {
+	periods := []WorkPeriod{
+		{User: User{Id: "usr2"}, Date: "2024-03-04", Minutes: 60},
+		{User: User{Id: "usr1"}, Date: "2024-03-05", Minutes: 120, Start: at("2024-03-05", "13:00"), End: at("2024-03-05", "15:00")},
+		{User: User{Id: "usr1"}, Date: "2024-03-05", Minutes: 180, Start: at("2024-03-05", "08:00"), End: at("2024-03-05", "11:00")},
+		{User: User{Id: "usr1"}, Date: "2024-03-06", Minutes: 60, Start: at("2024-03-06", "08:00"), End: at("2024-03-06", "09:00")},
+		{User: User{Id: "usr1"}, Date: "2024-03-06", Minutes: 30},
+	}
+
+	// Execute test
+	days := GroupWorkDays(periods)
+
+	// Assertions
+	assert.Len(t, days, 3)
+	assert.Equal(t, "usr1", days[0].User.Id)
+	assert.Equal(t, uint64(300), days[0].Minutes)
+	taken, known := days[0].BreakMinutes()
+	assert.True(t, known)
+	assert.Equal(t, uint64(120), taken)
+	assert.Nil(t, days[1].Start)
+	_, known = days[1].BreakMinutes()
+	assert.False(t, known)
+	assert.Equal(t, "usr2", days[2].User.Id)
+}
+
+func TestParseBreakRules(t *testing.T) {
+	// Execute test
+	rules, err := ParseBreakRules("540:45, 360:30")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, []BreakRule{{AfterMinutes: 360, BreakMinutes: 30}, {AfterMinutes: 540, BreakMinutes: 45}}, rules)
+	assert.Equal(t, uint64(0), RuleSet{Breaks: rules}.RequiredBreak(360))
+	assert.Equal(t, uint64(45), RuleSet{Breaks: rules}.RequiredBreak(541))
+
+	_, err = ParseBreakRules("360")
+	assert.ErrorIs(t, err, ErrInvalidBreakRule)
+	_, err = ParseRules("max_daily,lunch")
+	assert.ErrorIs(t, err, ErrUnknownRule)
+}
+
+func TestWindow(t *testing.T) {
+	from, _ := time.Parse(DateLayout, "2024-03-04")
+	to, _ := time.Parse(DateLayout, "2024-03-06")
+
+	// Execute test
+	start, end := Window(from, to)
+
+	// Assertions
+	assert.Equal(t, "2024-02-26", start.Format(DateLayout))
+	assert.Equal(t, "2024-03-10", end.Format(DateLayout))
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Violation is a broken working time rule. Period is the work date, or the ISO week for
+// the weekly limit; rest violations are dated on the day the rest ended.
+type Violation struct {
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	UserId   string   `json:"user_id"`
+	Period   string   `json:"period"`
+	// Actual and Limit are the worked, break or rest minutes and the limit they break
+	Actual  uint64 `json:"actual_minutes"`
+	Limit   uint64 `json:"limit_minutes"`
+	Message string `json:"message"`
+}
+
+// Err wraps the error of the rule with the message of the violation
+func (v Violation) Err() error {
+	return fmt.Errorf("%w: %s", ruleErrors[v.Rule], v.Message)
+}
+
+// Concerns reports whether changing the work on date can cause or resolve the violation
+func (v Violation) Concerns(date string) bool {
+	switch v.Rule {
+	case RuleMaxWeekly:
+		return v.Period == Week(date)
+	case RuleMinRest:
+		next, err := time.Parse(DateLayout, date)
+		if err != nil {
+			return false
+		}
+		return v.Period == date || v.Period == next.AddDate(0, 0, 1).Format(DateLayout)
+	default:
+		return v.Period == date
+	}
+}
+
+// Within reports whether the violation falls in the date range, weeks overlapping it
+// included
+func (v Violation) Within(from, to string) bool {
+	if v.Rule == RuleMaxWeekly {
+		return v.Period >= Week(from) && v.Period <= Week(to)
+	}
+
+	return v.Period >= from && v.Period <= to
+}
+
+// Evaluate checks the work days, ordered by user and date as GroupWorkDays returns them
+func (rs RuleSet) Evaluate(days []WorkDay) []Violation {
+	violations := []Violation{}
+	weekly := map[string]uint64{}
+	var weeks []struct{ userId, week string }
+
+	for i, day := range days {
+		if rs.MaxDailyMinutes > 0 && day.Minutes > rs.MaxDailyMinutes {
+			violations = append(violations, rs.violation(RuleMaxDaily, day.User.Id, day.Date, day.Minutes, rs.MaxDailyMinutes,
+				fmt.Sprintf("worked %s on %s, at most %s allowed",
+					clock(day.Minutes), day.Date, clock(rs.MaxDailyMinutes))))
+		}
+
+		if required := rs.RequiredBreak(day.Minutes); required > 0 {
+			if taken, known := day.BreakMinutes(); known && taken < required {
+				violations = append(violations, rs.violation(RuleBreak, day.User.Id, day.Date, taken, required,
+					fmt.Sprintf("took %d minutes of break on %s after working %s, at least %d required",
+						taken, day.Date, clock(day.Minutes), required)))
+			}
+		}
+
+		if rs.MinRestMinutes > 0 && i > 0 {
+			previous := days[i-1]
+			if previous.User.Id == day.User.Id && previous.End != nil && day.Start != nil {
+				rest := uint64(max(day.Start.Sub(*previous.End), 0).Minutes())
+				if rest < rs.MinRestMinutes {
+					violations = append(violations, rs.violation(RuleMinRest, day.User.Id, day.Date, rest, rs.MinRestMinutes,
+						fmt.Sprintf("rested %s before %s, at least %s required",
+							clock(rest), day.Date, clock(rs.MinRestMinutes))))
+				}
+			}
+		}
+
+		week := Week(day.Date)
+		key := day.User.Id + "/" + week
+		if _, ok := weekly[key]; !ok {
+			weeks = append(weeks, struct{ userId, week string }{day.User.Id, week})
+		}
+		weekly[key] += day.Minutes
+	}
+
+	if rs.MaxWeeklyMinutes > 0 {
+		for _, w := range weeks {
+			worked := weekly[w.userId+"/"+w.week]
+			if worked > rs.MaxWeeklyMinutes {
+				violations = append(violations, rs.violation(RuleMaxWeekly, w.userId, w.week, worked, rs.MaxWeeklyMinutes,
+					fmt.Sprintf("worked %s in %s, at most %s allowed",
+						clock(worked), w.week, clock(rs.MaxWeeklyMinutes))))
+			}
+		}
+	}
+
+	return violations
+}
+
+func (rs RuleSet) violation(rule Rule, userId, period string, actual, limit uint64, message string) Violation {
+	return Violation{
+		Rule:     rule,
+		Severity: rs.Severity(rule),
+		UserId:   userId,
+		Period:   period,
+		Actual:   actual,
+		Limit:    limit,
+		Message:  message,
+	}
+}
+
+// clock formats minutes as 7:05
+func clock(minutes uint64) string {
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
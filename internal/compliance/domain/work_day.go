@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DateLayout is the format of work dates
+const DateLayout = "2006-01-02"
+
+type User struct {
+	Id        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// WorkPeriod is a stretch of work of a user, taken from a report or a time entry. Start
+// and End are only known for reports with shift times and for time entries.
+type WorkPeriod struct {
+	User    User
+	Date    string
+	Minutes uint64
+	Start   *time.Time
+	End     *time.Time
+}
+
+// WorkDay sums the periods a user worked on a date. Start and End are the first start
+// and the last end, and only set when every period of the day has them.
+type WorkDay struct {
+	User    User
+	Date    string
+	Minutes uint64
+	Start   *time.Time
+	End     *time.Time
+}
+
+// BreakMinutes is the time between the first start and the last end that was not worked,
+// the second value is false when the day has no complete times
+func (d WorkDay) BreakMinutes() (uint64, bool) {
+	if d.Start == nil || d.End == nil {
+		return 0, false
+	}
+
+	span := uint64(d.End.Sub(*d.Start).Minutes())
+	if span < d.Minutes {
+		return 0, true
+	}
+
+	return span - d.Minutes, true
+}
+
+// GroupWorkDays sums the periods into work days ordered by user and date
+func GroupWorkDays(periods []WorkPeriod) []WorkDay {
+	type key struct{ userId, date string }
+	index := map[key]int{}
+	complete := map[key]bool{}
+	var days []WorkDay
+
+	for _, p := range periods {
+		k := key{p.User.Id, p.Date}
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(days)
+			complete[k] = true
+			days = append(days, WorkDay{User: p.User, Date: p.Date})
+			i = len(days) - 1
+		}
+
+		day := &days[i]
+		day.Minutes += p.Minutes
+		if p.Start == nil || p.End == nil {
+			complete[k] = false
+			continue
+		}
+		if day.Start == nil || p.Start.Before(*day.Start) {
+			day.Start = p.Start
+		}
+		if day.End == nil || p.End.After(*day.End) {
+			day.End = p.End
+		}
+	}
+
+	for k, i := range index {
+		if !complete[k] {
+			days[i].Start = nil
+			days[i].End = nil
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].User.Id != days[j].User.Id {
+			return days[i].User.Id < days[j].User.Id
+		}
+		return days[i].Date < days[j].Date
+	})
+
+	return days
+}
+
+// Week returns the ISO week of a date as 2024-W05
+func Week(date string) string {
+	t, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return ""
+	}
+
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// Window widens a date range to the days the rules need to judge it: whole ISO weeks for
+// the weekly limit and the day before for the rest period
+func Window(from, to time.Time) (time.Time, time.Time) {
+	start := from.AddDate(0, 0, -1)
+	// Weeks start on Monday, Sunday is the seventh day
+	start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	end := to.AddDate(0, 0, (7-int(to.Weekday()))%7)
+
+	return start, end
+}
+
+// ShiftTimes turns the clock times of a shift on a work date into local times, nil when
+// either is missing or invalid
+func ShiftTimes(date string, start, end *string) (*time.Time, *time.Time) {
+	if start == nil || end == nil {
+		return nil, nil
+	}
+
+	startTime, err := time.ParseInLocation(DateLayout+" 15:04", date+" "+*start, time.Local)
+	if err != nil {
+		return nil, nil
+	}
+	endTime, err := time.ParseInLocation(DateLayout+" 15:04", date+" "+*end, time.Local)
+	if err != nil || !endTime.After(startTime) {
+		return nil, nil
+	}
+
+	return &startTime, &endTime
+}
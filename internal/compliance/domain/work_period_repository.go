@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// WorkPeriodFilter selects the periods to evaluate; zero values are ignored. From and To
+// are work dates, both included.
+type WorkPeriodFilter struct {
+	UserId string
+	From   string
+	To     string
+	// ExcludeReportId leaves out a report that is about to be replaced
+	ExcludeReportId string
+	// WithTimeEntries adds the closed time entries not converted into a report yet
+	WithTimeEntries bool
+}
+
+type WorkPeriodRepository interface {
+	// GetWorkPeriods returns the periods of reports that were not denied and, if asked,
+	// of time entries
+	GetWorkPeriods(ctx context.Context, filter WorkPeriodFilter) ([]WorkPeriod, error)
+	// GetReportPeriod returns the period of a stored report
+	GetReportPeriod(ctx context.Context, reportId string) (*WorkPeriod, error)
+}
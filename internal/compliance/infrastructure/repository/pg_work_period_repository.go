@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"time-management/internal/compliance/domain"
+	reportDomain "time-management/internal/report/domain"
+	reportPg "time-management/internal/report/infrastructure/repository"
+	timeEntryPg "time-management/internal/timeentry/infrastructure/repository"
+	userPg "time-management/internal/user/infrastructure/repository"
+)
+
+// selectReportPeriods reads the total time and shift times of reports, denied reports
+// are not work anyone is held to
+var selectReportPeriods = fmt.Sprintf(`
+	SELECT
+		u.id, u.first_name, u.last_name, u.email,
+		to_char(r.work_date, 'YYYY-MM-DD'), r.working_minutes + r.maintenance_minutes,
+		to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI')
+	FROM %s r
+	JOIN %s u ON r.user_id = u.id
+`, reportPg.TableName, userPg.TableName)
+
+// selectEntryPeriods reads closed time entries that were not converted into a report,
+// converted entries are counted through their report
+var selectEntryPeriods = fmt.Sprintf(`
+	SELECT
+		u.id, u.first_name, u.last_name, u.email,
+		e.clocked_in_at, e.clocked_out_at,
+		COALESCE((
+			SELECT SUM(b.ended_at - b.started_at) FROM %s b
+			WHERE b.entry_id = e.id AND b.ended_at IS NOT NULL
+		), 0)
+	FROM %s e
+	JOIN %s u ON e.user_id = u.id
+	WHERE e.clocked_out_at IS NOT NULL AND e.report_id IS NULL
+`, timeEntryPg.BreakTableName, timeEntryPg.TableName, userPg.TableName)
+
+type PgWorkPeriodRepository struct {
+	DB *sql.DB
+}
+
+func NewPgWorkPeriodRepository(db *sql.DB) *PgWorkPeriodRepository {
+	return &PgWorkPeriodRepository{DB: db}
+}
+
+func (r *PgWorkPeriodRepository) GetWorkPeriods(
+	ctx context.Context,
+	filter domain.WorkPeriodFilter,
+) ([]domain.WorkPeriod, error) {
+	periods, err := r.getReportPeriods(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.WithTimeEntries {
+		entries, err := r.getEntryPeriods(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, entries...)
+	}
+
+	return periods, nil
+}
+
+func (r *PgWorkPeriodRepository) GetReportPeriod(ctx context.Context, reportId string) (*domain.WorkPeriod, error) {
+	query := selectReportPeriods + " WHERE r.id = $1"
+
+	period, err := scanReportPeriod(r.DB.QueryRowContext(ctx, query, reportId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return period, nil
+}
+
+func (r *PgWorkPeriodRepository) getReportPeriods(
+	ctx context.Context,
+	filter domain.WorkPeriodFilter,
+) ([]domain.WorkPeriod, error) {
+	args := []any{int(reportDomain.Denied)}
+	conditions := []string{"r.status <> $1"}
+
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("r.user_id = $%d", len(args)))
+	}
+	if filter.From != "" {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("r.work_date >= $%d", len(args)))
+	}
+	if filter.To != "" {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("r.work_date <= $%d", len(args)))
+	}
+	if filter.ExcludeReportId != "" {
+		args = append(args, filter.ExcludeReportId)
+		conditions = append(conditions, fmt.Sprintf("r.id <> $%d", len(args)))
+	}
+
+	query := selectReportPeriods + " WHERE " + strings.Join(conditions, " AND ")
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []domain.WorkPeriod{}
+	for rows.Next() {
+		period, err := scanReportPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, *period)
+	}
+
+	return periods, rows.Err()
+}
+
+// getEntryPeriods dates entries on the local day they were clocked in
+func (r *PgWorkPeriodRepository) getEntryPeriods(
+	ctx context.Context,
+	filter domain.WorkPeriodFilter,
+) ([]domain.WorkPeriod, error) {
+	var args []any
+	var conditions []string
+
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("e.user_id = $%d", len(args)))
+	}
+	if filter.From != "" {
+		from, err := time.ParseInLocation(domain.DateLayout, filter.From, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, from.Unix())
+		conditions = append(conditions, fmt.Sprintf("e.clocked_in_at >= $%d", len(args)))
+	}
+	if filter.To != "" {
+		to, err := time.ParseInLocation(domain.DateLayout, filter.To, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, to.AddDate(0, 0, 1).Unix())
+		conditions = append(conditions, fmt.Sprintf("e.clocked_in_at < $%d", len(args)))
+	}
+
+	query := selectEntryPeriods
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []domain.WorkPeriod{}
+	for rows.Next() {
+		var period domain.WorkPeriod
+		var clockedIn, clockedOut, breakSeconds int64
+		err := rows.Scan(
+			&period.User.Id, &period.User.FirstName, &period.User.LastName, &period.User.Email,
+			&clockedIn, &clockedOut, &breakSeconds,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Unix(clockedIn, 0).In(time.Local)
+		end := time.Unix(clockedOut, 0).In(time.Local)
+		period.Date = start.Format(domain.DateLayout)
+		period.Minutes = uint64(max(clockedOut-clockedIn-breakSeconds, 0) / 60)
+		period.Start = &start
+		period.End = &end
+		periods = append(periods, period)
+	}
+
+	return periods, rows.Err()
+}
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReportPeriod(row rowScanner) (*domain.WorkPeriod, error) {
+	var period domain.WorkPeriod
+	var start, end *string
+
+	err := row.Scan(
+		&period.User.Id, &period.User.FirstName, &period.User.LastName, &period.User.Email,
+		&period.Date, &period.Minutes,
+		&start, &end,
+	)
+	if err != nil {
+		return nil, err
+	}
+	period.Start, period.End = domain.ShiftTimes(period.Date, start, end)
+
+	return &period, nil
+}
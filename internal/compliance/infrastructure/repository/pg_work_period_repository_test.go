@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+	"time-management/internal/compliance/domain"
+	"time-management/internal/compliance/infrastructure/repository"
+)
+
+var reportPeriodColumns = []string{
+	"id", "first_name", "last_name", "email",
+	"work_date", "minutes", "start_time", "end_time",
+}
+
+var entryPeriodColumns = []string{
+	"id", "first_name", "last_name", "email",
+	"clocked_in_at", "clocked_out_at", "break_seconds",
+}
+
+func TestPgWorkPeriodRepository_GetWorkPeriods(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	from, _ := time.ParseInLocation(domain.DateLayout, "2024-03-04", time.Local)
+	clockedIn := from.Add(8 * time.Hour).Unix()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE r.status <> $1 AND r.user_id = $2 AND r.work_date >= $3 AND r.work_date <= $4 AND r.id <> $5`)).
+		WithArgs(2, "usr1", "2024-03-04", "2024-03-10", "rep9").
+		WillReturnRows(sqlmock.NewRows(reportPeriodColumns).
+			AddRow("usr1", "Jane", "Doe", "jane@example.com", "2024-03-05", 480, "08:00", "16:30").
+			AddRow("usr1", "Jane", "Doe", "jane@example.com", "2024-03-06", 300, nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE e.clocked_out_at IS NOT NULL AND e.report_id IS NULL AND e.user_id = $1 AND e.clocked_in_at >= $2 AND e.clocked_in_at < $3`)).
+		WithArgs("usr1", from.Unix(), from.AddDate(0, 0, 7).Unix()).
+		WillReturnRows(sqlmock.NewRows(entryPeriodColumns).
+			AddRow("usr1", "Jane", "Doe", "jane@example.com", clockedIn, clockedIn+5*3600, 1800))
+
+	// Execute test
+	periods, err := repo.GetWorkPeriods(context.Background(), domain.WorkPeriodFilter{
+		UserId:          "usr1",
+		From:            "2024-03-04",
+		To:              "2024-03-10",
+		ExcludeReportId: "rep9",
+		WithTimeEntries: true,
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, periods, 3)
+	assert.Equal(t, "Jane", periods[0].User.FirstName)
+	assert.Equal(t, uint64(480), periods[0].Minutes)
+	assert.Equal(t, "2024-03-05 16:30", periods[0].End.Format("2006-01-02 15:04"))
+	assert.Nil(t, periods[1].Start)
+	assert.Equal(t, "2024-03-04", periods[2].Date)
+	assert.Equal(t, uint64(270), periods[2].Minutes)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgWorkPeriodRepository_GetReportPeriod_NotFound(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE r.id = $1`)).
+		WithArgs("rep1").
+		WillReturnRows(sqlmock.NewRows(reportPeriodColumns))
+
+	// Execute test
+	period, err := repo.GetReportPeriod(context.Background(), "rep1")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Nil(t, period)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *repository.PgWorkPeriodRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	repo := repository.NewPgWorkPeriodRepository(db)
+	return mock, repo
+}
+
+// assertMockExpectations is a helper to ensure all expectations of the mock are met
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"strings"
+	"time-management/internal/compliance/domain"
+	"time-management/internal/shared/config"
+)
+
+// RuleSetFromConfig returns the configured preset with the configured limits, breaks and
+// hard rules in place of its own
+func RuleSetFromConfig(cfg config.Compliance) (domain.RuleSet, error) {
+	preset, ok := domain.RuleSets[cfg.RuleSet]
+	if !ok {
+		return domain.RuleSet{}, domain.ErrUnknownRuleSet
+	}
+	rules := preset
+	rules.Breaks = append([]domain.BreakRule(nil), preset.Breaks...)
+	rules.Hard = append([]domain.Rule(nil), preset.Hard...)
+
+	if cfg.MaxDailyMinutes > 0 {
+		rules.MaxDailyMinutes = uint64(cfg.MaxDailyMinutes)
+	}
+	if cfg.MaxWeeklyMinutes > 0 {
+		rules.MaxWeeklyMinutes = uint64(cfg.MaxWeeklyMinutes)
+	}
+	if cfg.MinRestMinutes > 0 {
+		rules.MinRestMinutes = uint64(cfg.MinRestMinutes)
+	}
+
+	if cfg.Breaks != "" {
+		breaks, err := domain.ParseBreakRules(cfg.Breaks)
+		if err != nil {
+			return domain.RuleSet{}, err
+		}
+		rules.Breaks = breaks
+	}
+
+	switch strings.TrimSpace(cfg.HardRules) {
+	case "":
+	case "none":
+		rules.Hard = nil
+	default:
+		hard, err := domain.ParseRules(cfg.HardRules)
+		if err != nil {
+			return domain.RuleSet{}, err
+		}
+		rules.Hard = hard
+	}
+
+	return rules, nil
+}
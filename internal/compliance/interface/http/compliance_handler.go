@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+	"time-management/internal/compliance/application/query"
+	"time-management/internal/compliance/domain"
+	"time-management/internal/shared/util"
+	userDomain "time-management/internal/user/domain"
+)
+
+type ComplianceHandler struct {
+	GetComplianceReportHandler query.GetComplianceReportHandler
+}
+
+func NewComplianceHandler(repository domain.WorkPeriodRepository, rules domain.RuleSet) *ComplianceHandler {
+	return &ComplianceHandler{
+		GetComplianceReportHandler: query.GetComplianceReportHandler{Repo: repository, Rules: rules},
+	}
+}
+
+// GetOwnComplianceReport lists the violations of the user between the from and to dates
+func (h *ComplianceHandler) GetOwnComplianceReport(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*userDomain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(userDomain.ErrUserNotFound))
+	}
+
+	params := r.URL.Query()
+	report, err := h.GetComplianceReportHandler.Handle(r.Context(), query.GetComplianceReportQuery{
+		UserId: user.Id,
+		From:   params.Get("from"),
+		To:     params.Get("to"),
+	})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, report)
+}
+
+// GetComplianceReport lists the violations of every employee, or of the one given as
+// user_id, between the from and to dates
+func (h *ComplianceHandler) GetComplianceReport(w http.ResponseWriter, r *http.Request) error {
+	params := r.URL.Query()
+	report, err := h.GetComplianceReportHandler.Handle(r.Context(), query.GetComplianceReportQuery{
+		UserId: params.Get("user_id"),
+		From:   params.Get("from"),
+		To:     params.Get("to"),
+	})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, report)
+}
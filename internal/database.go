@@ -1,60 +1,51 @@
 package internal
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"time-management/internal/shared/config"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
-	_ "github.com/joho/godotenv/autoload"
 )
 
-var (
-	database = os.Getenv("DB_DATABASE")
-	password = os.Getenv("DB_PASSWORD")
-	username = os.Getenv("DB_USERNAME")
-	port     = os.Getenv("DB_PORT")
-	host     = os.Getenv("DB_HOST")
-	schema   = os.Getenv("DB_SCHEMA")
-	db       *sql.DB
-)
+var db *sql.DB
 
 // InitializeDB sets up the connection pool for the database
-func InitializeDB() (*sql.DB, error) {
-	var err error
+func InitializeDB(cfg config.Database) (*sql.DB, error) {
 	if db != nil {
 		return db, nil // Return existing connection if already initialized
 	}
 
-	connStr := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable&search_path=%s",
-		username,
-		password,
-		host,
-		port,
-		database,
-		schema,
-	)
-
-	db, err := sql.Open("pgx", connStr)
+	pool, err := sql.Open("pgx", cfg.Dsn())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
+	pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
 	// Check if the connection is alive
-	if err := db.Ping(); err != nil {
+	if err := pool.PingContext(context.Background()); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("Database connection established.")
+	db = pool
+	slog.Info("database connection established")
 	return db, nil
 }
 
-// CloseDB closes the database connection
+// CloseDB closes the database connection. It must only be called once nothing uses the pool anymore.
 func CloseDB() error {
-	if db != nil {
-		return db.Close()
+	if db == nil {
+		return nil
 	}
-	return nil
+
+	err := db.Close()
+	db = nil
+	return err
 }
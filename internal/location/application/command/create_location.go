@@ -18,7 +18,7 @@ type CreateLocationHandler struct {
 
 func (h *CreateLocationHandler) Handle(ctx context.Context, cmd CreateLocationCommand) (*domain.Location, error) {
 	if cmd.Name == "" || len(cmd.Name) >= 50 {
-		return nil, util.NewValidationError(domain.ErrInvalidName)
+		return nil, util.NewFieldError("name", domain.ErrInvalidName)
 	}
 
 	location := domain.NewLocation(uuid.New().String(), cmd.Name, uint64(time.Now().Unix()))
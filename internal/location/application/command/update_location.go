@@ -18,7 +18,7 @@ type UpdateLocationHandler struct {
 func (h *UpdateLocationHandler) Handle(ctx context.Context, cmd UpdateLocationCommand) (*domain.Location, error) {
 	// Validation logic
 	if cmd.Name == "" || len(cmd.Name) >= 50 {
-		return nil, util.NewValidationError(domain.ErrInvalidName)
+		return nil, util.NewFieldError("name", domain.ErrInvalidName)
 	}
 
 	// Update the domain entity through the repository
@@ -3,21 +3,28 @@ package query
 import (
 	"context"
 	"time-management/internal/location/domain"
+	"time-management/internal/shared/pagination"
 )
 
+type GetLocationsQuery struct {
+	Filter domain.LocationFilter
+	Page   pagination.Request
+}
+
 type GetLocationsHandler struct {
 	Repo domain.LocationRepository
 }
 
-func (h *GetLocationsHandler) Handle(ctx context.Context) ([]domain.Location, error) {
-	locations, err := h.Repo.GetAll(ctx)
+func (h *GetLocationsHandler) Handle(
+	ctx context.Context,
+	query GetLocationsQuery,
+) (*pagination.Page[domain.Location], error) {
+	locations, total, err := h.Repo.GetAll(ctx, query.Filter, query.Page)
 	if err != nil {
 		return nil, err
 	}
 
-	if locations == nil {
-		return []domain.Location{}, nil
-	}
+	page := pagination.NewPage(locations, total, query.Page)
 
-	return locations, nil
+	return &page, nil
 }
@@ -6,3 +6,9 @@ var (
 	ErrLocationNotFound = errors.New("location not found")
 	ErrInvalidName      = errors.New("invalid location name")
 )
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrLocationNotFound: "location_not_found",
+	ErrInvalidName:      "location_name_invalid",
+}
@@ -1,10 +1,17 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+)
+
+type LocationFilter struct {
+	Search string
+}
 
 type LocationRepository interface {
 	Create(ctx context.Context, location *Location) (*Location, error)
-	GetAll(ctx context.Context) ([]Location, error)
+	GetAll(ctx context.Context, filter LocationFilter, page pagination.Request) ([]Location, int, error)
 	GetById(ctx context.Context, id string) (*Location, error)
 	Update(ctx context.Context, id, name string) (*Location, error)
 	Delete(ctx context.Context, id string) error
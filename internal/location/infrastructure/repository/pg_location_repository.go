@@ -6,36 +6,24 @@ import (
 	"errors"
 	"fmt"
 	"time-management/internal/location/domain"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
 )
 
 const TableName = "locations"
 
+// sortColumns maps the sortable location fields to their columns
+var sortColumns = map[string]string{
+	"name":       "name",
+	"created_at": "created_at",
+}
+
 type PgLocationRepository struct {
 	DB *sql.DB
 }
 
 func NewPgLocationRepository(db *sql.DB) *PgLocationRepository {
-	repository := &PgLocationRepository{DB: db}
-	err := repository.createLocationTable()
-	if err != nil {
-		panic(err)
-		return nil
-	}
-
-	return repository
-}
-
-func (r *PgLocationRepository) createLocationTable() error {
-	query := fmt.Sprintf(
-		`CREATE TABLE IF NOT EXISTS %s (
-		id VARCHAR(50) PRIMARY KEY,
-		name VARCHAR(50),
-		created_at SERIAL
-	)`, TableName)
-
-	_, err := r.DB.Exec(query)
-	return err
+	return &PgLocationRepository{DB: db}
 }
 
 func (r *PgLocationRepository) Create(ctx context.Context, location *domain.Location) (*domain.Location, error) {
@@ -54,21 +42,46 @@ func (r *PgLocationRepository) Create(ctx context.Context, location *domain.Loca
 	return savedLocation, nil
 }
 
-func (r *PgLocationRepository) GetAll(ctx context.Context) ([]domain.Location, error) {
-	query := fmt.Sprintf(`SELECT * FROM %s`, TableName)
+func (r *PgLocationRepository) GetAll(
+	ctx context.Context,
+	filter domain.LocationFilter,
+	page pagination.Request,
+) ([]domain.Location, int, error) {
+	where := ""
+	var args []any
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		where = "WHERE name ILIKE $1"
+	}
+
+	orderBy, err := page.OrderBy(sortColumns, "name ASC", "id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, TableName, where)
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
 
-	rows, err := r.DB.QueryContext(ctx, query)
+	query := fmt.Sprintf(
+		`SELECT * FROM %s %s %s LIMIT $%d OFFSET $%d`,
+		TableName, where, orderBy, len(args)+1, len(args)+2,
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, page.Limit, page.Offset)...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
 	locations, err := ScanLocationRows(rows)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return locations, nil
+	return locations, total, nil
 }
 
 func (r *PgLocationRepository) GetById(ctx context.Context, id string) (*domain.Location, error) {
@@ -78,7 +91,7 @@ func (r *PgLocationRepository) GetById(ctx context.Context, id string) (*domain.
 	location, err := ScanLocationRow(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, util.NewValidationError(domain.ErrLocationNotFound)
+			return nil, util.NewNotFoundError(domain.ErrLocationNotFound)
 		}
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"testing"
 	"time-management/internal/location/domain"
+	"time-management/internal/shared/pagination"
 )
 
 func TestPgLocationRepository_Create(t *testing.T) {
@@ -31,21 +32,26 @@ func TestPgLocationRepository_Create(t *testing.T) {
 func TestPgLocationRepository_GetAll(t *testing.T) {
 	mock, repo := setupMockAndRepo(t)
 
-	query := fmt.Sprintf(`SELECT * FROM %s`, TableName)
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, TableName)
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	query := fmt.Sprintf(`SELECT * FROM %s ORDER BY name ASC, id LIMIT $1 OFFSET $2`, TableName)
 	rows := sqlmock.NewRows([]string{"id", "name", "created_at"})
 
 	locations := []domain.Location{loc}
 	for _, loc := range locations {
 		rows.AddRow(loc.Id, loc.Name, loc.CreatedAt)
 	}
-	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(50, 0).WillReturnRows(rows)
 
 	// Execute test
 	ctx := context.Background()
-	fetchedLocations, err := repo.GetAll(ctx)
+	fetchedLocations, total, err := repo.GetAll(ctx, domain.LocationFilter{}, pagination.Request{Limit: 50})
 
 	// Assertions
 	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
 	assert.Len(t, fetchedLocations, 1)
 	assert.Equal(t, fetchedLocations[0].Id, locations[0].Id)
 	assert.Equal(t, fetchedLocations[0].Name, locations[0].Name)
@@ -53,6 +59,33 @@ func TestPgLocationRepository_GetAll(t *testing.T) {
 	assertMockExpectations(t, mock)
 }
 
+func TestPgLocationRepository_GetAll_SearchAndSort(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE name ILIKE $1`, TableName)
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs("%York%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	query := fmt.Sprintf(
+		`SELECT * FROM %s WHERE name ILIKE $1 ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`,
+		TableName,
+	)
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(loc.Id, loc.Name, loc.CreatedAt)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("%York%", 1, 2).WillReturnRows(rows)
+
+	// Execute test
+	ctx := context.Background()
+	page := pagination.Request{Limit: 1, Offset: 2, Sort: "created_at", Desc: true}
+	fetchedLocations, total, err := repo.GetAll(ctx, domain.LocationFilter{Search: "York"}, page)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, fetchedLocations, 1)
+	assertMockExpectations(t, mock)
+}
+
 func TestPgLocationRepository_GetById(t *testing.T) {
 	mock, repo := setupMockAndRepo(t)
 
@@ -115,9 +148,6 @@ func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *PgLocationRepository) {
 	assert.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
 
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS locations").
-		WillReturnResult(sqlmock.NewResult(0, 0))
-
 	repo := NewPgLocationRepository(db)
 	return mock, repo
 }
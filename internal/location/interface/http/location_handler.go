@@ -7,8 +7,8 @@ import (
 	"time-management/internal/location/application/command"
 	"time-management/internal/location/application/query"
 	locDomain "time-management/internal/location/domain"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
-	"time-management/internal/user/domain"
 )
 
 type LocationHandler struct {
@@ -34,21 +34,30 @@ func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request)
 		Name string `json:"name"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	location, err := h.CreateLocationHandler.Handle(r.Context(), command.CreateLocationCommand{Name: req.Name})
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusCreated, location)
 }
 
 func (h *LocationHandler) GetLocations(w http.ResponseWriter, r *http.Request) error {
-	locations, err := h.GetLocationsHandler.Handle(r.Context())
+	page, err := pagination.FromRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
+	}
+
+	locationsQuery := query.GetLocationsQuery{
+		Filter: locDomain.LocationFilter{Search: r.URL.Query().Get("search")},
+		Page:   page,
+	}
+	locations, err := h.GetLocationsHandler.Handle(r.Context(), locationsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, locations)
@@ -59,7 +68,7 @@ func (h *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) er
 
 	location, err := h.GetLocationHandler.Handle(r.Context(), query.GetLocationQuery{Id: id})
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, location)
@@ -71,9 +80,11 @@ func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request)
 		Name string `json:"name"`
 	}
 
-	err := json.NewDecoder(r.Body).Decode(&requestData)
-	if err != nil || requestData.Name == "" {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: "Invalid request body"})
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+	if requestData.Name == "" {
+		return util.WriteError(w, r, util.NewFieldError("name", locDomain.ErrInvalidName))
 	}
 
 	location, err := h.UpdateLocationHandler.Handle(
@@ -81,7 +92,7 @@ func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request)
 		command.UpdateLocationCommand{Id: id, Name: requestData.Name},
 	)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, location)
@@ -92,7 +103,7 @@ func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request)
 
 	err := h.DeleteLocationHandler.Handle(r.Context(), command.DeleteLocationCommand{Id: id})
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusNoContent, nil)
@@ -0,0 +1,12 @@
+package migration
+
+import "errors"
+
+var (
+	ErrInvalidFileName  = errors.New("invalid migration file name")
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+	ErrMissingUp        = errors.New("missing up migration")
+	ErrMissingDown      = errors.New("missing down migration")
+	ErrChecksumMismatch = errors.New("applied migration checksum does not match migration file")
+	ErrUnknownVersion   = errors.New("applied migration version is unknown")
+)
@@ -0,0 +1,273 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const TableName = "schema_migrations"
+
+// lockKey identifies the advisory lock held while migrations run, so that several
+// instances starting at once do not apply the same migration twice
+const lockKey int64 = 7_240_512_901
+
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt uint64
+}
+
+type appliedMigration struct {
+	Version   int64
+	Checksum  string
+	AppliedAt uint64
+}
+
+// NewMigrator creates a Migrator for the migrations bundled with the application
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pending(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			err := m.apply(ctx, conn, migration.Up, func(tx *sql.Tx) error {
+				query := fmt.Sprintf(
+					`INSERT INTO %s (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)`,
+					TableName,
+				)
+				_, err := tx.ExecContext(
+					ctx,
+					query,
+					migration.Version,
+					migration.Name,
+					migration.Checksum,
+					uint64(time.Now().Unix()),
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the latest steps applied migrations and returns the ones rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var rolledBack []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration, ok := m.find(applied[i].Version)
+			if !ok {
+				return fmt.Errorf("%w: %d", ErrUnknownVersion, applied[i].Version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%w: version %d", ErrMissingDown, migration.Version)
+			}
+
+			err := m.apply(ctx, conn, migration.Down, func(tx *sql.Tx) error {
+				query := fmt.Sprintf(`DELETE FROM %s WHERE version = $1`, TableName)
+				_, err := tx.ExecContext(ctx, query, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Status reports whether each known migration has been applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		appliedAt := make(map[int64]uint64, len(applied))
+		for _, a := range applied {
+			appliedAt[a.Version] = a.AppliedAt
+		}
+
+		for _, migration := range m.Migrations {
+			at, ok := appliedAt[migration.Version]
+			statuses = append(statuses, Status{Migration: migration, Applied: ok, AppliedAt: at})
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Pending returns the migrations that have not been applied yet
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	var pending []Migration
+
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		var err error
+		pending, err = m.pending(ctx, conn)
+		return err
+	})
+
+	return pending, err
+}
+
+func (m *Migrator) pending(ctx context.Context, conn *sql.Conn) ([]Migration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedVersions := make(map[int64]bool, len(applied))
+	for _, a := range applied {
+		appliedVersions[a.Version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range m.Migrations {
+		if !appliedVersions[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// applied returns the applied migrations after verifying they still match the migration files
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) ([]appliedMigration, error) {
+	query := fmt.Sprintf(`SELECT version, checksum, applied_at FROM %s ORDER BY version`, TableName)
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []appliedMigration
+	for rows.Next() {
+		var a appliedMigration
+		if err := rows.Scan(&a.Version, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+
+		migration, ok := m.find(a.Version)
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, a.Version)
+		}
+		if migration.Checksum != a.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+
+		applied = append(applied, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, script string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.Migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+
+	return Migration{}, false
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+		return fn(conn)
+	})
+}
+
+// withConn runs fn on a dedicated connection after making sure the migrations table exists
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at BIGINT NOT NULL
+		)`, TableName)
+
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
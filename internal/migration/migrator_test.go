@@ -0,0 +1,158 @@
+package migration
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFiles, "sql")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_items", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE items;", migrations[0].Down)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, checksum("ALTER TABLE items ADD COLUMN name TEXT;"), migrations[1].Checksum)
+}
+
+func TestLoad_InvalidFileName(t *testing.T) {
+	_, err := Load(fstest.MapFS{"sql/items.sql": {Data: []byte("SELECT 1;")}}, "sql")
+
+	// Assertions
+	assert.ErrorIs(t, err, ErrInvalidFileName)
+}
+
+func TestMigrations_Bundled(t *testing.T) {
+	migrations, err := Migrations()
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	for _, m := range migrations {
+		assert.NotEmpty(t, m.Down, "migration %d has no down file", m.Version)
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	mock, migrator := setupMockAndMigrator(t)
+
+	mockSchemaTable(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, checksum, applied_at FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).
+			AddRow(1, migrator.Migrations[0].Checksum, 123456789))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(migrator.Migrations[1].Up)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations`)).
+		WithArgs(int64(2), "add_name", migrator.Migrations[1].Checksum, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute test
+	applied, err := migrator.Up(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, int64(2), applied[0].Version)
+	assertMockExpectations(t, mock)
+}
+
+func TestMigrator_Up_ChecksumMismatch(t *testing.T) {
+	mock, migrator := setupMockAndMigrator(t)
+
+	mockSchemaTable(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, checksum, applied_at FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).
+			AddRow(1, "edited", 123456789))
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute test
+	applied, err := migrator.Up(context.Background())
+
+	// Assertions
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.Empty(t, applied)
+	assertMockExpectations(t, mock)
+}
+
+func TestMigrator_Down(t *testing.T) {
+	mock, migrator := setupMockAndMigrator(t)
+
+	mockSchemaTable(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, checksum, applied_at FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).
+			AddRow(1, migrator.Migrations[0].Checksum, 123456789).
+			AddRow(2, migrator.Migrations[1].Checksum, 123456790))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(migrator.Migrations[1].Down)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations WHERE version = $1`)).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute test
+	rolledBack, err := migrator.Down(context.Background(), 1)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, rolledBack, 1)
+	assert.Equal(t, int64(2), rolledBack[0].Version)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndMigrator(t *testing.T) (sqlmock.Sqlmock, *Migrator) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := Load(testFiles, "sql")
+	assert.NoError(t, err)
+
+	return mock, &Migrator{DB: db, Migrations: migrations}
+}
+
+// mockSchemaTable mocks the creation of the schema_migrations table
+func mockSchemaTable(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// assertMockExpectations is a helper to ensure all expectations of the mock are met
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+var testFiles = fstest.MapFS{
+	"sql/0001_create_items.up.sql":   {Data: []byte("CREATE TABLE items (id INT);")},
+	"sql/0001_create_items.down.sql": {Data: []byte("DROP TABLE items;")},
+	"sql/0002_add_name.up.sql":       {Data: []byte("ALTER TABLE items ADD COLUMN name TEXT;")},
+	"sql/0002_add_name.down.sql":     {Data: []byte("ALTER TABLE items DROP COLUMN name;")},
+}
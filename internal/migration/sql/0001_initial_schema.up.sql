@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(50) PRIMARY KEY,
+    first_name VARCHAR(50),
+    last_name VARCHAR(50),
+    email VARCHAR(50),
+    role VARCHAR(50),
+    password_hashed VARCHAR,
+    created_at SERIAL,
+    active BOOLEAN
+);
+
+CREATE TABLE IF NOT EXISTS locations (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(50),
+    created_at SERIAL
+);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(50) REFERENCES users(id) ON DELETE CASCADE,
+    location_id VARCHAR(50) REFERENCES locations(id) ON DELETE CASCADE,
+    working_hours SERIAL,
+    maintenance_hours SERIAL,
+    status SERIAL,
+    created_at SERIAL
+);
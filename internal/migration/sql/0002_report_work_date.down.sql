@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS reports_user_id_work_date_idx;
+
+ALTER TABLE reports DROP COLUMN IF EXISTS end_time;
+ALTER TABLE reports DROP COLUMN IF EXISTS start_time;
+ALTER TABLE reports DROP COLUMN IF EXISTS work_date;
@@ -0,0 +1,7 @@
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS work_date DATE;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS start_time TIME;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS end_time TIME;
+
+UPDATE reports SET work_date = to_timestamp(created_at)::date WHERE work_date IS NULL;
+
+CREATE INDEX IF NOT EXISTS reports_user_id_work_date_idx ON reports (user_id, work_date);
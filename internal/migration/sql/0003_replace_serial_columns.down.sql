@@ -0,0 +1,4 @@
+-- Sequence defaults are not restored; only the column types are reverted.
+ALTER TABLE reports ALTER COLUMN created_at TYPE INTEGER;
+ALTER TABLE locations ALTER COLUMN created_at TYPE INTEGER;
+ALTER TABLE users ALTER COLUMN created_at TYPE INTEGER;
@@ -0,0 +1,19 @@
+-- Hours, status and timestamps were declared SERIAL, which attaches a sequence
+-- default and limits created_at to 32 bits. Replace them with plain integers.
+ALTER TABLE users ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN created_at TYPE BIGINT;
+DROP SEQUENCE IF EXISTS users_created_at_seq;
+
+ALTER TABLE locations ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE locations ALTER COLUMN created_at TYPE BIGINT;
+DROP SEQUENCE IF EXISTS locations_created_at_seq;
+
+ALTER TABLE reports ALTER COLUMN working_hours DROP DEFAULT;
+ALTER TABLE reports ALTER COLUMN maintenance_hours DROP DEFAULT;
+ALTER TABLE reports ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE reports ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE reports ALTER COLUMN created_at TYPE BIGINT;
+DROP SEQUENCE IF EXISTS reports_working_hours_seq;
+DROP SEQUENCE IF EXISTS reports_maintenance_hours_seq;
+DROP SEQUENCE IF EXISTS reports_status_seq;
+DROP SEQUENCE IF EXISTS reports_created_at_seq;
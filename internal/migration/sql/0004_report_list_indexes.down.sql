@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_role_idx;
+DROP INDEX IF EXISTS reports_location_id_idx;
+DROP INDEX IF EXISTS reports_status_work_date_idx;
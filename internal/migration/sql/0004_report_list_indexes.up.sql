@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS reports_status_work_date_idx ON reports (status, work_date);
+CREATE INDEX IF NOT EXISTS reports_location_id_idx ON reports (location_id);
+CREATE INDEX IF NOT EXISTS users_role_idx ON users (role);
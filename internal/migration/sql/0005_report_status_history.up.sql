@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS report_status_history (
+    id VARCHAR(50) PRIMARY KEY,
+    report_id VARCHAR(50) NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    from_status INTEGER NOT NULL,
+    to_status INTEGER NOT NULL,
+    reason TEXT,
+    changed_by VARCHAR(50) REFERENCES users(id) ON DELETE SET NULL,
+    changed_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS report_status_history_report_idx ON report_status_history (report_id, changed_at);
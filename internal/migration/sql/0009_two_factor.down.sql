@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS two_factor_policies;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
@@ -0,0 +1,7 @@
+-- Minutes are rounded to the nearest hour, durations reported since lose their precision
+UPDATE reports SET
+    working_minutes = ROUND(working_minutes / 60.0),
+    maintenance_minutes = ROUND(maintenance_minutes / 60.0);
+
+ALTER TABLE reports RENAME COLUMN working_minutes TO working_hours;
+ALTER TABLE reports RENAME COLUMN maintenance_minutes TO maintenance_hours;
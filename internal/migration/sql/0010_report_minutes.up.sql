@@ -0,0 +1,7 @@
+-- Durations were whole hours, which cannot record a 7h45m shift. Store minutes instead.
+ALTER TABLE reports RENAME COLUMN working_hours TO working_minutes;
+ALTER TABLE reports RENAME COLUMN maintenance_hours TO maintenance_minutes;
+
+UPDATE reports SET
+    working_minutes = working_minutes * 60,
+    maintenance_minutes = maintenance_minutes * 60;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS time_entry_breaks;
+DROP TABLE IF EXISTS time_entries;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS time_entries (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    location_id VARCHAR(50) NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    clocked_in_at BIGINT NOT NULL,
+    clocked_out_at BIGINT CHECK (clocked_out_at >= clocked_in_at),
+    auto_closed BOOLEAN NOT NULL DEFAULT FALSE,
+    report_id VARCHAR(50) REFERENCES reports(id) ON DELETE SET NULL
+);
+
+-- A user has at most one open entry
+CREATE UNIQUE INDEX IF NOT EXISTS time_entries_open_user_idx ON time_entries (user_id) WHERE clocked_out_at IS NULL;
+CREATE INDEX IF NOT EXISTS time_entries_user_clocked_in_idx ON time_entries (user_id, clocked_in_at);
+
+CREATE TABLE IF NOT EXISTS time_entry_breaks (
+    id VARCHAR(50) PRIMARY KEY,
+    entry_id VARCHAR(50) NOT NULL REFERENCES time_entries(id) ON DELETE CASCADE,
+    started_at BIGINT NOT NULL,
+    ended_at BIGINT CHECK (ended_at >= started_at)
+);
+
+-- An entry has at most one open break
+CREATE UNIQUE INDEX IF NOT EXISTS time_entry_breaks_open_idx ON time_entry_breaks (entry_id) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS time_entry_breaks_entry_idx ON time_entry_breaks (entry_id, started_at);
@@ -0,0 +1,7 @@
+-- Work days are stored as comma separated weekday names, e.g. mon,tue,wed,thu,fri
+CREATE TABLE IF NOT EXISTS employee_contracts (
+    user_id VARCHAR(50) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    weekly_minutes INTEGER NOT NULL CHECK (weekly_minutes >= 0 AND weekly_minutes <= 10080),
+    work_days VARCHAR(30) NOT NULL,
+    updated_at BIGINT NOT NULL
+);
@@ -0,0 +1,18 @@
+-- Half day absences cover a single date, start_date equals end_date
+CREATE TABLE IF NOT EXISTS absences (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL CHECK (end_date >= start_date),
+    half_day VARCHAR(10),
+    note TEXT,
+    status VARCHAR(20) NOT NULL,
+    decided_by VARCHAR(50) REFERENCES users(id) ON DELETE SET NULL,
+    decision_reason TEXT,
+    decided_at BIGINT,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS absences_user_dates_idx ON absences (user_id, start_date, end_date);
+CREATE INDEX IF NOT EXISTS absences_status_start_idx ON absences (status, start_date);
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+	"time-management/internal/overtime/domain"
+	"time-management/internal/shared/util"
+)
+
+type SetContractCommand struct {
+	UserId        string
+	WeeklyMinutes uint64
+	WorkDays      []string
+}
+
+type SetContractHandler struct {
+	Repo domain.OvertimeRepository
+}
+
+// Handle stores the contract of the user, replacing the one they had. The overtime of past
+// pay periods is computed with the new contract too.
+func (h *SetContractHandler) Handle(ctx context.Context, cmd SetContractCommand) (*domain.Contract, error) {
+	workDays, err := domain.ParseWorkDays(cmd.WorkDays)
+	if err != nil {
+		return nil, util.NewFieldError("work_days", err)
+	}
+
+	contract, err := domain.NewContract(cmd.UserId, cmd.WeeklyMinutes, workDays, uint64(time.Now().Unix()))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWeeklyHours) {
+			return nil, util.NewFieldError("weekly_minutes", err)
+		}
+		return nil, util.NewFieldError("work_days", err)
+	}
+
+	if _, err := h.Repo.GetUser(ctx, cmd.UserId); err != nil {
+		if errors.Is(err, domain.ErrWrongEmployeeId) {
+			return nil, util.NewNotFoundError(err)
+		}
+		return nil, err
+	}
+
+	return h.Repo.SaveContract(ctx, contract)
+}
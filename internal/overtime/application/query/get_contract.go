@@ -0,0 +1,35 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time-management/internal/overtime/domain"
+	"time-management/internal/shared/util"
+)
+
+type GetContractQuery struct {
+	UserId string
+}
+
+type GetContractHandler struct {
+	Repo     domain.OvertimeRepository
+	Settings domain.Settings
+}
+
+// Handle returns the contract of the user, the default one when none was stored
+func (h *GetContractHandler) Handle(ctx context.Context, query GetContractQuery) (*domain.Contract, error) {
+	if _, err := h.Repo.GetUser(ctx, query.UserId); err != nil {
+		if errors.Is(err, domain.ErrWrongEmployeeId) {
+			return nil, util.NewNotFoundError(err)
+		}
+		return nil, err
+	}
+
+	stored, err := h.Repo.GetContract(ctx, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := h.Settings.ContractOf(query.UserId, stored)
+	return &contract, nil
+}
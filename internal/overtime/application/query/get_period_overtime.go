@@ -0,0 +1,117 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+	"time-management/internal/overtime/domain"
+	"time-management/internal/shared/util"
+)
+
+type GetPeriodOvertimeQuery struct {
+	// UserId limits the result to one employee, who is listed even without approved work
+	UserId string
+	// Date picks the pay period, today when empty
+	Date string
+}
+
+type GetPeriodOvertimeHandler struct {
+	Repo     domain.OvertimeRepository
+	Settings domain.Settings
+}
+
+// Handle splits the approved work of the pay period into regular time, overtime and time
+// on days off. Without a user, every employee with approved work in the period is listed.
+func (h *GetPeriodOvertimeHandler) Handle(
+	ctx context.Context,
+	query GetPeriodOvertimeQuery,
+) (*domain.PeriodOvertime, error) {
+	date := time.Now()
+	if query.Date != "" {
+		var err error
+		date, err = time.Parse(domain.DateLayout, query.Date)
+		if err != nil {
+			return nil, util.NewFieldError("date", domain.ErrInvalidDate)
+		}
+	}
+
+	period := h.Settings.Calendar.Period
+	from, to := period.Bounds(date)
+	work, err := h.Repo.GetApprovedWork(ctx, domain.WorkFilter{
+		UserId: query.UserId,
+		From:   from.Format(domain.DateLayout),
+		To:     to.Format(domain.DateLayout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := loadContracts(ctx, h.Repo, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := map[string][]domain.Work{}
+	var users []domain.User
+	for _, w := range work {
+		if _, ok := byUser[w.User.Id]; !ok {
+			users = append(users, w.User)
+		}
+		byUser[w.User.Id] = append(byUser[w.User.Id], w)
+	}
+	if query.UserId != "" && len(users) == 0 {
+		user, err := h.Repo.GetUser(ctx, query.UserId)
+		if err != nil {
+			if errors.Is(err, domain.ErrWrongEmployeeId) {
+				return nil, util.NewNotFoundError(err)
+			}
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+
+	result := &domain.PeriodOvertime{
+		Period:    period.Label(date),
+		From:      from.Format(domain.DateLayout),
+		To:        to.Format(domain.DateLayout),
+		Employees: []domain.EmployeeOvertime{},
+	}
+	for _, user := range users {
+		contract := h.Settings.ContractOf(user.Id, contracts[user.Id])
+		split, _ := h.Settings.Calendar.Split(contract, from, to, byUser[user.Id])
+		result.Employees = append(result.Employees, domain.EmployeeOvertime{
+			User:     user,
+			Contract: contract,
+			Overtime: split,
+		})
+	}
+
+	return result, nil
+}
+
+// loadContracts returns the stored contracts by user id, of a single user when one is given
+func loadContracts(
+	ctx context.Context,
+	repo domain.OvertimeRepository,
+	userId string,
+) (map[string]*domain.Contract, error) {
+	contracts := map[string]*domain.Contract{}
+	if userId != "" {
+		contract, err := repo.GetContract(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		contracts[userId] = contract
+		return contracts, nil
+	}
+
+	all, err := repo.GetContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		contracts[all[i].UserId] = &all[i]
+	}
+
+	return contracts, nil
+}
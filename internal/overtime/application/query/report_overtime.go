@@ -0,0 +1,121 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/overtime/domain"
+	reportDomain "time-management/internal/report/domain"
+)
+
+// ReportOvertime computes the overtime shown in report summaries and exports
+type ReportOvertime struct {
+	Repo     domain.OvertimeRepository
+	Settings domain.Settings
+}
+
+// Summarize splits the approved work of the employees between from and to, at every
+// location, against the target of that range
+func (o *ReportOvertime) Summarize(
+	ctx context.Context,
+	userIds []string,
+	from, to reportDomain.Date,
+) (map[string]reportDomain.Overtime, error) {
+	result := map[string]reportDomain.Overtime{}
+	if len(userIds) == 0 {
+		return result, nil
+	}
+
+	filter := domain.WorkFilter{From: from.String(), To: to.String()}
+	if len(userIds) == 1 {
+		filter.UserId = userIds[0]
+	}
+	work, err := o.Repo.GetApprovedWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := loadContracts(ctx, o.Repo, filter.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := map[string][]domain.Work{}
+	for _, w := range work {
+		byUser[w.User.Id] = append(byUser[w.User.Id], w)
+	}
+	for _, userId := range userIds {
+		contract := o.Settings.ContractOf(userId, contracts[userId])
+		split, _ := o.Settings.Calendar.Split(contract, from.Time, to.Time, byUser[userId])
+		result[userId] = toReportOvertime(split)
+	}
+
+	return result, nil
+}
+
+// Splitter loads the approved work of the pay periods the filtered range falls in, at every
+// location, and the contracts of the employees before any report is split. Callers bound
+// the range, an open one loads all approved work.
+func (o *ReportOvertime) Splitter(
+	ctx context.Context,
+	filter reportDomain.ReportFilter,
+) (reportDomain.OvertimeSplitter, error) {
+	period := o.Settings.Calendar.Period
+	workFilter := domain.WorkFilter{UserId: filter.UserId}
+	if filter.From != nil {
+		from, _ := period.Bounds(filter.From.Time)
+		workFilter.From = from.Format(domain.DateLayout)
+	}
+	if filter.To != nil {
+		_, to := period.Bounds(filter.To.Time)
+		workFilter.To = to.Format(domain.DateLayout)
+	}
+
+	work, err := o.Repo.GetApprovedWork(ctx, workFilter)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := loadContracts(ctx, o.Repo, filter.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := map[string][]domain.Work{}
+	for _, w := range work {
+		byUser[w.User.Id] = append(byUser[w.User.Id], w)
+	}
+
+	return &reportSplitter{settings: o.Settings, work: byUser, contracts: contracts}, nil
+}
+
+// reportSplitter keeps the splits of the last employee and pay period, exports come
+// ordered by user and work date
+type reportSplitter struct {
+	settings  domain.Settings
+	work      map[string][]domain.Work
+	contracts map[string]*domain.Contract
+	key       string
+	splits    map[string]domain.Split
+}
+
+// Split returns the part of the report that is regular time, overtime or time on a day
+// off. Work on work days is regular until the target of the pay period is reached.
+func (s *reportSplitter) Split(report reportDomain.Report) reportDomain.Overtime {
+	calendar := s.settings.Calendar
+	key := report.User.Id + "/" + calendar.Period.Label(report.WorkDate.Time)
+
+	if key != s.key {
+		from, to := calendar.Period.Bounds(report.WorkDate.Time)
+		contract := s.settings.ContractOf(report.User.Id, s.contracts[report.User.Id])
+		_, s.splits = calendar.Split(contract, from, to, s.work[report.User.Id])
+		s.key = key
+	}
+
+	return toReportOvertime(s.splits[report.Id])
+}
+
+func toReportOvertime(split domain.Split) reportDomain.Overtime {
+	return reportDomain.Overtime{
+		TargetMinutes:         split.TargetMinutes,
+		RegularMinutes:        split.RegularMinutes,
+		OvertimeMinutes:       split.OvertimeMinutes,
+		WeekendHolidayMinutes: split.WeekendHolidayMinutes,
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of work dates
+const DateLayout = "2006-01-02"
+
+// PayPeriod is the stretch of time overtime is settled over
+type PayPeriod string
+
+const (
+	PayPeriodWeek  PayPeriod = "week"
+	PayPeriodMonth PayPeriod = "month"
+)
+
+func ParsePayPeriod(value string) (PayPeriod, error) {
+	switch PayPeriod(value) {
+	case PayPeriodWeek, PayPeriodMonth:
+		return PayPeriod(value), nil
+	default:
+		return "", ErrUnknownPayPeriod
+	}
+}
+
+// Bounds returns the first and last day of the pay period the date falls in. Weeks start
+// on Monday.
+func (p PayPeriod) Bounds(date time.Time) (time.Time, time.Time) {
+	year, month, day := date.Date()
+	if p == PayPeriodWeek {
+		monday := time.Date(year, month, day-(int(date.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+		return monday, monday.AddDate(0, 0, 6)
+	}
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return first, first.AddDate(0, 1, -1)
+}
+
+// Label names the pay period the date falls in, as 2024-W05 or 2024-02
+func (p PayPeriod) Label(date time.Time) string {
+	if p == PayPeriodWeek {
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	}
+
+	return date.Format("2006-01")
+}
+
+// Calendar holds the pay period and the public holidays of the organisation
+type Calendar struct {
+	Period   PayPeriod
+	Holidays map[string]bool
+}
+
+// NewCalendar parses the holidays, given as comma separated dates
+func NewCalendar(period PayPeriod, holidays string) (Calendar, error) {
+	calendar := Calendar{Period: period, Holidays: map[string]bool{}}
+	for _, part := range strings.Split(holidays, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, part); err != nil {
+			return Calendar{}, fmt.Errorf("%w: %q", ErrInvalidDate, part)
+		}
+		calendar.Holidays[part] = true
+	}
+
+	return calendar, nil
+}
+
+// IsWorkDay reports whether the contract expects work on the date, holidays are off
+func (c Calendar) IsWorkDay(contract Contract, date time.Time) bool {
+	return contract.IsWorkDay(date.Weekday()) && !c.Holidays[date.Format(DateLayout)]
+}
+
+// Target is the time the contract expects between from and to, both included. The weekly
+// time is spread evenly over the work days, holidays reduce it.
+func (c Calendar) Target(contract Contract, from, to time.Time) uint64 {
+	if len(contract.WorkDays) == 0 {
+		return 0
+	}
+
+	var days uint64
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if c.IsWorkDay(contract, day) {
+			days++
+		}
+	}
+
+	return contract.WeeklyMinutes * days / uint64(len(contract.WorkDays))
+}
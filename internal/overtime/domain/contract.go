@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxWeeklyMinutes is a full week, a contract cannot ask for more
+const MaxWeeklyMinutes = 7 * 24 * 60
+
+// weekdayNames are the names work days are written with in requests, responses and storage
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "mon",
+	time.Tuesday:   "tue",
+	time.Wednesday: "wed",
+	time.Thursday:  "thu",
+	time.Friday:    "fri",
+	time.Saturday:  "sat",
+	time.Sunday:    "sun",
+}
+
+// Contract holds the hours an employee is contracted for. Employees without a stored
+// contract get the default one of the deployment.
+type Contract struct {
+	UserId        string   `json:"user_id"`
+	WeeklyMinutes uint64   `json:"weekly_minutes"`
+	WorkDays      WorkDays `json:"work_days"`
+	UpdatedAt     uint64   `json:"updated_at,omitempty"`
+	// Default is set when the employee has no contract of their own
+	Default bool `json:"default"`
+}
+
+func NewContract(userId string, weeklyMinutes uint64, workDays WorkDays, updatedAt uint64) (*Contract, error) {
+	if weeklyMinutes > MaxWeeklyMinutes {
+		return nil, ErrInvalidWeeklyHours
+	}
+	if weeklyMinutes > 0 && len(workDays) == 0 {
+		return nil, ErrNoWorkDays
+	}
+
+	return &Contract{UserId: userId, WeeklyMinutes: weeklyMinutes, WorkDays: workDays, UpdatedAt: updatedAt}, nil
+}
+
+// IsWorkDay reports whether the contract expects work on the weekday
+func (c Contract) IsWorkDay(day time.Weekday) bool {
+	for _, d := range c.WorkDays {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarshalJSON adds the weekly time in decimal hours next to the minutes
+func (c Contract) MarshalJSON() ([]byte, error) {
+	type contract Contract
+	return json.Marshal(struct {
+		contract
+		WeeklyHours float64 `json:"weekly_hours"`
+	}{
+		contract:    contract(c),
+		WeeklyHours: minutesToHours(c.WeeklyMinutes),
+	})
+}
+
+// WorkDays are the weekdays of a contract, ordered from Monday to Sunday
+type WorkDays []time.Weekday
+
+// ParseWorkDays parses weekday names such as mon or tue, duplicates are dropped
+func ParseWorkDays(names []string) (WorkDays, error) {
+	seen := map[time.Weekday]bool{}
+	days := WorkDays{}
+	for _, name := range names {
+		day, ok := weekdayByName(strings.ToLower(strings.TrimSpace(name)))
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWorkDay, name)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	// Sunday is the last day of the week
+	sort.Slice(days, func(i, j int) bool { return (days[i]+6)%7 < (days[j]+6)%7 })
+
+	return days, nil
+}
+
+// String writes the days as comma separated names, the way they are stored
+func (w WorkDays) String() string {
+	names := make([]string, len(w))
+	for i, day := range w {
+		names[i] = weekdayNames[day]
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (w WorkDays) MarshalJSON() ([]byte, error) {
+	names := make([]string, len(w))
+	for i, day := range w {
+		names[i] = weekdayNames[day]
+	}
+
+	return json.Marshal(names)
+}
+
+func weekdayByName(name string) (time.Weekday, bool) {
+	for day, dayName := range weekdayNames {
+		if dayName == name {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
+
+// minutesToHours returns minutes as decimal hours rounded to two decimals, like reports do
+func minutesToHours(minutes uint64) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
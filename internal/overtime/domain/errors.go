@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+var (
+	ErrWrongEmployeeId    = errors.New("wrong employee id: employee does not exist")
+	ErrInvalidWeeklyHours = errors.New("invalid weekly hours: must be between 0 and 168")
+	ErrInvalidWorkDay     = errors.New("invalid work day: expected mon, tue, wed, thu, fri, sat or sun")
+	ErrNoWorkDays         = errors.New("a contract with weekly hours needs at least one work day")
+	ErrUnknownPayPeriod   = errors.New("unknown pay period: expected week or month")
+	ErrInvalidDate        = errors.New("invalid date: expected format YYYY-MM-DD")
+)
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrWrongEmployeeId:    "employee_id_invalid",
+	ErrInvalidWeeklyHours: "weekly_hours_invalid",
+	ErrInvalidWorkDay:     "work_day_invalid",
+	ErrNoWorkDays:         "work_days_required",
+	ErrUnknownPayPeriod:   "pay_period_unknown",
+	ErrInvalidDate:        "date_invalid",
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type User struct {
+	Id        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// Work is the time of an approved report
+type Work struct {
+	ReportId string
+	User     User
+	Date     string
+	Minutes  uint64
+}
+
+// Split divides worked time into the part within the contracted target, the overtime on
+// top of it and the time worked on days off or holidays
+type Split struct {
+	TargetMinutes         uint64 `json:"target_minutes"`
+	RegularMinutes        uint64 `json:"regular_minutes"`
+	OvertimeMinutes       uint64 `json:"overtime_minutes"`
+	WeekendHolidayMinutes uint64 `json:"weekend_holiday_minutes"`
+}
+
+// TotalMinutes is all the time worked
+func (s Split) TotalMinutes() uint64 {
+	return s.RegularMinutes + s.OvertimeMinutes + s.WeekendHolidayMinutes
+}
+
+func (s Split) Add(other Split) Split {
+	return Split{
+		TargetMinutes:         s.TargetMinutes + other.TargetMinutes,
+		RegularMinutes:        s.RegularMinutes + other.RegularMinutes,
+		OvertimeMinutes:       s.OvertimeMinutes + other.OvertimeMinutes,
+		WeekendHolidayMinutes: s.WeekendHolidayMinutes + other.WeekendHolidayMinutes,
+	}
+}
+
+// MarshalJSON adds the durations in decimal hours next to the minutes
+func (s Split) MarshalJSON() ([]byte, error) {
+	type split Split
+	return json.Marshal(struct {
+		split
+		TotalMinutes        uint64  `json:"total_minutes"`
+		TargetHours         float64 `json:"target_hours"`
+		RegularHours        float64 `json:"regular_hours"`
+		OvertimeHours       float64 `json:"overtime_hours"`
+		WeekendHolidayHours float64 `json:"weekend_holiday_hours"`
+		TotalHours          float64 `json:"total_hours"`
+	}{
+		split:               split(s),
+		TotalMinutes:        s.TotalMinutes(),
+		TargetHours:         minutesToHours(s.TargetMinutes),
+		RegularHours:        minutesToHours(s.RegularMinutes),
+		OvertimeHours:       minutesToHours(s.OvertimeMinutes),
+		WeekendHolidayHours: minutesToHours(s.WeekendHolidayMinutes),
+		TotalHours:          minutesToHours(s.TotalMinutes()),
+	})
+}
+
+// Split divides the work of one employee between from and to, both included, into
+// regular time and overtime against the target of the contract. Work on days off and
+// holidays is counted apart. Work on work days is regular until the target is reached,
+// in the order given, so the split of each report is returned as well, keyed by report id.
+func (c Calendar) Split(contract Contract, from, to time.Time, work []Work) (Split, map[string]Split) {
+	total := Split{TargetMinutes: c.Target(contract, from, to)}
+	reports := map[string]Split{}
+
+	for _, w := range work {
+		date, err := time.Parse(DateLayout, w.Date)
+		if err != nil || date.Before(from) || date.After(to) {
+			continue
+		}
+
+		var split Split
+		switch {
+		case !c.IsWorkDay(contract, date):
+			split.WeekendHolidayMinutes = w.Minutes
+		case total.RegularMinutes >= total.TargetMinutes:
+			split.OvertimeMinutes = w.Minutes
+		default:
+			split.RegularMinutes = min(w.Minutes, total.TargetMinutes-total.RegularMinutes)
+			split.OvertimeMinutes = w.Minutes - split.RegularMinutes
+		}
+
+		total = total.Add(split)
+		reports[w.ReportId] = reports[w.ReportId].Add(split)
+	}
+
+	return total, reports
+}
+
+// EmployeeOvertime is the split of the work of an employee over a period
+type EmployeeOvertime struct {
+	User     User     `json:"user"`
+	Contract Contract `json:"contract"`
+	Overtime Split    `json:"overtime"`
+}
+
+// PeriodOvertime lists the overtime of employees over a pay period
+type PeriodOvertime struct {
+	Period    string             `json:"period"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Employees []EmployeeOvertime `json:"employees"`
+}
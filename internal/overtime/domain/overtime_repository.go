@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// WorkFilter selects approved work; zero values are ignored. From and To are work dates,
+// both included.
+type WorkFilter struct {
+	UserId string
+	From   string
+	To     string
+}
+
+type OvertimeRepository interface {
+	// GetContract returns the stored contract of the user, nil when there is none
+	GetContract(ctx context.Context, userId string) (*Contract, error)
+	GetContracts(ctx context.Context) ([]Contract, error)
+	// SaveContract stores the contract, replacing the one the user had
+	SaveContract(ctx context.Context, contract *Contract) (*Contract, error)
+	// GetUser returns the user, failing with ErrWrongEmployeeId when there is none
+	GetUser(ctx context.Context, userId string) (*User, error)
+	// GetApprovedWork returns the approved reports ordered by user, work date and creation
+	GetApprovedWork(ctx context.Context, filter WorkFilter) ([]Work, error)
+}
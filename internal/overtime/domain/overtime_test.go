@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) time.Time {
+	t, _ := time.Parse(DateLayout, value)
+	return t
+}
+
+var fullTime = Contract{
+	UserId:        "usr1",
+	WeeklyMinutes: 40 * 60,
+	WorkDays:      WorkDays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+}
+
+func TestCalendar_Split(t *testing.T) {
+	calendar, err := NewCalendar(PayPeriodWeek, "2024-03-08")
+	assert.NoError(t, err)
+	from, to := calendar.Period.Bounds(date("2024-03-06"))
+	work := []Work{
+		{ReportId: "rep1", Date: "2024-03-04", Minutes: 600},
+		{ReportId: "rep2", Date: "2024-03-05", Minutes: 600},
+		{ReportId: "rep3", Date: "2024-03-06", Minutes: 600},
+		{ReportId: "rep4", Date: "2024-03-07", Minutes: 300},
+		{ReportId: "rep5", Date: "2024-03-08", Minutes: 240},
+		{ReportId: "rep6", Date: "2024-03-09", Minutes: 120},
+		{ReportId: "rep7", Date: "2024-03-11", Minutes: 480},
+	}
+
+	// Execute test
+	total, reports := calendar.Split(fullTime, from, to, work)
+
+	// Assertions
+	assert.Equal(t, Split{
+		TargetMinutes:         4 * 480,
+		RegularMinutes:        1920,
+		OvertimeMinutes:       180,
+		WeekendHolidayMinutes: 360,
+	}, total)
+	assert.Equal(t, Split{RegularMinutes: 600}, reports["rep3"])
+	assert.Equal(t, Split{RegularMinutes: 120, OvertimeMinutes: 180}, reports["rep4"])
+	assert.Equal(t, Split{WeekendHolidayMinutes: 240}, reports["rep5"])
+	assert.NotContains(t, reports, "rep7")
+}
+
+func TestCalendar_Target_PartTime(t *testing.T) {
+	calendar, err := NewCalendar(PayPeriodMonth, "")
+	assert.NoError(t, err)
+	contract := Contract{WeeklyMinutes: 20 * 60, WorkDays: WorkDays{time.Monday, time.Wednesday, time.Friday}}
+	from, to := calendar.Period.Bounds(date("2024-02-14"))
+
+	// Execute test
+	target := calendar.Target(contract, from, to)
+
+	// Assertions
+	assert.Equal(t, "2024-02-01", from.Format(DateLayout))
+	assert.Equal(t, "2024-02-29", to.Format(DateLayout))
+	assert.Equal(t, uint64(1200*12/3), target)
+}
+
+func TestPayPeriod_Bounds(t *testing.T) {
+	from, to := PayPeriodWeek.Bounds(date("2024-03-10"))
+
+	// Assertions
+	assert.Equal(t, "2024-03-04", from.Format(DateLayout))
+	assert.Equal(t, "2024-03-10", to.Format(DateLayout))
+	assert.Equal(t, "2024-W10", PayPeriodWeek.Label(date("2024-03-10")))
+	assert.Equal(t, "2024-03", PayPeriodMonth.Label(date("2024-03-10")))
+}
+
+func TestParseWorkDays(t *testing.T) {
+	// Execute test
+	days, err := ParseWorkDays([]string{"sun", "MON", "wed", "mon"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, WorkDays{time.Monday, time.Wednesday, time.Sunday}, days)
+	assert.Equal(t, "mon,wed,sun", days.String())
+
+	_, err = ParseWorkDays([]string{"someday"})
+	assert.ErrorIs(t, err, ErrInvalidWorkDay)
+	_, err = NewContract("usr1", 600, WorkDays{}, 0)
+	assert.ErrorIs(t, err, ErrNoWorkDays)
+	_, err = NewCalendar(PayPeriodMonth, "2024-13-01")
+	assert.ErrorIs(t, err, ErrInvalidDate)
+}
@@ -0,0 +1,21 @@
+package domain
+
+// Settings are the overtime rules of the deployment
+type Settings struct {
+	Calendar Calendar
+	// DefaultContract applies to employees without a contract of their own
+	DefaultContract Contract
+}
+
+// ContractOf returns the stored contract of the user, or the default one when there is none
+func (s Settings) ContractOf(userId string, stored *Contract) Contract {
+	if stored != nil {
+		return *stored
+	}
+
+	contract := s.DefaultContract
+	contract.UserId = userId
+	contract.Default = true
+
+	return contract
+}
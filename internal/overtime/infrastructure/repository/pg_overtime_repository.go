@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time-management/internal/overtime/domain"
+	reportDomain "time-management/internal/report/domain"
+	reportPg "time-management/internal/report/infrastructure/repository"
+	userPg "time-management/internal/user/infrastructure/repository"
+)
+
+const ContractTableName = "employee_contracts"
+
+var selectContracts = fmt.Sprintf(`SELECT user_id, weekly_minutes, work_days, updated_at FROM %s`, ContractTableName)
+
+// selectApprovedWork reads the total time of approved reports, reports of a day are
+// ordered by creation so overtime is always attributed to the same ones
+var selectApprovedWork = fmt.Sprintf(`
+	SELECT
+		r.id, u.id, u.first_name, u.last_name, u.email,
+		to_char(r.work_date, 'YYYY-MM-DD'), r.working_minutes + r.maintenance_minutes
+	FROM %s r
+	JOIN %s u ON r.user_id = u.id
+`, reportPg.TableName, userPg.TableName)
+
+type PgOvertimeRepository struct {
+	DB *sql.DB
+}
+
+func NewPgOvertimeRepository(db *sql.DB) *PgOvertimeRepository {
+	return &PgOvertimeRepository{DB: db}
+}
+
+func (r *PgOvertimeRepository) GetContract(ctx context.Context, userId string) (*domain.Contract, error) {
+	query := selectContracts + " WHERE user_id = $1"
+
+	contract, err := scanContract(r.DB.QueryRowContext(ctx, query, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (r *PgOvertimeRepository) GetContracts(ctx context.Context) ([]domain.Contract, error) {
+	rows, err := r.DB.QueryContext(ctx, selectContracts+" ORDER BY user_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contracts := []domain.Contract{}
+	for rows.Next() {
+		contract, err := scanContract(rows)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, *contract)
+	}
+
+	return contracts, rows.Err()
+}
+
+func (r *PgOvertimeRepository) SaveContract(ctx context.Context, contract *domain.Contract) (*domain.Contract, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, weekly_minutes, work_days, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			weekly_minutes = EXCLUDED.weekly_minutes,
+			work_days = EXCLUDED.work_days,
+			updated_at = EXCLUDED.updated_at
+	`, ContractTableName)
+
+	_, err := r.DB.ExecContext(ctx, query,
+		contract.UserId, contract.WeeklyMinutes, contract.WorkDays.String(), contract.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (r *PgOvertimeRepository) GetUser(ctx context.Context, userId string) (*domain.User, error) {
+	query := fmt.Sprintf(`SELECT id, first_name, last_name, email FROM %s WHERE id = $1`, userPg.TableName)
+
+	var user domain.User
+	err := r.DB.QueryRowContext(ctx, query, userId).Scan(&user.Id, &user.FirstName, &user.LastName, &user.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWrongEmployeeId
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *PgOvertimeRepository) GetApprovedWork(ctx context.Context, filter domain.WorkFilter) ([]domain.Work, error) {
+	args := []any{int(reportDomain.Approved)}
+	conditions := []string{"r.status = $1"}
+
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("r.user_id = $%d", len(args)))
+	}
+	if filter.From != "" {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("r.work_date >= $%d", len(args)))
+	}
+	if filter.To != "" {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("r.work_date <= $%d", len(args)))
+	}
+
+	query := selectApprovedWork +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY u.id, r.work_date, r.created_at, r.id"
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	work := []domain.Work{}
+	for rows.Next() {
+		var w domain.Work
+		err := rows.Scan(
+			&w.ReportId, &w.User.Id, &w.User.FirstName, &w.User.LastName, &w.User.Email,
+			&w.Date, &w.Minutes,
+		)
+		if err != nil {
+			return nil, err
+		}
+		work = append(work, w)
+	}
+
+	return work, rows.Err()
+}
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanContract(row rowScanner) (*domain.Contract, error) {
+	var contract domain.Contract
+	var workDays string
+
+	err := row.Scan(&contract.UserId, &contract.WeeklyMinutes, &workDays, &contract.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if workDays != "" {
+		names = strings.Split(workDays, ",")
+	}
+	contract.WorkDays, err = domain.ParseWorkDays(names)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
@@ -0,0 +1,125 @@
+package repository_test
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+	"time-management/internal/overtime/domain"
+	"time-management/internal/overtime/infrastructure/repository"
+)
+
+var contractColumns = []string{"user_id", "weekly_minutes", "work_days", "updated_at"}
+
+func TestPgOvertimeRepository_GetContract(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM employee_contracts WHERE user_id = $1`)).
+		WithArgs("usr1").
+		WillReturnRows(sqlmock.NewRows(contractColumns).AddRow("usr1", 1200, "mon,wed,fri", 1700000000))
+
+	// Execute test
+	contract, err := repo.GetContract(context.Background(), "usr1")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1200), contract.WeeklyMinutes)
+	assert.Equal(t, domain.WorkDays{time.Monday, time.Wednesday, time.Friday}, contract.WorkDays)
+	assert.False(t, contract.Default)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgOvertimeRepository_GetContract_None(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM employee_contracts WHERE user_id = $1`)).
+		WithArgs("usr1").
+		WillReturnRows(sqlmock.NewRows(contractColumns))
+
+	// Execute test
+	contract, err := repo.GetContract(context.Background(), "usr1")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Nil(t, contract)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgOvertimeRepository_SaveContract(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+	contract := &domain.Contract{
+		UserId:        "usr1",
+		WeeklyMinutes: 2400,
+		WorkDays:      domain.WorkDays{time.Monday, time.Tuesday},
+		UpdatedAt:     1700000000,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT (user_id) DO UPDATE SET`)).
+		WithArgs("usr1", uint64(2400), "mon,tue", uint64(1700000000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute test
+	saved, err := repo.SaveContract(context.Background(), contract)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, contract, saved)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgOvertimeRepository_GetUser_NotFound(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM users WHERE id = $1`)).
+		WithArgs("usr9").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "email"}))
+
+	// Execute test
+	_, err := repo.GetUser(context.Background(), "usr9")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrWrongEmployeeId)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgOvertimeRepository_GetApprovedWork(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE r.status = $1 AND r.user_id = $2 AND r.work_date >= $3 AND r.work_date <= $4 ORDER BY u.id, r.work_date, r.created_at, r.id`)).
+		WithArgs(1, "usr1", "2024-03-01", "2024-03-31").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "id", "first_name", "last_name", "email", "work_date", "minutes"}).
+			AddRow("rep1", "usr1", "Jane", "Doe", "jane@example.com", "2024-03-04", 480).
+			AddRow("rep2", "usr1", "Jane", "Doe", "jane@example.com", "2024-03-05", 510))
+
+	// Execute test
+	work, err := repo.GetApprovedWork(context.Background(), domain.WorkFilter{
+		UserId: "usr1",
+		From:   "2024-03-01",
+		To:     "2024-03-31",
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, work, 2)
+	assert.Equal(t, "Jane", work[0].User.FirstName)
+	assert.Equal(t, uint64(510), work[1].Minutes)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *repository.PgOvertimeRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	repo := repository.NewPgOvertimeRepository(db)
+	return mock, repo
+}
+
+// assertMockExpectations is a helper to ensure all expectations of the mock are met
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,34 @@
+package settings
+
+import (
+	"strings"
+	"time-management/internal/overtime/domain"
+	"time-management/internal/shared/config"
+)
+
+// SettingsFromConfig returns the configured pay period and holidays with the default contract
+func SettingsFromConfig(cfg config.Overtime) (domain.Settings, error) {
+	period, err := domain.ParsePayPeriod(cfg.PayPeriod)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+	calendar, err := domain.NewCalendar(period, cfg.Holidays)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+
+	var names []string
+	if strings.TrimSpace(cfg.WorkDays) != "" {
+		names = strings.Split(cfg.WorkDays, ",")
+	}
+	workDays, err := domain.ParseWorkDays(names)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+	contract, err := domain.NewContract("", uint64(cfg.WeeklyMinutes), workDays, 0)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+
+	return domain.Settings{Calendar: calendar, DefaultContract: *contract}, nil
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"math"
+	"net/http"
+	"time-management/internal/overtime/application/command"
+	"time-management/internal/overtime/application/query"
+	"time-management/internal/overtime/domain"
+	"time-management/internal/shared/util"
+	userDomain "time-management/internal/user/domain"
+)
+
+type OvertimeHandler struct {
+	SetContractHandler       command.SetContractHandler
+	GetContractHandler       query.GetContractHandler
+	GetPeriodOvertimeHandler query.GetPeriodOvertimeHandler
+}
+
+func NewOvertimeHandler(repository domain.OvertimeRepository, settings domain.Settings) *OvertimeHandler {
+	return &OvertimeHandler{
+		SetContractHandler:       command.SetContractHandler{Repo: repository},
+		GetContractHandler:       query.GetContractHandler{Repo: repository, Settings: settings},
+		GetPeriodOvertimeHandler: query.GetPeriodOvertimeHandler{Repo: repository, Settings: settings},
+	}
+}
+
+func (h *OvertimeHandler) GetOwnContract(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*userDomain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(userDomain.ErrUserNotFound))
+	}
+
+	return h.writeContract(w, r, user.Id)
+}
+
+func (h *OvertimeHandler) GetContract(w http.ResponseWriter, r *http.Request) error {
+	return h.writeContract(w, r, chi.URLParam(r, "user_id"))
+}
+
+func (h *OvertimeHandler) writeContract(w http.ResponseWriter, r *http.Request, userId string) error {
+	contract, err := h.GetContractHandler.Handle(r.Context(), query.GetContractQuery{UserId: userId})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, contract)
+}
+
+// SetContract stores the weekly time, in weekly_minutes or decimal weekly_hours, and the
+// work days of an employee
+func (h *OvertimeHandler) SetContract(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		WeeklyMinutes *int64   `json:"weekly_minutes"`
+		WeeklyHours   *float64 `json:"weekly_hours"`
+		WorkDays      []string `json:"work_days"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	var weekly int64
+	switch {
+	case req.WeeklyMinutes != nil:
+		weekly = *req.WeeklyMinutes
+	case req.WeeklyHours != nil:
+		weekly = int64(math.Round(*req.WeeklyHours * 60))
+	default:
+		weekly = -1
+	}
+	if weekly < 0 {
+		return util.WriteError(w, r, util.NewFieldError("weekly_minutes", domain.ErrInvalidWeeklyHours))
+	}
+
+	cmd := command.SetContractCommand{
+		UserId:        chi.URLParam(r, "user_id"),
+		WeeklyMinutes: uint64(weekly),
+		WorkDays:      req.WorkDays,
+	}
+	contract, err := h.SetContractHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, contract)
+}
+
+// GetOwnOvertime splits the approved time of the user in the pay period of the date
+func (h *OvertimeHandler) GetOwnOvertime(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*userDomain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(userDomain.ErrUserNotFound))
+	}
+
+	return h.writeOvertime(w, r, user.Id)
+}
+
+// GetOvertime splits the approved time of every employee, or of the one given as
+// user_id, in the pay period of the date
+func (h *OvertimeHandler) GetOvertime(w http.ResponseWriter, r *http.Request) error {
+	return h.writeOvertime(w, r, r.URL.Query().Get("user_id"))
+}
+
+func (h *OvertimeHandler) writeOvertime(w http.ResponseWriter, r *http.Request, userId string) error {
+	overtimeQuery := query.GetPeriodOvertimeQuery{UserId: userId, Date: r.URL.Query().Get("date")}
+	overtime, err := h.GetPeriodOvertimeHandler.Handle(r.Context(), overtimeQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, overtime)
+}
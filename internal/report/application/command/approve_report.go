@@ -2,22 +2,54 @@ package command
 
 import (
 	"context"
+	"github.com/google/uuid"
+	"time"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/metrics"
+	"time-management/internal/shared/util"
 )
 
 type ApproveReportCommand struct {
-	Id string
+	Id        string
+	ChangedBy string
 }
 
 type ApproveReportHandler struct {
 	Repo domain.ReportRepository
+	// Compliance checks the working time rules, nil skips them
+	Compliance domain.ComplianceChecker
 }
 
-func (h *ApproveReportHandler) Handle(ctx context.Context, cmd ApproveReportCommand) error {
-	err := h.Repo.Approve(ctx, cmd.Id)
+// Handle approves the report unless it breaks a hard working time rule, the rules it
+// breaks otherwise are returned as warnings
+func (h *ApproveReportHandler) Handle(ctx context.Context, cmd ApproveReportCommand) ([]domain.ComplianceWarning, error) {
+	if cmd.ChangedBy == "" {
+		return nil, util.NewValidationError(domain.ErrMissingActingUser)
+	}
+
+	var warnings []domain.ComplianceWarning
+	if h.Compliance != nil {
+		var err error
+		warnings, err = h.Compliance.CheckStoredReport(ctx, cmd.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	change := domain.NewStatusChange(
+		uuid.New().String(),
+		cmd.Id,
+		domain.Approved,
+		cmd.ChangedBy,
+		nil,
+		uint64(time.Now().Unix()),
+	)
+
+	err := h.Repo.Approve(ctx, change)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	metrics.ReportsApproved.Inc()
 
-	return nil
+	return warnings, nil
 }
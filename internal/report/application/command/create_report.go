@@ -5,51 +5,108 @@ import (
 	"github.com/google/uuid"
 	"time"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/metrics"
 	"time-management/internal/shared/util"
 )
 
 type CreateReportCommand struct {
-	EmployeeId       string
-	LocationId       string
-	WorkingHours     uint64
-	MaintenanceHours uint64
+	EmployeeId         string
+	LocationId         string
+	WorkingMinutes     uint64
+	MaintenanceMinutes uint64
+	WorkDate           string
+	StartTime          string
+	EndTime            string
+	// EntryIds are the time entries the report is converted from, they are claimed in the
+	// transaction that stores it
+	EntryIds []string
 }
 
 type CreateReportHandler struct {
 	Repo domain.ReportRepository
+	// Rounding is applied to the reported minutes before they are validated and stored
+	Rounding domain.RoundingRule
+	// Compliance checks the working time rules, nil skips them
+	Compliance domain.ComplianceChecker
+	// Absences rejects reports for days the user took off, nil skips the check
+	Absences domain.AbsenceChecker
 }
 
 func (h *CreateReportHandler) Handle(ctx context.Context, cmd CreateReportCommand) (*domain.Report, error) {
 	if cmd.EmployeeId == "" || len(cmd.EmployeeId) >= 50 {
-		return nil, util.NewValidationError(domain.ErrWrongEmployeeId)
+		return nil, util.NewFieldError("employee_id", domain.ErrWrongEmployeeId)
 	}
 	if cmd.LocationId == "" || len(cmd.LocationId) >= 50 {
-		return nil, util.NewValidationError(domain.ErrWrongLocationId)
+		return nil, util.NewFieldError("location_id", domain.ErrWrongLocationId)
 	}
-	if cmd.WorkingHours < 0 || cmd.WorkingHours > 16 {
-		return nil, util.NewValidationError(domain.ErrInvalidWorkingHours)
+
+	working := h.Rounding.Apply(cmd.WorkingMinutes)
+	maintenance := h.Rounding.Apply(cmd.MaintenanceMinutes)
+	if working < 0 || working > domain.MaxDailyMinutes {
+		return nil, util.NewFieldError("working_minutes", domain.ErrInvalidWorkingHours)
 	}
-	if cmd.MaintenanceHours <= 0 || cmd.MaintenanceHours > 16 {
-		return nil, util.NewValidationError(domain.ErrInvalidMaintenanceHours)
+	if maintenance <= 0 || maintenance > domain.MaxDailyMinutes {
+		return nil, util.NewFieldError("maintenance_minutes", domain.ErrInvalidMaintenanceHours)
 	}
-	if cmd.WorkingHours+cmd.MaintenanceHours > 16 {
+	if working+maintenance > domain.MaxDailyMinutes {
 		return nil, util.NewValidationError(domain.ErrInvalidHoursSum)
 	}
 
+	// A report without a work date is for today. The shift is checked against the minutes as
+	// reported, rounding up must not reject them even though the stored minutes may then
+	// exceed the shift
+	s, err := parseSchedule(
+		cmd.WorkDate,
+		domain.NewDate(time.Now()),
+		cmd.StartTime,
+		cmd.EndTime,
+		cmd.WorkingMinutes+cmd.MaintenanceMinutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := h.Repo.IsEmployeeActive(ctx, cmd.EmployeeId)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, util.NewValidationError(domain.ErrEmployeeInactive)
+	}
+
 	report := domain.NewReport(
 		uuid.New().String(),
 		cmd.EmployeeId,
 		cmd.LocationId,
-		cmd.WorkingHours,
-		cmd.MaintenanceHours,
+		working,
+		maintenance,
+		s.WorkDate,
+		s.StartTime,
+		s.EndTime,
 		domain.Pending,
 		uint64(time.Now().Unix()),
 	)
 
-	createdReport, err := h.Repo.Create(ctx, report)
+	if err := checkAbsence(ctx, h.Absences, cmd.EmployeeId, s.WorkDate); err != nil {
+		return nil, err
+	}
+
+	warnings, err := checkCompliance(ctx, h.Compliance, report)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdReport *domain.Report
+	if len(cmd.EntryIds) > 0 {
+		createdReport, err = h.Repo.CreateFromEntries(ctx, report, cmd.EntryIds)
+	} else {
+		createdReport, err = h.Repo.Create(ctx, report)
+	}
 	if err != nil {
 		return nil, err
 	}
+	metrics.ReportsCreated.Inc()
+	createdReport.ComplianceWarnings = warnings
 
 	return createdReport, nil
 }
@@ -0,0 +1,73 @@
+package command_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time-management/internal/report/application/command"
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+// fakeReportRepository stores created reports in memory, the methods it does not override
+// are not expected to be called
+type fakeReportRepository struct {
+	domain.ReportRepository
+	created *domain.Report
+}
+
+func (r *fakeReportRepository) IsEmployeeActive(ctx context.Context, userId string) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeReportRepository) Create(ctx context.Context, report *domain.Report) (*domain.Report, error) {
+	r.created = report
+	return report, nil
+}
+
+// fakeAbsences takes the listed dates off
+type fakeAbsences map[string]bool
+
+func (a fakeAbsences) HasFullDayAbsence(ctx context.Context, userId string, workDate domain.Date) (bool, error) {
+	return a[workDate.String()], nil
+}
+
+func TestCreateReportHandler_Handle(t *testing.T) {
+	repo := &fakeReportRepository{}
+	handler := command.CreateReportHandler{Repo: repo, Absences: fakeAbsences{"2024-03-04": true}}
+
+	// Execute test
+	report, err := handler.Handle(context.Background(), command.CreateReportCommand{
+		EmployeeId:         "usr1",
+		LocationId:         "loc1",
+		WorkingMinutes:     420,
+		MaintenanceMinutes: 30,
+		WorkDate:           "2024-03-05",
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-05", report.WorkDate.String())
+	assert.Same(t, report, repo.created)
+}
+
+func TestCreateReportHandler_Handle_OnLeave(t *testing.T) {
+	repo := &fakeReportRepository{}
+	handler := command.CreateReportHandler{Repo: repo, Absences: fakeAbsences{"2024-03-05": true}}
+
+	// Execute test
+	report, err := handler.Handle(context.Background(), command.CreateReportCommand{
+		EmployeeId:         "usr1",
+		LocationId:         "loc1",
+		WorkingMinutes:     420,
+		MaintenanceMinutes: 30,
+		WorkDate:           "2024-03-05",
+	})
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrAbsentOnWorkDate)
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Nil(t, report)
+	assert.Nil(t, repo.created)
+}
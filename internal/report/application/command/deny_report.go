@@ -2,11 +2,18 @@ package command
 
 import (
 	"context"
+	"github.com/google/uuid"
+	"strings"
+	"time"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/metrics"
+	"time-management/internal/shared/util"
 )
 
 type DenyReportCommand struct {
-	Id string
+	Id        string
+	ChangedBy string
+	Reason    string
 }
 
 type DenyReportHandler struct {
@@ -14,10 +21,32 @@ type DenyReportHandler struct {
 }
 
 func (h *DenyReportHandler) Handle(ctx context.Context, cmd DenyReportCommand) error {
-	err := h.Repo.Deny(ctx, cmd.Id)
+	if cmd.ChangedBy == "" {
+		return util.NewValidationError(domain.ErrMissingActingUser)
+	}
+
+	reason := strings.TrimSpace(cmd.Reason)
+	if reason == "" {
+		return util.NewFieldError("reason", domain.ErrReasonRequired)
+	}
+	if len([]rune(reason)) > domain.MaxReasonLength {
+		return util.NewFieldError("reason", domain.ErrReasonTooLong)
+	}
+
+	change := domain.NewStatusChange(
+		uuid.New().String(),
+		cmd.Id,
+		domain.Denied,
+		cmd.ChangedBy,
+		&reason,
+		uint64(time.Now().Unix()),
+	)
+
+	err := h.Repo.Deny(ctx, change)
 	if err != nil {
 		return err
 	}
+	metrics.ReportsDenied.Inc()
 
 	return nil
 }
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+type ReopenReportCommand struct {
+	Id        string
+	ChangedBy string
+	Reason    string
+}
+
+type ReopenReportHandler struct {
+	Repo domain.ReportRepository
+}
+
+func (h *ReopenReportHandler) Handle(ctx context.Context, cmd ReopenReportCommand) error {
+	if cmd.ChangedBy == "" {
+		return util.NewValidationError(domain.ErrMissingActingUser)
+	}
+
+	var reason *string
+	if trimmed := strings.TrimSpace(cmd.Reason); trimmed != "" {
+		if len([]rune(trimmed)) > domain.MaxReasonLength {
+			return util.NewFieldError("reason", domain.ErrReasonTooLong)
+		}
+		reason = &trimmed
+	}
+
+	change := domain.NewStatusChange(
+		uuid.New().String(),
+		cmd.Id,
+		domain.Reopened,
+		cmd.ChangedBy,
+		reason,
+		uint64(time.Now().Unix()),
+	)
+
+	err := h.Repo.Reopen(ctx, change)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
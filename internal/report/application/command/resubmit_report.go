@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+// ResubmitReportCommand edits a denied report of the user and sends it back for review
+type ResubmitReportCommand struct {
+	UserId             string
+	Id                 string
+	LocationId         string
+	WorkingMinutes     uint64
+	MaintenanceMinutes uint64
+	WorkDate           string
+	StartTime          string
+	EndTime            string
+}
+
+type ResubmitReportHandler struct {
+	Repo domain.ReportRepository
+	// Rounding is applied to the reported minutes before they are validated and stored
+	Rounding domain.RoundingRule
+	// Compliance checks the working time rules, nil skips them
+	Compliance domain.ComplianceChecker
+	// Absences rejects reports for days the user took off, nil skips the check
+	Absences domain.AbsenceChecker
+}
+
+func (h *ResubmitReportHandler) Handle(ctx context.Context, cmd ResubmitReportCommand) (*domain.Report, error) {
+	if cmd.LocationId == "" || len(cmd.LocationId) >= 50 {
+		return nil, util.NewFieldError("location_id", domain.ErrWrongLocationId)
+	}
+
+	working := h.Rounding.Apply(cmd.WorkingMinutes)
+	maintenance := h.Rounding.Apply(cmd.MaintenanceMinutes)
+	if working <= 0 || working > domain.MaxDailyMinutes {
+		return nil, util.NewFieldError("working_minutes", domain.ErrInvalidWorkingHours)
+	}
+	if maintenance < 0 || maintenance > domain.MaxDailyMinutes {
+		return nil, util.NewFieldError("maintenance_minutes", domain.ErrInvalidMaintenanceHours)
+	}
+	if working+maintenance > domain.MaxDailyMinutes {
+		return nil, util.NewValidationError(domain.ErrInvalidHoursSum)
+	}
+
+	workDate, err := storedWorkDate(ctx, h.Repo, cmd.Id, cmd.UserId, cmd.WorkDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The shift is checked against the minutes as reported, rounding up must not reject them
+	// even though the stored minutes may then exceed the shift
+	s, err := parseSchedule(
+		cmd.WorkDate,
+		workDate,
+		cmd.StartTime,
+		cmd.EndTime,
+		cmd.WorkingMinutes+cmd.MaintenanceMinutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.NewReport(
+		cmd.Id,
+		cmd.UserId,
+		cmd.LocationId,
+		working,
+		maintenance,
+		s.WorkDate,
+		s.StartTime,
+		s.EndTime,
+		domain.Pending,
+		0,
+	)
+
+	if err := checkAbsence(ctx, h.Absences, cmd.UserId, s.WorkDate); err != nil {
+		return nil, err
+	}
+
+	warnings, err := checkCompliance(ctx, h.Compliance, report)
+	if err != nil {
+		return nil, err
+	}
+
+	change := domain.NewStatusChange(
+		uuid.New().String(),
+		cmd.Id,
+		domain.Pending,
+		cmd.UserId,
+		nil,
+		uint64(time.Now().Unix()),
+	)
+
+	resubmittedReport, err := h.Repo.Resubmit(ctx, report, change)
+	if err != nil {
+		return nil, err
+	}
+	resubmittedReport.ComplianceWarnings = warnings
+
+	return resubmittedReport, nil
+}
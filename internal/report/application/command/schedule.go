@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+type schedule struct {
+	WorkDate  domain.Date
+	StartTime *string
+	EndTime   *string
+}
+
+// parseSchedule validates the work date and optional shift times of a report.
+// An empty work date falls back to fallback.
+func parseSchedule(
+	workDate string,
+	fallback domain.Date,
+	startTime, endTime string,
+	totalMinutes uint64,
+) (*schedule, error) {
+	s := &schedule{WorkDate: fallback}
+	if workDate != "" {
+		date, err := domain.ParseDate(workDate)
+		if err != nil {
+			return nil, util.NewFieldError("work_date", domain.ErrInvalidWorkDate)
+		}
+		if date.After(time.Now()) {
+			return nil, util.NewFieldError("work_date", domain.ErrWorkDateInFuture)
+		}
+		s.WorkDate = date
+	}
+
+	if startTime == "" && endTime == "" {
+		return s, nil
+	}
+	if startTime == "" || endTime == "" {
+		return nil, util.NewValidationError(domain.ErrInvalidShiftTimes)
+	}
+
+	duration, err := domain.ShiftDuration(startTime, endTime)
+	if err != nil {
+		return nil, util.NewValidationError(err)
+	}
+	if time.Duration(totalMinutes)*time.Minute > duration {
+		return nil, util.NewValidationError(domain.ErrHoursExceedShift)
+	}
+
+	s.StartTime = &startTime
+	s.EndTime = &endTime
+
+	return s, nil
+}
+
+// storedWorkDate returns the work date of the user's report when workDate is omitted, so an
+// edit without one keeps the report on its day
+func storedWorkDate(
+	ctx context.Context,
+	repo domain.ReportRepository,
+	reportId, userId, workDate string,
+) (domain.Date, error) {
+	if workDate != "" {
+		return domain.Date{}, nil
+	}
+
+	report, err := repo.GetByIdWithUserId(ctx, reportId, userId, nil)
+	if err != nil {
+		return domain.Date{}, err
+	}
+
+	return report.WorkDate, nil
+}
+
+// checkAbsence rejects a report for a day the user took off, a nil checker skips it. A half
+// day absence leaves the other half of the day to work.
+func checkAbsence(ctx context.Context, checker domain.AbsenceChecker, userId string, workDate domain.Date) error {
+	if checker == nil {
+		return nil
+	}
+
+	absent, err := checker.HasFullDayAbsence(ctx, userId, workDate)
+	if err != nil {
+		return err
+	}
+	if absent {
+		return util.NewConflictError(domain.ErrAbsentOnWorkDate)
+	}
+
+	return nil
+}
+
+// checkCompliance evaluates the working time rules for the report, a nil checker skips them
+func checkCompliance(
+	ctx context.Context,
+	checker domain.ComplianceChecker,
+	report *domain.Report,
+) ([]domain.ComplianceWarning, error) {
+	if checker == nil {
+		return nil, nil
+	}
+
+	return checker.CheckReport(ctx, report)
+}
@@ -7,15 +7,24 @@ import (
 )
 
 type UpdatePendingReportCommand struct {
-	UserId           string
-	Id               string
-	LocationId       string
-	WorkingHours     uint64
-	MaintenanceHours uint64
+	UserId             string
+	Id                 string
+	LocationId         string
+	WorkingMinutes     uint64
+	MaintenanceMinutes uint64
+	WorkDate           string
+	StartTime          string
+	EndTime            string
 }
 
 type UpdatePendingReportHandler struct {
 	Repo domain.ReportRepository
+	// Rounding is applied to the reported minutes before they are validated and stored
+	Rounding domain.RoundingRule
+	// Compliance checks the working time rules, nil skips them
+	Compliance domain.ComplianceChecker
+	// Absences rejects reports for days the user took off, nil skips the check
+	Absences domain.AbsenceChecker
 }
 
 func (h *UpdatePendingReportHandler) Handle(
@@ -23,30 +32,66 @@ func (h *UpdatePendingReportHandler) Handle(
 	cmd UpdatePendingReportCommand,
 ) (*domain.Report, error) {
 	if cmd.LocationId == "" || len(cmd.LocationId) >= 50 {
-		return nil, util.NewValidationError(domain.ErrWrongLocationId)
+		return nil, util.NewFieldError("location_id", domain.ErrWrongLocationId)
 	}
-	if cmd.WorkingHours <= 0 || cmd.WorkingHours > 16 {
-		return nil, util.NewValidationError(domain.ErrInvalidWorkingHours)
+
+	working := h.Rounding.Apply(cmd.WorkingMinutes)
+	maintenance := h.Rounding.Apply(cmd.MaintenanceMinutes)
+	if working <= 0 || working > domain.MaxDailyMinutes {
+		return nil, util.NewFieldError("working_minutes", domain.ErrInvalidWorkingHours)
 	}
-	if cmd.MaintenanceHours < 0 || cmd.MaintenanceHours > 16 {
-		return nil, util.NewValidationError(domain.ErrInvalidMaintenanceHours)
+	if maintenance < 0 || maintenance > domain.MaxDailyMinutes {
+		return nil, util.NewFieldError("maintenance_minutes", domain.ErrInvalidMaintenanceHours)
 	}
-	if cmd.WorkingHours+cmd.MaintenanceHours > 16 {
+	if working+maintenance > domain.MaxDailyMinutes {
 		return nil, util.NewValidationError(domain.ErrInvalidHoursSum)
 	}
 
-	updatedReport, err := h.Repo.Update(
-		ctx,
+	workDate, err := storedWorkDate(ctx, h.Repo, cmd.Id, cmd.UserId, cmd.WorkDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The shift is checked against the minutes as reported, rounding up must not reject them
+	// even though the stored minutes may then exceed the shift
+	s, err := parseSchedule(
+		cmd.WorkDate,
+		workDate,
+		cmd.StartTime,
+		cmd.EndTime,
+		cmd.WorkingMinutes+cmd.MaintenanceMinutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.NewReport(
 		cmd.Id,
 		cmd.UserId,
 		cmd.LocationId,
-		cmd.WorkingHours,
-		cmd.MaintenanceHours,
+		working,
+		maintenance,
+		s.WorkDate,
+		s.StartTime,
+		s.EndTime,
 		domain.Pending,
+		0,
 	)
+
+	if err := checkAbsence(ctx, h.Absences, cmd.UserId, s.WorkDate); err != nil {
+		return nil, err
+	}
+
+	warnings, err := checkCompliance(ctx, h.Compliance, report)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedReport, err := h.Repo.Update(ctx, report)
 	if err != nil {
 		return nil, err
 	}
+	updatedReport.ComplianceWarnings = warnings
 
 	return updatedReport, nil
 }
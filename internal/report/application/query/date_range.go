@@ -0,0 +1,24 @@
+package query
+
+import (
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+// maxRangeDays limits how long a period a single summary or export with overtime can cover
+const maxRangeDays = 366
+
+// checkDateRange requires the filter to set from and to no more than maxRangeDays apart
+func checkDateRange(filter domain.ReportFilter) error {
+	if filter.From == nil || filter.To == nil {
+		return util.NewValidationError(domain.ErrDateRangeRequired)
+	}
+	if filter.From.After(filter.To.Time) {
+		return util.NewValidationError(domain.ErrInvalidDateRange)
+	}
+	if filter.To.Sub(filter.From.Time).Hours()/24 >= maxRangeDays {
+		return util.NewValidationError(domain.ErrDateRangeTooLong)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/report/domain"
+)
+
+type ExportReportsQuery struct {
+	Filter domain.ReportFilter
+}
+
+type ExportReportsHandler struct {
+	Repo domain.ReportRepository
+	// Overtime splits each report into regular time and overtime, nil leaves it out
+	Overtime domain.OvertimeCalculator
+}
+
+// Handle streams the approved reports matching the filter into the exporter. With overtime
+// the range must be bounded, the work of its pay periods is loaded before streaming.
+func (h *ExportReportsHandler) Handle(ctx context.Context, query ExportReportsQuery, exporter domain.ReportExporter) error {
+	write := exporter.Write
+	if h.Overtime != nil {
+		if err := checkDateRange(query.Filter); err != nil {
+			return err
+		}
+		splitter, err := h.Overtime.Splitter(ctx, query.Filter)
+		if err != nil {
+			return err
+		}
+		write = func(report domain.Report) error {
+			overtime := splitter.Split(report)
+			report.Overtime = &overtime
+
+			return exporter.Write(report)
+		}
+	}
+
+	if err := h.Repo.Stream(ctx, []domain.ReportStatus{domain.Approved}, query.Filter, write); err != nil {
+		return err
+	}
+
+	return exporter.Close()
+}
@@ -0,0 +1,118 @@
+package query_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"time-management/internal/report/application/query"
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+// fakeReportRepository streams the given reports, the methods it does not override are not
+// expected to be called
+type fakeReportRepository struct {
+	domain.ReportRepository
+	reports  []domain.Report
+	streamed bool
+}
+
+func (r *fakeReportRepository) Stream(
+	ctx context.Context,
+	statuses []domain.ReportStatus,
+	filter domain.ReportFilter,
+	fn func(report domain.Report) error,
+) error {
+	r.streamed = true
+	for _, report := range r.reports {
+		if err := fn(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeOvertime counts the splitters it loads, each one stands for the approved work of the
+// range being read
+type fakeOvertime struct {
+	domain.OvertimeCalculator
+	loaded int
+}
+
+func (o *fakeOvertime) Splitter(ctx context.Context, filter domain.ReportFilter) (domain.OvertimeSplitter, error) {
+	o.loaded++
+	return fakeSplitter{}, nil
+}
+
+type fakeSplitter struct{}
+
+func (fakeSplitter) Split(report domain.Report) domain.Overtime {
+	return domain.Overtime{RegularMinutes: report.WorkingMinutes}
+}
+
+// fakeExporter keeps the written reports in memory
+type fakeExporter struct {
+	domain.ReportExporter
+	written []domain.Report
+}
+
+func (e *fakeExporter) Write(report domain.Report) error {
+	e.written = append(e.written, report)
+	return nil
+}
+
+func (e *fakeExporter) Close() error {
+	return nil
+}
+
+func TestExportReportsHandler_Handle_Overtime(t *testing.T) {
+	repo := &fakeReportRepository{reports: []domain.Report{{Id: "rep1", WorkingMinutes: 420}}}
+	overtime := &fakeOvertime{}
+	handler := query.ExportReportsHandler{Repo: repo, Overtime: overtime}
+	exporter := &fakeExporter{}
+
+	from := domain.NewDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	to := domain.NewDate(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	// Execute test
+	exportQuery := query.ExportReportsQuery{Filter: domain.ReportFilter{From: &from, To: &to}}
+	err := handler.Handle(context.Background(), exportQuery, exporter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, overtime.loaded)
+	if assert.Len(t, exporter.written, 1) {
+		assert.Equal(t, uint64(420), exporter.written[0].Overtime.RegularMinutes)
+	}
+}
+
+func TestExportReportsHandler_Handle_UnboundedOvertime(t *testing.T) {
+	repo := &fakeReportRepository{reports: []domain.Report{{Id: "rep1", WorkingMinutes: 420}}}
+	overtime := &fakeOvertime{}
+	handler := query.ExportReportsHandler{Repo: repo, Overtime: overtime}
+	exporter := &fakeExporter{}
+
+	// Execute test, without a range the work of every pay period would be loaded up front
+	err := handler.Handle(context.Background(), query.ExportReportsQuery{}, exporter)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrDateRangeRequired)
+	var validationErr *util.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Zero(t, overtime.loaded)
+	assert.False(t, repo.streamed)
+}
+
+func TestExportReportsHandler_Handle_UnboundedWithoutOvertime(t *testing.T) {
+	repo := &fakeReportRepository{reports: []domain.Report{{Id: "rep1", WorkingMinutes: 420}}}
+	handler := query.ExportReportsHandler{Repo: repo}
+	exporter := &fakeExporter{}
+
+	// Execute test
+	err := handler.Handle(context.Background(), query.ExportReportsQuery{}, exporter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, exporter.written, 1)
+}
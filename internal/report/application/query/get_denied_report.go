@@ -14,7 +14,7 @@ type GetDeniedReportHandler struct {
 }
 
 func (h *GetDeniedReportHandler) Handle(ctx context.Context, query GetDeniedReportQuery) (*domain.Report, error) {
-	report, err := h.Repo.GetById(ctx, query.Id, domain.Denied)
+	report, err := h.Repo.GetById(ctx, query.Id, []domain.ReportStatus{domain.Denied})
 	if err != nil {
 		return nil, err
 	}
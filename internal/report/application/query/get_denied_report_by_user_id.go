@@ -18,7 +18,7 @@ func (h *GetDeniedReportByUserIdHandler) Handle(
 	ctx context.Context,
 	query GetDeniedReportByUserIdQuery,
 ) (*domain.Report, error) {
-	reports, err := h.Repo.GetByIdWithUserId(ctx, query.Id, query.UserId, domain.Denied)
+	reports, err := h.Repo.GetByIdWithUserId(ctx, query.Id, query.UserId, []domain.ReportStatus{domain.Denied})
 	if err != nil {
 		return nil, err
 	}
@@ -3,17 +3,28 @@ package query
 import (
 	"context"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/pagination"
 )
 
+type GetDeniedReportsQuery struct {
+	Filter domain.ReportFilter
+	Page   pagination.Request
+}
+
 type GetDeniedReportsHandler struct {
 	Repo domain.ReportRepository
 }
 
-func (h *GetDeniedReportsHandler) Handle(ctx context.Context) ([]domain.Report, error) {
-	reports, err := h.Repo.GetAll(ctx, domain.Denied)
+func (h *GetDeniedReportsHandler) Handle(
+	ctx context.Context,
+	query GetDeniedReportsQuery,
+) (*pagination.Page[domain.Report], error) {
+	reports, total, err := h.Repo.GetAll(ctx, []domain.ReportStatus{domain.Denied}, query.Filter, query.Page)
 	if err != nil {
 		return nil, err
 	}
 
-	return reports, nil
+	page := pagination.NewPage(reports, total, query.Page)
+
+	return &page, nil
 }
@@ -14,7 +14,7 @@ type GetPendingReportHandler struct {
 }
 
 func (h *GetPendingReportHandler) Handle(ctx context.Context, query GetPendingReportQuery) (*domain.Report, error) {
-	reports, err := h.Repo.GetById(ctx, query.Id, domain.Pending)
+	reports, err := h.Repo.GetById(ctx, query.Id, domain.PendingStatuses)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +18,7 @@ func (h *GetPendingReportByUserIdHandler) Handle(
 	ctx context.Context,
 	query GetPendingReportByUserIdQuery,
 ) (*domain.Report, error) {
-	report, err := h.Repo.GetByIdWithUserId(ctx, query.Id, query.UserId, domain.Pending)
+	report, err := h.Repo.GetByIdWithUserId(ctx, query.Id, query.UserId, domain.PendingStatuses)
 	if err != nil {
 		return nil, err
 	}
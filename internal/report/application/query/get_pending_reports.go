@@ -3,17 +3,28 @@ package query
 import (
 	"context"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/pagination"
 )
 
+type GetPendingReportsQuery struct {
+	Filter domain.ReportFilter
+	Page   pagination.Request
+}
+
 type GetPendingReportsHandler struct {
 	Repo domain.ReportRepository
 }
 
-func (h *GetPendingReportsHandler) Handle(ctx context.Context) ([]domain.Report, error) {
-	reports, err := h.Repo.GetAll(ctx, domain.Pending)
+func (h *GetPendingReportsHandler) Handle(
+	ctx context.Context,
+	query GetPendingReportsQuery,
+) (*pagination.Page[domain.Report], error) {
+	reports, total, err := h.Repo.GetAll(ctx, domain.PendingStatuses, query.Filter, query.Page)
 	if err != nil {
 		return nil, err
 	}
 
-	return reports, nil
+	page := pagination.NewPage(reports, total, query.Page)
+
+	return &page, nil
 }
@@ -18,7 +18,7 @@ func (h *GetReportByUserIdHandler) Handle(
 	ctx context.Context,
 	query GetReportByUserIdQuery,
 ) (*domain.Report, error) {
-	reports, err := h.Repo.GetByIdWithUserId(ctx, query.Id, query.UserId, domain.Approved)
+	reports, err := h.Repo.GetByIdWithUserId(ctx, query.Id, query.UserId, []domain.ReportStatus{domain.Approved})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,26 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/report/domain"
+)
+
+// GetReportHistoryQuery returns the status changes of a report. When UserId is set,
+// only a report owned by that user is found.
+type GetReportHistoryQuery struct {
+	Id     string
+	UserId string
+}
+
+type GetReportHistoryHandler struct {
+	Repo domain.ReportRepository
+}
+
+func (h *GetReportHistoryHandler) Handle(ctx context.Context, query GetReportHistoryQuery) ([]domain.StatusChange, error) {
+	history, err := h.Repo.GetStatusHistory(ctx, query.Id, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
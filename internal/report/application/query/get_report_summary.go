@@ -0,0 +1,50 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+type GetReportSummaryQuery struct {
+	GroupBy string
+	Filter  domain.ReportFilter
+}
+
+type GetReportSummaryHandler struct {
+	Repo domain.ReportRepository
+	// Overtime splits the approved hours of each employee, nil leaves it out
+	Overtime domain.OvertimeCalculator
+}
+
+func (h *GetReportSummaryHandler) Handle(ctx context.Context, query GetReportSummaryQuery) (*domain.Summary, error) {
+	groupBy, err := domain.ParseSummaryGroup(query.GroupBy)
+	if err != nil {
+		return nil, util.NewValidationError(err)
+	}
+	if err := checkDateRange(query.Filter); err != nil {
+		return nil, err
+	}
+
+	rows, err := h.Repo.GetSummary(ctx, groupBy, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupBy == domain.GroupByEmployee && h.Overtime != nil {
+		userIds := make([]string, len(rows))
+		for i, row := range rows {
+			userIds[i] = row.Key
+		}
+		overtime, err := h.Overtime.Summarize(ctx, userIds, *query.Filter.From, *query.Filter.To)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			split := overtime[rows[i].Key]
+			rows[i].Overtime = &split
+		}
+	}
+
+	return domain.NewSummary(groupBy, *query.Filter.From, *query.Filter.To, rows), nil
+}
@@ -14,7 +14,7 @@ type GetReportHandler struct {
 }
 
 func (h *GetReportHandler) Handle(ctx context.Context, query GetReportQuery) (*domain.Report, error) {
-	report, err := h.Repo.GetById(ctx, query.Id, domain.Approved)
+	report, err := h.Repo.GetById(ctx, query.Id, []domain.ReportStatus{domain.Approved})
 	if err != nil {
 		return nil, err
 	}
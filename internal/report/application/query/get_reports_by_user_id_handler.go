@@ -3,10 +3,13 @@ package query
 import (
 	"context"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/pagination"
 )
 
 type GetReportsByUserIdQuery struct {
 	UserId string
+	Filter domain.ReportFilter
+	Page   pagination.Request
 }
 
 type GetReportsByUserIdHandler struct {
@@ -16,11 +19,16 @@ type GetReportsByUserIdHandler struct {
 func (h *GetReportsByUserIdHandler) Handle(
 	ctx context.Context,
 	query GetReportsByUserIdQuery,
-) ([]domain.Report, error) {
-	reports, err := h.Repo.GetAllWithUserId(ctx, query.UserId, domain.Approved)
+) (*pagination.Page[domain.Report], error) {
+	filter := query.Filter
+	filter.UserId = query.UserId
+
+	reports, total, err := h.Repo.GetAll(ctx, []domain.ReportStatus{domain.Approved}, filter, query.Page)
 	if err != nil {
 		return nil, err
 	}
 
-	return reports, nil
+	page := pagination.NewPage(reports, total, query.Page)
+
+	return &page, nil
 }
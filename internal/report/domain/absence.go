@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// AbsenceChecker looks up the leave of employees, reports are not accepted for days they
+// took off
+type AbsenceChecker interface {
+	// HasFullDayAbsence reports whether the user has an approved absence taking the whole
+	// work date off
+	HasFullDayAbsence(ctx context.Context, userId string, workDate Date) (bool, error)
+}
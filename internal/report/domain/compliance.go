@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// ComplianceWarning is a working time rule a report breaks without being rejected
+type ComplianceWarning struct {
+	Rule    string `json:"rule"`
+	Period  string `json:"period"`
+	Message string `json:"message"`
+}
+
+// ComplianceChecker evaluates the working time rules of the organisation. Violations of
+// hard rules are returned as a validation error, the others as warnings.
+type ComplianceChecker interface {
+	// CheckReport evaluates the report as if it were stored, in place of its stored version
+	CheckReport(ctx context.Context, report *Report) ([]ComplianceWarning, error)
+	// CheckStoredReport evaluates a stored report before it is approved
+	CheckStoredReport(ctx context.Context, reportId string) ([]ComplianceWarning, error)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "math"
+
+// MinutesPerHour converts the whole hours still accepted by the API into minutes
+const MinutesPerHour = 60
+
+// MaxDailyMinutes is MaxDailyHours in minutes, the unit durations are stored in
+const MaxDailyMinutes = MaxDailyHours * MinutesPerHour
+
+// MinutesToHours returns minutes as decimal hours rounded to two places, e.g. 465 as 7.75
+func MinutesToHours(minutes uint64) float64 {
+	return math.Round(float64(minutes)/MinutesPerHour*100) / 100
+}
+
+// HoursToMinutes returns decimal hours as whole minutes, e.g. 7.75 as 465
+func HoursToMinutes(hours float64) uint64 {
+	return uint64(math.Round(hours * MinutesPerHour))
+}
+
+type RoundingMode string
+
+const (
+	RoundNearest RoundingMode = "nearest"
+	RoundUp      RoundingMode = "up"
+	RoundDown    RoundingMode = "down"
+)
+
+// RoundingRule rounds reported durations to a multiple of Increment minutes, for
+// organisations that book time in quarter hours for example. An Increment of 0 or 1
+// keeps the minutes as reported. Time that was reported is never rounded away, a
+// duration that would round down to 0 becomes one increment.
+type RoundingRule struct {
+	Increment uint64
+	Mode      RoundingMode
+}
+
+func (r RoundingRule) Apply(minutes uint64) uint64 {
+	if r.Increment <= 1 {
+		return minutes
+	}
+
+	remainder := minutes % r.Increment
+	if remainder == 0 {
+		return minutes
+	}
+
+	down := minutes - remainder
+	if down == 0 {
+		return r.Increment
+	}
+
+	switch r.Mode {
+	case RoundUp:
+		return down + r.Increment
+	case RoundDown:
+		return down
+	default:
+		// Halves are rounded up, 7:53 becomes 8:00 and 7:52 becomes 7:45 with 15 minute increments
+		if remainder*2 >= r.Increment {
+			return down + r.Increment
+		}
+		return down
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinutesToHours(t *testing.T) {
+	assert.Equal(t, 7.75, MinutesToHours(465))
+	assert.Equal(t, 8.0, MinutesToHours(480))
+	assert.Equal(t, 0.33, MinutesToHours(20))
+	assert.Equal(t, uint64(465), HoursToMinutes(7.75))
+	assert.Equal(t, uint64(20), HoursToMinutes(0.33))
+}
+
+func TestRoundingRule_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    RoundingRule
+		minutes uint64
+		want    uint64
+	}{
+		{"no rounding", RoundingRule{}, 467, 467},
+		{"nearest down", RoundingRule{Increment: 15, Mode: RoundNearest}, 467, 465},
+		{"nearest up", RoundingRule{Increment: 15, Mode: RoundNearest}, 473, 480},
+		{"exact", RoundingRule{Increment: 15, Mode: RoundNearest}, 450, 450},
+		{"up", RoundingRule{Increment: 15, Mode: RoundUp}, 451, 465},
+		{"down", RoundingRule{Increment: 15, Mode: RoundDown}, 464, 450},
+		{"default mode is nearest", RoundingRule{Increment: 30}, 20, 30},
+		{"nearest keeps short time", RoundingRule{Increment: 15, Mode: RoundNearest}, 5, 15},
+		{"down keeps short time", RoundingRule{Increment: 15, Mode: RoundDown}, 14, 15},
+		{"zero", RoundingRule{Increment: 15, Mode: RoundUp}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Apply(tt.minutes))
+		})
+	}
+}
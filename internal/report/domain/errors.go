@@ -5,11 +5,60 @@ import "errors"
 var (
 	ErrReportNotFound               = errors.New("report not found")
 	ErrWrongEmployeeId              = errors.New("wrong employee id: employee does not exist")
+	ErrEmployeeInactive             = errors.New("employee is deactivated and cannot submit reports")
 	ErrWrongLocationId              = errors.New("wrong location id: location does not exist")
 	ErrInvalidWorkingHours          = errors.New("invalid working hours")
 	ErrInvalidMaintenanceHours      = errors.New("invalid maintenance hours")
 	ErrInvalidHoursInput            = errors.New("invalid hours input")
 	ErrInvalidHoursSum              = errors.New("invalid hours sum")
-	ErrCannotUpdateReport           = errors.New("cannot update report which is approved or denied")
+	ErrCannotUpdateReport           = errors.New("cannot update report which is not pending")
 	ErrReportNotFoundOrUnauthorized = errors.New("report not found")
+	ErrInvalidWorkDate              = errors.New("invalid work date: expected format YYYY-MM-DD")
+	ErrWorkDateInFuture             = errors.New("work date cannot be in the future")
+	ErrInvalidShiftTimes            = errors.New("invalid shift times: expected HH:MM with end after start")
+	ErrHoursExceedShift             = errors.New("reported hours exceed shift duration")
+	ErrDailyHoursExceeded           = errors.New("daily hours limit exceeded for work date")
+	ErrInvalidDateRange             = errors.New("invalid date range: from must not be after to")
+	ErrDateRangeRequired            = errors.New("date range required: from and to must be set")
+	ErrDateRangeTooLong             = errors.New("date range too long: at most 366 days")
+	ErrInvalidSummaryGroup          = errors.New("invalid group: expected employee, location, day, week or month")
+	ErrInvalidExportFormat          = errors.New("invalid export format: expected csv or xlsx")
+	ErrReasonRequired               = errors.New("reason required: a report cannot be denied without a reason")
+	ErrReasonTooLong                = errors.New("reason too long: at most 500 characters")
+	ErrMissingActingUser            = errors.New("acting user required")
+	ErrInvalidStatusTransition      = errors.New("invalid report status transition")
+	ErrInvalidReportStatus          = errors.New("invalid report status: expected pending, approved, denied or reopened")
+	ErrEntriesAlreadyConverted      = errors.New("time entries were already converted into a report")
+	ErrAbsentOnWorkDate             = errors.New("employee has an approved absence on the work date")
 )
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrReportNotFound:               "report_not_found",
+	ErrWrongEmployeeId:              "employee_id_invalid",
+	ErrEmployeeInactive:             "employee_inactive",
+	ErrWrongLocationId:              "location_id_invalid",
+	ErrInvalidWorkingHours:          "working_hours_invalid",
+	ErrInvalidMaintenanceHours:      "maintenance_hours_invalid",
+	ErrInvalidHoursInput:            "hours_negative",
+	ErrInvalidHoursSum:              "hours_sum_invalid",
+	ErrCannotUpdateReport:           "report_not_pending",
+	ErrReportNotFoundOrUnauthorized: "report_not_found",
+	ErrInvalidWorkDate:              "work_date_invalid",
+	ErrWorkDateInFuture:             "work_date_in_future",
+	ErrInvalidShiftTimes:            "shift_times_invalid",
+	ErrHoursExceedShift:             "hours_exceed_shift",
+	ErrDailyHoursExceeded:           "daily_hours_exceeded",
+	ErrInvalidDateRange:             "date_range_invalid",
+	ErrDateRangeRequired:            "date_range_required",
+	ErrDateRangeTooLong:             "date_range_too_long",
+	ErrInvalidSummaryGroup:          "summary_group_invalid",
+	ErrInvalidExportFormat:          "export_format_invalid",
+	ErrReasonRequired:               "reason_required",
+	ErrReasonTooLong:                "reason_too_long",
+	ErrMissingActingUser:            "acting_user_required",
+	ErrInvalidStatusTransition:      "status_transition_invalid",
+	ErrInvalidReportStatus:          "report_status_invalid",
+	ErrEntriesAlreadyConverted:      "time_entries_converted",
+	ErrAbsentOnWorkDate:             "absent_on_work_date",
+}
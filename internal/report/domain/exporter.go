@@ -0,0 +1,11 @@
+package domain
+
+// ReportExporter writes reports to a file format one at a time, so that large
+// exports never need to hold every report in memory
+type ReportExporter interface {
+	ContentType() string
+	FileExtension() string
+	Write(report Report) error
+	// Close finishes the file after the last report has been written
+	Close() error
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Overtime splits approved time into the part within the contracted hours, the overtime
+// on top of them and the time worked on days off or holidays. Reports carry no target.
+type Overtime struct {
+	TargetMinutes         uint64 `json:"target_minutes"`
+	RegularMinutes        uint64 `json:"regular_minutes"`
+	OvertimeMinutes       uint64 `json:"overtime_minutes"`
+	WeekendHolidayMinutes uint64 `json:"weekend_holiday_minutes"`
+}
+
+func (o Overtime) Add(other Overtime) Overtime {
+	return Overtime{
+		TargetMinutes:         o.TargetMinutes + other.TargetMinutes,
+		RegularMinutes:        o.RegularMinutes + other.RegularMinutes,
+		OvertimeMinutes:       o.OvertimeMinutes + other.OvertimeMinutes,
+		WeekendHolidayMinutes: o.WeekendHolidayMinutes + other.WeekendHolidayMinutes,
+	}
+}
+
+// MarshalJSON adds the durations in decimal hours next to the minutes
+func (o Overtime) MarshalJSON() ([]byte, error) {
+	type overtime Overtime
+	return json.Marshal(struct {
+		overtime
+		TargetHours         float64 `json:"target_hours"`
+		RegularHours        float64 `json:"regular_hours"`
+		OvertimeHours       float64 `json:"overtime_hours"`
+		WeekendHolidayHours float64 `json:"weekend_holiday_hours"`
+	}{
+		overtime:            overtime(o),
+		TargetHours:         MinutesToHours(o.TargetMinutes),
+		RegularHours:        MinutesToHours(o.RegularMinutes),
+		OvertimeHours:       MinutesToHours(o.OvertimeMinutes),
+		WeekendHolidayHours: MinutesToHours(o.WeekendHolidayMinutes),
+	})
+}
+
+// OvertimeCalculator splits approved time against the contracts of the employees
+type OvertimeCalculator interface {
+	// Summarize returns the overtime of the employees between from and to, keyed by user id
+	Summarize(ctx context.Context, userIds []string, from, to Date) (map[string]Overtime, error)
+	// Splitter returns a splitter for the reports matching the filter, loading what they are
+	// split against up front
+	Splitter(ctx context.Context, filter ReportFilter) (OvertimeSplitter, error)
+}
+
+// OvertimeSplitter splits approved reports one at a time within their pay period. It only
+// uses what was loaded when it was created, so reports can be split while they are still
+// streamed from the database without holding a second connection.
+type OvertimeSplitter interface {
+	Split(report Report) Overtime
+}
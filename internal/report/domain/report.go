@@ -1,13 +1,32 @@
 package domain
 
+import (
+	"encoding/json"
+	"time"
+)
+
+// MaxDailyHours is the maximum number of hours a user can report for a single work date
+const MaxDailyHours = 16
+
+// ClockLayout is the format used for shift start and end times
+const ClockLayout = "15:04"
+
 type Report struct {
-	Id               string       `json:"id"`
-	User             User         `json:"user"`
-	Location         Location     `json:"location"`
-	WorkingHours     uint64       `json:"working_hours"`
-	MaintenanceHours uint64       `json:"maintenance_hours"`
-	Status           ReportStatus `json:"status"`
-	CreatedAt        uint64       `json:"created_at"`
+	Id                 string       `json:"id"`
+	User               User         `json:"user"`
+	Location           Location     `json:"location"`
+	WorkingMinutes     uint64       `json:"working_minutes"`
+	MaintenanceMinutes uint64       `json:"maintenance_minutes"`
+	WorkDate           Date         `json:"work_date"`
+	StartTime          *string      `json:"start_time,omitempty"`
+	EndTime            *string      `json:"end_time,omitempty"`
+	ShiftMinutes       *uint64      `json:"shift_minutes,omitempty"`
+	Status             ReportStatus `json:"status"`
+	CreatedAt          uint64       `json:"created_at"`
+	// ComplianceWarnings are filled when the report is submitted, they are not stored
+	ComplianceWarnings []ComplianceWarning `json:"compliance_warnings,omitempty"`
+	// Overtime is filled for exports, it is not stored
+	Overtime *Overtime `json:"overtime,omitempty"`
 }
 
 type User struct {
@@ -26,18 +45,83 @@ func NewReport(
 	id string,
 	userId string,
 	locationId string,
-	workingHours uint64,
-	maintenanceHours uint64,
+	workingMinutes uint64,
+	maintenanceMinutes uint64,
+	workDate Date,
+	startTime *string,
+	endTime *string,
 	status ReportStatus,
 	createdAt uint64,
 ) *Report {
-	return &Report{
-		Id:               id,
-		User:             User{Id: userId},
-		Location:         Location{Id: locationId},
-		WorkingHours:     workingHours,
-		MaintenanceHours: maintenanceHours,
-		Status:           status,
-		CreatedAt:        createdAt,
+	report := &Report{
+		Id:                 id,
+		User:               User{Id: userId},
+		Location:           Location{Id: locationId},
+		WorkingMinutes:     workingMinutes,
+		MaintenanceMinutes: maintenanceMinutes,
+		WorkDate:           workDate,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		Status:             status,
+		CreatedAt:          createdAt,
+	}
+	report.ComputeShift()
+
+	return report
+}
+
+// TotalMinutes is the working and maintenance time of the report
+func (r Report) TotalMinutes() uint64 {
+	return r.WorkingMinutes + r.MaintenanceMinutes
+}
+
+// MarshalJSON adds the durations in decimal hours next to the minutes
+func (r Report) MarshalJSON() ([]byte, error) {
+	type report Report
+	return json.Marshal(struct {
+		report
+		WorkingHours     float64 `json:"working_hours"`
+		MaintenanceHours float64 `json:"maintenance_hours"`
+		TotalMinutes     uint64  `json:"total_minutes"`
+		TotalHours       float64 `json:"total_hours"`
+	}{
+		report:           report(r),
+		WorkingHours:     MinutesToHours(r.WorkingMinutes),
+		MaintenanceHours: MinutesToHours(r.MaintenanceMinutes),
+		TotalMinutes:     r.TotalMinutes(),
+		TotalHours:       MinutesToHours(r.TotalMinutes()),
+	})
+}
+
+// ComputeShift fills ShiftMinutes from the start and end times when both are set
+func (r *Report) ComputeShift() {
+	r.ShiftMinutes = nil
+	if r.StartTime == nil || r.EndTime == nil {
+		return
+	}
+
+	duration, err := ShiftDuration(*r.StartTime, *r.EndTime)
+	if err != nil {
+		return
+	}
+
+	minutes := uint64(duration.Minutes())
+	r.ShiftMinutes = &minutes
+}
+
+// ShiftDuration returns the time between two clock times in ClockLayout format
+func ShiftDuration(start, end string) (time.Duration, error) {
+	startTime, err := time.Parse(ClockLayout, start)
+	if err != nil {
+		return 0, ErrInvalidShiftTimes
+	}
+	endTime, err := time.Parse(ClockLayout, end)
+	if err != nil {
+		return 0, ErrInvalidShiftTimes
 	}
+	if !endTime.After(startTime) {
+		return 0, ErrInvalidShiftTimes
+	}
+
+	return endTime.Sub(startTime), nil
 }
@@ -1,20 +1,45 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+)
+
+// ReportFilter narrows report lists; zero values are ignored
+type ReportFilter struct {
+	UserId     string
+	LocationId string
+	From       *Date
+	To         *Date
+	// Status narrows the statuses a list is limited to, outside them nothing matches
+	Status *ReportStatus
+}
 
 type ReportRepository interface {
+	// Create, CreateFromEntries, Update and Resubmit fail with ErrDailyHoursExceeded when the
+	// user's reports for the work date would exceed MaxDailyMinutes
 	Create(ctx context.Context, report *Report) (*Report, error)
-	GetAll(ctx context.Context, status ReportStatus) ([]Report, error)
-	GetAllWithUserId(ctx context.Context, employeeId string, status ReportStatus) ([]Report, error)
-	GetById(ctx context.Context, id string, status ReportStatus) (*Report, error)
-	GetByIdWithUserId(ctx context.Context, id, userId string, status ReportStatus) (*Report, error)
-	Update(
-		ctx context.Context,
-		id, userId, locationId string,
-		workingHours, maintenanceHours uint64,
-		status ReportStatus,
-	) (*Report, error)
-	Approve(ctx context.Context, id string) error
-	Deny(ctx context.Context, id string) error
+	// CreateFromEntries stores the report and links the time entries it was converted from
+	// in one transaction, failing with ErrEntriesAlreadyConverted when any of them was
+	// converted meanwhile
+	CreateFromEntries(ctx context.Context, report *Report, entryIds []string) (*Report, error)
+	// GetAll, GetById, GetByIdWithUserId and Stream only match reports in one of the given
+	// statuses, no statuses match reports in every status
+	GetAll(ctx context.Context, statuses []ReportStatus, filter ReportFilter, page pagination.Request) ([]Report, int, error)
+	GetById(ctx context.Context, id string, statuses []ReportStatus) (*Report, error)
+	GetByIdWithUserId(ctx context.Context, id, userId string, statuses []ReportStatus) (*Report, error)
+	// Stream calls fn for every report matching the filter, ordered by user and work date
+	Stream(ctx context.Context, statuses []ReportStatus, filter ReportFilter, fn func(report Report) error) error
+	GetSummary(ctx context.Context, groupBy SummaryGroup, filter ReportFilter) ([]SummaryRow, error)
+	// IsEmployeeActive reports whether the user exists and is active
+	IsEmployeeActive(ctx context.Context, userId string) (bool, error)
+	Update(ctx context.Context, report *Report) (*Report, error)
+	// Approve, Deny, Reopen and Resubmit move the report to a new status when the transition
+	// is allowed and record the change in its history
+	Approve(ctx context.Context, change *StatusChange) error
+	Deny(ctx context.Context, change *StatusChange) error
+	Reopen(ctx context.Context, change *StatusChange) error
+	Resubmit(ctx context.Context, report *Report, change *StatusChange) (*Report, error)
+	GetStatusHistory(ctx context.Context, reportId, userId string) ([]StatusChange, error)
 	Delete(ctx context.Context, id string) error
 }
@@ -10,11 +10,45 @@ const (
 	Pending  ReportStatus = iota // 0
 	Approved                     // 1
 	Denied                       // 2
+	Reopened                     // 3
 )
 
+// transitions lists the statuses each status can move to. Reopened reports were
+// approved and sent back for review, so they are decided again like pending ones.
+var transitions = map[ReportStatus][]ReportStatus{
+	Pending:  {Approved, Denied},
+	Approved: {Reopened},
+	Denied:   {Pending},
+	Reopened: {Approved, Denied},
+}
+
+// PendingStatuses are the statuses waiting for a decision. Reopened reports were sent back
+// for review, so they are listed and decided together with pending ones.
+var PendingStatuses = []ReportStatus{Pending, Reopened}
+
 // To convert the ReportStatus to a string
 func (s ReportStatus) String() string {
-	return [...]string{"pending", "approved", "denied"}[s]
+	return [...]string{"pending", "approved", "denied", "reopened"}[s]
+}
+
+// CanTransitionTo reports whether a report with this status may move to the given status
+func (s ReportStatus) CanTransitionTo(to ReportStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckTransition returns ErrInvalidStatusTransition when from cannot move to to
+func CheckTransition(from, to ReportStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, from, to)
+	}
+
+	return nil
 }
 
 // ParseReportStatus For parsing a string back to ReportStatus
@@ -26,7 +60,9 @@ func ParseReportStatus(status string) (ReportStatus, error) {
 		return Approved, nil
 	case "denied":
 		return Denied, nil
+	case "reopened":
+		return Reopened, nil
 	default:
-		return -1, fmt.Errorf("invalid report status: %s", status)
+		return -1, ErrInvalidReportStatus
 	}
 }
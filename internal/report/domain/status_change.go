@@ -0,0 +1,35 @@
+package domain
+
+// MaxReasonLength is the longest reason that can be given for a status change
+const MaxReasonLength = 500
+
+// StatusChange records a single report status transition, who made it and why
+type StatusChange struct {
+	Id         string       `json:"id"`
+	ReportId   string       `json:"report_id"`
+	FromStatus ReportStatus `json:"from_status"`
+	ToStatus   ReportStatus `json:"to_status"`
+	Reason     *string      `json:"reason,omitempty"`
+	ChangedBy  User         `json:"changed_by"`
+	ChangedAt  uint64       `json:"changed_at"`
+}
+
+// NewStatusChange creates a change to the given status. The previous status is
+// filled in by the repository when the change is applied.
+func NewStatusChange(
+	id string,
+	reportId string,
+	toStatus ReportStatus,
+	changedBy string,
+	reason *string,
+	changedAt uint64,
+) *StatusChange {
+	return &StatusChange{
+		Id:        id,
+		ReportId:  reportId,
+		ToStatus:  toStatus,
+		Reason:    reason,
+		ChangedBy: User{Id: changedBy},
+		ChangedAt: changedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time-management/internal/report/domain"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to domain.ReportStatus
+		allowed  bool
+	}{
+		{domain.Pending, domain.Approved, true},
+		{domain.Pending, domain.Denied, true},
+		{domain.Denied, domain.Pending, true},
+		{domain.Approved, domain.Reopened, true},
+		{domain.Reopened, domain.Approved, true},
+		{domain.Reopened, domain.Denied, true},
+		{domain.Approved, domain.Denied, false},
+		{domain.Denied, domain.Approved, false},
+		{domain.Pending, domain.Reopened, false},
+		{domain.Approved, domain.Approved, false},
+	}
+
+	for _, tt := range tests {
+		err := domain.CheckTransition(tt.from, tt.to)
+		if tt.allowed {
+			assert.NoError(t, err, "%s to %s", tt.from, tt.to)
+		} else {
+			assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition, "%s to %s", tt.from, tt.to)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import "encoding/json"
+
+// SummaryGroup defines how report hours are grouped in a summary
+type SummaryGroup string
+
+const (
+	GroupByEmployee SummaryGroup = "employee"
+	GroupByLocation SummaryGroup = "location"
+	GroupByDay      SummaryGroup = "day"
+	GroupByWeek     SummaryGroup = "week"
+	GroupByMonth    SummaryGroup = "month"
+)
+
+// ParseSummaryGroup For parsing a string back to SummaryGroup
+func ParseSummaryGroup(group string) (SummaryGroup, error) {
+	switch SummaryGroup(group) {
+	case GroupByEmployee, GroupByLocation, GroupByDay, GroupByWeek, GroupByMonth:
+		return SummaryGroup(group), nil
+	default:
+		return "", ErrInvalidSummaryGroup
+	}
+}
+
+// Hours sums the reported durations in minutes
+type Hours struct {
+	WorkingMinutes     uint64 `json:"working_minutes"`
+	MaintenanceMinutes uint64 `json:"maintenance_minutes"`
+	TotalMinutes       uint64 `json:"total_minutes"`
+}
+
+func (h Hours) Add(other Hours) Hours {
+	return Hours{
+		WorkingMinutes:     h.WorkingMinutes + other.WorkingMinutes,
+		MaintenanceMinutes: h.MaintenanceMinutes + other.MaintenanceMinutes,
+		TotalMinutes:       h.TotalMinutes + other.TotalMinutes,
+	}
+}
+
+// MarshalJSON adds the sums in decimal hours next to the minutes
+func (h Hours) MarshalJSON() ([]byte, error) {
+	type hours Hours
+	return json.Marshal(struct {
+		hours
+		WorkingHours     float64 `json:"working_hours"`
+		MaintenanceHours float64 `json:"maintenance_hours"`
+		TotalHours       float64 `json:"total_hours"`
+	}{
+		hours:            hours(h),
+		WorkingHours:     MinutesToHours(h.WorkingMinutes),
+		MaintenanceHours: MinutesToHours(h.MaintenanceMinutes),
+		TotalHours:       MinutesToHours(h.TotalMinutes),
+	})
+}
+
+// SummaryRow holds the hours of one group, split by report status.
+// Key is the user or location id, or the period (2024-10-07, 2024-W41, 2024-10).
+type SummaryRow struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Approved Hours  `json:"approved"`
+	Pending  Hours  `json:"pending"`
+	Denied   Hours  `json:"denied"`
+	// Overtime splits the approved hours, it is only filled when grouping by employee
+	Overtime *Overtime `json:"overtime,omitempty"`
+}
+
+type Summary struct {
+	GroupBy SummaryGroup `json:"group_by"`
+	From    Date         `json:"from"`
+	To      Date         `json:"to"`
+	Rows    []SummaryRow `json:"rows"`
+	Totals  SummaryRow   `json:"totals"`
+}
+
+// NewSummary builds a summary from its rows and computes the totals across them
+func NewSummary(groupBy SummaryGroup, from, to Date, rows []SummaryRow) *Summary {
+	if rows == nil {
+		rows = []SummaryRow{}
+	}
+
+	totals := SummaryRow{Key: "total", Label: "Total"}
+	for _, row := range rows {
+		totals.Approved = totals.Approved.Add(row.Approved)
+		totals.Pending = totals.Pending.Add(row.Pending)
+		totals.Denied = totals.Denied.Add(row.Denied)
+		if row.Overtime != nil {
+			var overtime Overtime
+			if totals.Overtime != nil {
+				overtime = *totals.Overtime
+			}
+			overtime = overtime.Add(*row.Overtime)
+			totals.Overtime = &overtime
+		}
+	}
+
+	return &Summary{GroupBy: groupBy, From: from, To: to, Rows: rows, Totals: totals}
+}
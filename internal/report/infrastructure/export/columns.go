@@ -0,0 +1,72 @@
+package export
+
+import (
+	"fmt"
+	"time"
+	"time-management/internal/report/domain"
+)
+
+// header lists the columns written for every report, in order. Durations are written
+// in decimal hours, which spreadsheets can sum directly.
+var header = []string{
+	"Report ID",
+	"First Name",
+	"Last Name",
+	"Email",
+	"Location",
+	"Work Date",
+	"Start Time",
+	"End Time",
+	"Working Hours",
+	"Maintenance Hours",
+	"Total Hours",
+	"Regular Hours",
+	"Overtime Hours",
+	"Weekend/Holiday Hours",
+	"Status",
+	"Created At",
+}
+
+// columns returns the values of a report in header order
+func columns(report domain.Report) []any {
+	return []any{
+		report.Id,
+		report.User.FirstName,
+		report.User.LastName,
+		report.User.Email,
+		report.Location.Name,
+		report.WorkDate.String(),
+		optional(report.StartTime),
+		optional(report.EndTime),
+		domain.MinutesToHours(report.WorkingMinutes),
+		domain.MinutesToHours(report.MaintenanceMinutes),
+		domain.MinutesToHours(report.TotalMinutes()),
+		overtimeHours(report.Overtime, func(o domain.Overtime) uint64 { return o.RegularMinutes }),
+		overtimeHours(report.Overtime, func(o domain.Overtime) uint64 { return o.OvertimeMinutes }),
+		overtimeHours(report.Overtime, func(o domain.Overtime) uint64 { return o.WeekendHolidayMinutes }),
+		report.Status.String(),
+		time.Unix(int64(report.CreatedAt), 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func optional(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+// overtimeHours returns a part of the overtime split in decimal hours, empty when the
+// report was not split
+func overtimeHours(overtime *domain.Overtime, part func(domain.Overtime) uint64) any {
+	if overtime == nil {
+		return ""
+	}
+
+	return domain.MinutesToHours(part(*overtime))
+}
+
+func fullName(user domain.User) string {
+	return fmt.Sprintf("%s %s", user.FirstName, user.LastName)
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time-management/internal/report/domain"
+)
+
+type CsvExporter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func NewCsvExporter(w io.Writer) *CsvExporter {
+	return &CsvExporter{writer: csv.NewWriter(w)}
+}
+
+func (e *CsvExporter) ContentType() string {
+	return "text/csv"
+}
+
+func (e *CsvExporter) FileExtension() string {
+	return "csv"
+}
+
+func (e *CsvExporter) Write(report domain.Report) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	values := columns(report)
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = fmt.Sprint(value)
+	}
+
+	return e.writer.Write(record)
+}
+
+func (e *CsvExporter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *CsvExporter) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+
+	return e.writer.Write(header)
+}
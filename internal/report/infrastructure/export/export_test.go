@@ -0,0 +1,108 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+	"time"
+	"time-management/internal/report/domain"
+	"time-management/internal/report/infrastructure/export"
+)
+
+func TestCsvExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := export.NewCsvExporter(&buf)
+
+	// Execute test
+	assert.NoError(t, exporter.Write(rep1))
+	assert.NoError(t, exporter.Write(rep2))
+	assert.NoError(t, exporter.Close())
+
+	// Assertions
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "Report ID", records[0][0])
+	assert.Equal(t, []string{
+		"rep1", "John", "Doe", "john@example.com", "Main Office", "2024-10-07", "08:00", "16:00",
+		"6.75", "1", "7.75", "", "", "", "approved", "1970-01-02T10:17:36Z",
+	}, records[1])
+	assert.Equal(t, "Smith", records[2][2])
+	assert.Equal(t, []string{"8", "1", "0"}, records[2][11:14])
+}
+
+func TestCsvExporter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := export.NewCsvExporter(&buf)
+
+	// Execute test
+	err := exporter.Close()
+
+	// Assertions
+	assert.NoError(t, err)
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestXlsxExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := export.NewXlsxExporter(&buf)
+
+	// Execute test
+	assert.NoError(t, exporter.Write(rep1))
+	assert.NoError(t, exporter.Write(rep2))
+	assert.Zero(t, buf.Len())
+	assert.NoError(t, exporter.Close())
+
+	// Assertions
+	file, err := excelize.OpenReader(&buf)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	assert.Equal(t, []string{"Totals", "John Doe", "Jane Smith"}, file.GetSheetList())
+
+	rows, err := file.GetRows("John Doe")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "rep1", rows[1][0])
+
+	totals, err := file.GetRows("Totals")
+	assert.NoError(t, err)
+	assert.Len(t, totals, 4)
+	assert.Equal(t, []string{"John Doe", "john@example.com", "1", "6.75", "1", "7.75"}, totals[1])
+	assert.Equal(t, []string{"Jane Smith", "jane@example.com", "1", "6", "3", "9", "8", "1", "0"}, totals[2])
+	assert.Equal(t, []string{"Total", "", "2", "12.75", "4", "16.75", "8", "1", "0"}, totals[3])
+}
+
+var (
+	start = "08:00"
+	end   = "16:00"
+)
+
+var rep1 = domain.Report{
+	Id:                 "rep1",
+	User:               domain.User{Id: "user1", FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+	Location:           domain.Location{Id: "loc1", Name: "Main Office"},
+	WorkingMinutes:     405,
+	MaintenanceMinutes: 60,
+	WorkDate:           domain.NewDate(time.Date(2024, 10, 7, 0, 0, 0, 0, time.UTC)),
+	StartTime:          &start,
+	EndTime:            &end,
+	Status:             domain.Approved,
+	CreatedAt:          123456,
+}
+
+var rep2 = domain.Report{
+	Id:                 "rep2",
+	User:               domain.User{Id: "user2", FirstName: "Jane", LastName: "Smith", Email: "jane@example.com"},
+	Location:           domain.Location{Id: "loc1", Name: "Main Office"},
+	WorkingMinutes:     360,
+	MaintenanceMinutes: 180,
+	WorkDate:           domain.NewDate(time.Date(2024, 10, 8, 0, 0, 0, 0, time.UTC)),
+	Status:             domain.Approved,
+	CreatedAt:          123456,
+	Overtime:           &domain.Overtime{RegularMinutes: 480, OvertimeMinutes: 60},
+}
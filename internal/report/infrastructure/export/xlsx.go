@@ -0,0 +1,251 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"strings"
+	"time-management/internal/report/domain"
+)
+
+const totalsSheet = "Totals"
+
+// maxSheetNameLength is the longest sheet name Excel accepts
+const maxSheetNameLength = 31
+
+// XlsxExporter writes one sheet per employee followed by a totals sheet.
+// Reports must arrive grouped by employee, as returned by ReportRepository.Stream.
+// Nothing reaches the writer before Close has built the whole workbook.
+type XlsxExporter struct {
+	out        io.Writer
+	file       *excelize.File
+	stream     *excelize.StreamWriter
+	row        int
+	userId     string
+	totals     []employeeTotals
+	sheetNames map[string]bool
+}
+
+type employeeTotals struct {
+	Name               string
+	Email              string
+	Reports            int
+	WorkingMinutes     uint64
+	MaintenanceMinutes uint64
+	// Overtime sums the splits of the reports, nil when none was split
+	Overtime *domain.Overtime
+}
+
+func (t *employeeTotals) add(overtime *domain.Overtime) {
+	if overtime == nil {
+		return
+	}
+
+	var sum domain.Overtime
+	if t.Overtime != nil {
+		sum = *t.Overtime
+	}
+	sum = sum.Add(*overtime)
+	t.Overtime = &sum
+}
+
+// hours returns the working, maintenance and total time of t in decimal hours, followed
+// by the regular, overtime and weekend or holiday time
+func (t employeeTotals) hours() []any {
+	return []any{
+		domain.MinutesToHours(t.WorkingMinutes),
+		domain.MinutesToHours(t.MaintenanceMinutes),
+		domain.MinutesToHours(t.WorkingMinutes + t.MaintenanceMinutes),
+		overtimeHours(t.Overtime, func(o domain.Overtime) uint64 { return o.RegularMinutes }),
+		overtimeHours(t.Overtime, func(o domain.Overtime) uint64 { return o.OvertimeMinutes }),
+		overtimeHours(t.Overtime, func(o domain.Overtime) uint64 { return o.WeekendHolidayMinutes }),
+	}
+}
+
+func NewXlsxExporter(w io.Writer) *XlsxExporter {
+	file := excelize.NewFile()
+	_ = file.SetSheetName(file.GetSheetName(0), totalsSheet)
+
+	return &XlsxExporter{
+		out:        w,
+		file:       file,
+		sheetNames: map[string]bool{strings.ToLower(totalsSheet): true},
+	}
+}
+
+func (e *XlsxExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (e *XlsxExporter) FileExtension() string {
+	return "xlsx"
+}
+
+func (e *XlsxExporter) Write(report domain.Report) error {
+	if e.stream == nil || report.User.Id != e.userId {
+		if err := e.startEmployeeSheet(report.User); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeRow(columns(report)); err != nil {
+		return err
+	}
+
+	current := &e.totals[len(e.totals)-1]
+	current.Reports++
+	current.WorkingMinutes += report.WorkingMinutes
+	current.MaintenanceMinutes += report.MaintenanceMinutes
+	current.add(report.Overtime)
+
+	return nil
+}
+
+func (e *XlsxExporter) Close() error {
+	defer e.file.Close()
+
+	if err := e.flush(); err != nil {
+		return err
+	}
+	if err := e.writeTotalsSheet(); err != nil {
+		return err
+	}
+
+	// Built apart first, so that a failing workbook is reported before any of it is sent
+	var buf bytes.Buffer
+	if err := e.file.Write(&buf); err != nil {
+		return err
+	}
+
+	_, err := buf.WriteTo(e.out)
+	return err
+}
+
+func (e *XlsxExporter) startEmployeeSheet(user domain.User) error {
+	if err := e.flush(); err != nil {
+		return err
+	}
+
+	sheet := e.uniqueSheetName(fullName(user))
+	if _, err := e.file.NewSheet(sheet); err != nil {
+		return err
+	}
+	if err := e.openStream(sheet); err != nil {
+		return err
+	}
+
+	e.userId = user.Id
+	e.totals = append(e.totals, employeeTotals{Name: fullName(user), Email: user.Email})
+
+	return e.writeRow(toRow(header))
+}
+
+func (e *XlsxExporter) writeTotalsSheet() error {
+	if err := e.openStream(totalsSheet); err != nil {
+		return err
+	}
+
+	err := e.writeRow([]any{
+		"Employee", "Email", "Reports", "Working Hours", "Maintenance Hours", "Total Hours",
+		"Regular Hours", "Overtime Hours", "Weekend/Holiday Hours",
+	})
+	if err != nil {
+		return err
+	}
+
+	var grand employeeTotals
+	for _, t := range e.totals {
+		err := e.writeRow(append([]any{t.Name, t.Email, t.Reports}, t.hours()...))
+		if err != nil {
+			return err
+		}
+
+		grand.Reports += t.Reports
+		grand.WorkingMinutes += t.WorkingMinutes
+		grand.MaintenanceMinutes += t.MaintenanceMinutes
+		grand.add(t.Overtime)
+	}
+
+	err = e.writeRow(append([]any{"Total", "", grand.Reports}, grand.hours()...))
+	if err != nil {
+		return err
+	}
+
+	return e.flush()
+}
+
+func (e *XlsxExporter) openStream(sheet string) error {
+	stream, err := e.file.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	e.stream = stream
+	e.row = 0
+
+	return nil
+}
+
+func (e *XlsxExporter) writeRow(values []any) error {
+	e.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *XlsxExporter) flush() error {
+	if e.stream == nil {
+		return nil
+	}
+
+	stream := e.stream
+	e.stream = nil
+
+	return stream.Flush()
+}
+
+// uniqueSheetName strips characters Excel does not allow in sheet names and
+// appends a counter when two employees share a name
+func (e *XlsxExporter) uniqueSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "Employee"
+	}
+
+	candidate := truncate(name, maxSheetNameLength)
+	for i := 2; e.sheetNames[strings.ToLower(candidate)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncate(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	e.sheetNames[strings.ToLower(candidate)] = true
+
+	return candidate
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+
+	return string(runes[:length])
+}
+
+func toRow(values []string) []any {
+	row := make([]any, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+
+	return row
+}
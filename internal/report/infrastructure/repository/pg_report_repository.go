@@ -5,47 +5,69 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	locationPg "time-management/internal/location/infrastructure/repository"
 	"time-management/internal/report/domain"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
+	timeEntryPg "time-management/internal/timeentry/infrastructure/repository"
 	userPg "time-management/internal/user/infrastructure/repository"
 )
 
-const TableName = "reports"
+const (
+	TableName        = "reports"
+	HistoryTableName = "report_status_history"
+)
+
+// summaryGroups maps each summary grouping to its key and label expressions
+var summaryGroups = map[domain.SummaryGroup]struct{ Key, Label string }{
+	domain.GroupByEmployee: {Key: "u.id", Label: "u.first_name || ' ' || u.last_name"},
+	domain.GroupByLocation: {Key: "l.id", Label: "l.name"},
+	domain.GroupByDay:      {Key: "to_char(r.work_date, 'YYYY-MM-DD')", Label: "to_char(r.work_date, 'YYYY-MM-DD')"},
+	domain.GroupByWeek:     {Key: `to_char(r.work_date, 'IYYY-"W"IW')`, Label: `to_char(r.work_date, 'IYYY-"W"IW')`},
+	domain.GroupByMonth:    {Key: "to_char(r.work_date, 'YYYY-MM')", Label: "to_char(r.work_date, 'YYYY-MM')"},
+}
+
+// sortColumns maps the sortable report fields to their columns
+var sortColumns = map[string]string{
+	"work_date":           "r.work_date",
+	"created_at":          "r.created_at",
+	"working_minutes":     "r.working_minutes",
+	"maintenance_minutes": "r.maintenance_minutes",
+	// Kept for clients that sorted by hours before durations were stored in minutes
+	"working_hours":     "r.working_minutes",
+	"maintenance_hours": "r.maintenance_minutes",
+}
 
 type PgReportRepository struct {
 	DB *sql.DB
 }
 
 func NewPgReportRepository(db *sql.DB) *PgReportRepository {
-	repository := &PgReportRepository{DB: db}
-	err := repository.createLocationTable()
-	if err != nil {
-		panic(err)
-		return nil
-	}
+	return &PgReportRepository{DB: db}
+}
 
-	return repository
+func (r *PgReportRepository) Create(ctx context.Context, report *domain.Report) (*domain.Report, error) {
+	return r.create(ctx, report, nil)
 }
 
-func (r *PgReportRepository) createLocationTable() error {
-	query := fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s (
-			id VARCHAR(50) PRIMARY KEY,
-			user_id VARCHAR(50) REFERENCES %s(id) ON DELETE CASCADE,
-			location_id VARCHAR(50) REFERENCES %s(id) ON DELETE CASCADE,
-			working_hours SERIAL,
-			maintenance_hours SERIAL,
-			status SERIAL,
-			created_at SERIAL
-		)`, TableName, userPg.TableName, locationPg.TableName)
-
-	_, err := r.DB.Exec(query)
-	return err
+func (r *PgReportRepository) CreateFromEntries(
+	ctx context.Context,
+	report *domain.Report,
+	entryIds []string,
+) (*domain.Report, error) {
+	return r.create(ctx, report, entryIds)
 }
 
-func (r *PgReportRepository) Create(ctx context.Context, report *domain.Report) (*domain.Report, error) {
+// create stores the report and links the time entries it was converted from, if any, in
+// the same transaction
+func (r *PgReportRepository) create(
+	ctx context.Context,
+	report *domain.Report,
+	entryIds []string,
+) (*domain.Report, error) {
 	var wg sync.WaitGroup
 	wg.Add(2)
 
@@ -105,9 +127,17 @@ func (r *PgReportRepository) Create(ctx context.Context, report *domain.Report)
 		return nil, err
 	}
 
+	if err := checkDailyMinutes(ctx, tx, report); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	query := fmt.Sprintf(`
-		INSERT INTO %s (id, user_id, location_id, working_hours, maintenance_hours, status, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO %s (
+			id, user_id, location_id, working_minutes, maintenance_minutes,
+			work_date, start_time, end_time, status, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id
 	`, TableName)
 
@@ -116,8 +146,11 @@ func (r *PgReportRepository) Create(ctx context.Context, report *domain.Report)
 		query, report.Id,
 		report.User.Id,
 		report.Location.Id,
-		report.WorkingHours,
-		report.MaintenanceHours,
+		report.WorkingMinutes,
+		report.MaintenanceMinutes,
+		report.WorkDate,
+		report.StartTime,
+		report.EndTime,
 		report.Status,
 		report.CreatedAt,
 	)
@@ -128,6 +161,13 @@ func (r *PgReportRepository) Create(ctx context.Context, report *domain.Report)
 		return nil, err
 	}
 
+	if len(entryIds) > 0 {
+		if err := r.claimEntries(ctx, tx, entryIds, savedId); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return nil, err
@@ -136,58 +176,89 @@ func (r *PgReportRepository) Create(ctx context.Context, report *domain.Report)
 	return r.getFullReport(ctx, savedId, nil)
 }
 
-func (r *PgReportRepository) GetAll(ctx context.Context, status domain.ReportStatus) ([]domain.Report, error) {
+func (r *PgReportRepository) GetAll(
+	ctx context.Context,
+	statuses []domain.ReportStatus,
+	filter domain.ReportFilter,
+	page pagination.Request,
+) ([]domain.Report, int, error) {
+	where, args := buildReportFilter(statuses, filter)
+
+	orderBy, err := page.OrderBy(sortColumns, "r.work_date DESC, r.created_at DESC", "r.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s r %s`, TableName, where)
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
 	query := fmt.Sprintf(`
 		SELECT 
-			r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+			r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM %s r
 		JOIN %s u ON r.user_id = u.id
 		JOIN %s l ON r.location_id = l.id
-		WHERE r.status = $1;
-	`, TableName, userPg.TableName, locationPg.TableName)
+		%s %s LIMIT $%d OFFSET $%d
+	`, TableName, userPg.TableName, locationPg.TableName, where, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.DB.QueryContext(ctx, query, status)
+	rows, err := r.DB.QueryContext(ctx, query, append(args, page.Limit, page.Offset)...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
-	return r.ScanReportRows(rows)
+	reports, err := r.ScanReportRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reports, total, nil
 }
 
-func (r *PgReportRepository) GetAllWithUserId(
+func (r *PgReportRepository) Stream(
 	ctx context.Context,
-	userId string,
-	status domain.ReportStatus,
-) ([]domain.Report, error) {
+	statuses []domain.ReportStatus,
+	filter domain.ReportFilter,
+	fn func(report domain.Report) error,
+) error {
+	where, args := buildReportFilter(statuses, filter)
+
 	query := fmt.Sprintf(`
 		SELECT 
-			r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+			r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM %s r
 		JOIN %s u ON r.user_id = u.id
 		JOIN %s l ON r.location_id = l.id
-		WHERE r.status = $1 AND r.user_id = $2;
-	`, TableName, userPg.TableName, locationPg.TableName)
+		%s
+		ORDER BY u.last_name, u.first_name, u.id, r.work_date, r.created_at
+	`, TableName, userPg.TableName, locationPg.TableName, where)
 
-	rows, err := r.DB.QueryContext(ctx, query, status, userId)
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	return r.ScanReportRows(rows)
+	return r.EachReportRow(rows, fn)
 }
 
 func (r *PgReportRepository) GetById(
 	ctx context.Context,
 	id string,
-	status domain.ReportStatus,
+	statuses []domain.ReportStatus,
 ) (*domain.Report, error) {
-	report, err := r.getFullReport(ctx, id, &status)
+	report, err := r.getFullReport(ctx, id, statuses)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, util.NewNotFoundError(domain.ErrReportNotFound)
@@ -202,32 +273,102 @@ func (r *PgReportRepository) GetById(
 func (r *PgReportRepository) GetByIdWithUserId(
 	ctx context.Context,
 	id, userId string,
-	status domain.ReportStatus,
+	statuses []domain.ReportStatus,
 ) (*domain.Report, error) {
 	query := fmt.Sprintf(`
 		SELECT 
-			r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+			r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM %s r
 		JOIN %s u ON r.user_id = u.id
 		JOIN %s l ON r.location_id = l.id
-		WHERE r.id = $1 AND r.status = $2 AND r.user_id = $3;
-
+		WHERE r.id = $1 AND r.user_id = $2
 	`, TableName, userPg.TableName, locationPg.TableName)
 
-	row := r.DB.QueryRowContext(ctx, query, id, status, userId)
+	args := []any{id, userId}
+	if len(statuses) > 0 {
+		var statusIn string
+		statusIn, args = statusCondition(statuses, args)
+		query += " AND " + statusIn
+	}
+
+	row := r.DB.QueryRowContext(ctx, query, args...)
 
 	return r.ScanReportRow(row)
 }
 
-func (r *PgReportRepository) Update(
+func (r *PgReportRepository) GetSummary(
 	ctx context.Context,
-	id, userId, locationId string,
-	workingHours, maintenanceHours uint64,
-	status domain.ReportStatus,
-) (*domain.Report, error) {
-	locationExist, err := r.checkIfRecordExists(ctx, locationId, locationPg.TableName)
+	groupBy domain.SummaryGroup,
+	filter domain.ReportFilter,
+) ([]domain.SummaryRow, error) {
+	group, ok := summaryGroups[groupBy]
+	if !ok {
+		return nil, util.NewValidationError(domain.ErrInvalidSummaryGroup)
+	}
+
+	where, args := buildReportFilter(nil, filter)
+	buckets := [][]domain.ReportStatus{
+		{domain.Approved},
+		domain.PendingStatuses,
+		{domain.Denied},
+	}
+
+	var sums []string
+	for _, statuses := range buckets {
+		var statusIn string
+		statusIn, args = statusCondition(statuses, args)
+
+		sums = append(sums, fmt.Sprintf(
+			`COALESCE(SUM(r.working_minutes) FILTER (WHERE %[1]s), 0),
+			COALESCE(SUM(r.maintenance_minutes) FILTER (WHERE %[1]s), 0)`,
+			statusIn,
+		))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS key, %s AS label, %s
+		FROM %s r
+		JOIN %s u ON r.user_id = u.id
+		JOIN %s l ON r.location_id = l.id
+		%s
+		GROUP BY 1, 2
+		ORDER BY 1
+	`,
+		group.Key, group.Label, strings.Join(sums, ", "),
+		TableName, userPg.TableName, locationPg.TableName,
+		where,
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return ScanSummaryRows(rows)
+}
+
+func (r *PgReportRepository) IsEmployeeActive(ctx context.Context, userId string) (bool, error) {
+	query := fmt.Sprintf(`SELECT active FROM %s WHERE id = $1`, userPg.TableName)
+
+	var active sql.NullBool
+	err := r.DB.QueryRowContext(ctx, query, userId).Scan(&active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, util.NewValidationError(domain.ErrWrongEmployeeId)
+		}
+		return false, err
+	}
+
+	return active.Valid && active.Bool, nil
+}
+
+func (r *PgReportRepository) Update(ctx context.Context, report *domain.Report) (*domain.Report, error) {
+	locationExist, err := r.checkIfRecordExists(ctx, report.Location.Id, locationPg.TableName)
 	if err != nil {
 		return nil, err
 	}
@@ -235,26 +376,55 @@ func (r *PgReportRepository) Update(
 		return nil, util.NewValidationError(domain.ErrWrongLocationId)
 	}
 
+	// Reports awaiting a decision can be edited, a reopened report keeps its status
+	statusIn, args := statusCondition(domain.PendingStatuses, []any{
+		report.WorkingMinutes,
+		report.MaintenanceMinutes,
+		report.Location.Id,
+		report.WorkDate,
+		report.StartTime,
+		report.EndTime,
+		report.Id,
+		report.User.Id,
+	})
 	query := fmt.Sprintf(`
-		UPDATE %s SET working_hours=$1, maintenance_hours=$2, location_id=$3 
-		WHERE id=$4 AND user_id=$5 AND status=$6
-	`, TableName)
+		UPDATE %s r SET working_minutes=$1, maintenance_minutes=$2, location_id=$3,
+			work_date=$4, start_time=$5, end_time=$6
+		WHERE id=$7 AND user_id=$8 AND %s
+	`, TableName, statusIn)
 
-	result, err := r.DB.ExecContext(ctx, query, workingHours, maintenanceHours, locationId, id, userId, status)
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkDailyMinutes(ctx, tx, report); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
 	if rowsAffected == 0 {
-		return nil, util.NewValidationError(domain.ErrReportNotFoundOrUnauthorized)
+		tx.Rollback()
+		return nil, r.updateFailure(ctx, report.Id, report.User.Id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
 
-	updateReport, err := r.getFullReport(ctx, id, &status)
+	updateReport, err := r.getFullReport(ctx, report.Id, domain.PendingStatuses)
 	if err != nil {
 		return nil, err
 	}
@@ -262,26 +432,104 @@ func (r *PgReportRepository) Update(
 	return updateReport, nil
 }
 
-func (r *PgReportRepository) Approve(ctx context.Context, id string) error {
-	query := fmt.Sprintf(`UPDATE %s SET status = $1 WHERE id = $2`, TableName)
+func (r *PgReportRepository) Approve(ctx context.Context, change *domain.StatusChange) error {
+	change.ToStatus = domain.Approved
+	return r.changeStatus(ctx, change, "", nil)
+}
 
-	_, err := r.DB.ExecContext(ctx, query, domain.Approved, id)
+func (r *PgReportRepository) Deny(ctx context.Context, change *domain.StatusChange) error {
+	change.ToStatus = domain.Denied
+	return r.changeStatus(ctx, change, "", nil)
+}
+
+func (r *PgReportRepository) Reopen(ctx context.Context, change *domain.StatusChange) error {
+	change.ToStatus = domain.Reopened
+	return r.changeStatus(ctx, change, "", nil)
+}
+
+func (r *PgReportRepository) Resubmit(
+	ctx context.Context,
+	report *domain.Report,
+	change *domain.StatusChange,
+) (*domain.Report, error) {
+	locationExist, err := r.checkIfRecordExists(ctx, report.Location.Id, locationPg.TableName)
 	if err != nil {
+		return nil, err
+	}
+	if !locationExist {
+		return nil, util.NewValidationError(domain.ErrWrongLocationId)
+	}
+
+	change.ToStatus = domain.Pending
+	err = r.changeStatus(ctx, change, report.User.Id, func(tx *sql.Tx) error {
+		if err := checkDailyMinutes(ctx, tx, report); err != nil {
+			return err
+		}
+
+		query := fmt.Sprintf(`
+			UPDATE %s SET working_minutes=$1, maintenance_minutes=$2, location_id=$3,
+				work_date=$4, start_time=$5, end_time=$6
+			WHERE id=$7
+		`, TableName)
+
+		_, err := tx.ExecContext(
+			ctx,
+			query,
+			report.WorkingMinutes,
+			report.MaintenanceMinutes,
+			report.Location.Id,
+			report.WorkDate,
+			report.StartTime,
+			report.EndTime,
+			report.Id,
+		)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return r.getFullReport(ctx, report.Id, []domain.ReportStatus{domain.Pending})
 }
 
-func (r *PgReportRepository) Deny(ctx context.Context, id string) error {
-	query := fmt.Sprintf(`UPDATE %s SET status = $1 WHERE id = $2`, TableName)
+func (r *PgReportRepository) GetStatusHistory(
+	ctx context.Context,
+	reportId string,
+	userId string,
+) ([]domain.StatusChange, error) {
+	existsQuery := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1`, TableName)
+	existsArgs := []any{reportId}
+	if userId != "" {
+		existsQuery += ` AND user_id = $2`
+		existsArgs = append(existsArgs, userId)
+	}
+	existsQuery += `)`
+
+	var exists bool
+	if err := r.DB.QueryRowContext(ctx, existsQuery, existsArgs...).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, util.NewNotFoundError(domain.ErrReportNotFound)
+	}
 
-	_, err := r.DB.ExecContext(ctx, query, domain.Denied, id)
+	query := fmt.Sprintf(`
+		SELECT
+			h.id, h.report_id, h.from_status, h.to_status, h.reason, h.changed_at,
+			COALESCE(u.id, ''), COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(u.email, '')
+		FROM %s h
+		LEFT JOIN %s u ON h.changed_by = u.id
+		WHERE h.report_id = $1
+		ORDER BY h.changed_at, h.id
+	`, HistoryTableName, userPg.TableName)
+
+	rows, err := r.DB.QueryContext(ctx, query, reportId)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return nil
+	return ScanStatusChangeRows(rows)
 }
 
 func (r *PgReportRepository) Delete(ctx context.Context, id string) error {
@@ -295,42 +543,107 @@ func (r *PgReportRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *PgReportRepository) checkIfRecordExists(ctx context.Context, id, table string) (bool, error) {
-	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)`, table)
+// updateFailure explains why an update matched no rows: the report either does not
+// belong to the user or is no longer pending
+func (r *PgReportRepository) updateFailure(ctx context.Context, id, userId string) error {
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1 AND user_id = $2)`, TableName)
 
 	var exists bool
-	err := r.DB.QueryRowContext(ctx, query, id).Scan(&exists)
+	if err := r.DB.QueryRowContext(ctx, query, id, userId).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return util.NewConflictError(domain.ErrCannotUpdateReport)
+	}
+
+	return util.NewNotFoundError(domain.ErrReportNotFoundOrUnauthorized)
+}
+
+// checkDailyMinutes ensures the user's reports for the work date, the report in place of its
+// stored version, stay within domain.MaxDailyMinutes. The user's row is locked until tx ends,
+// so concurrent writes for the same user cannot both pass the check.
+func checkDailyMinutes(ctx context.Context, tx *sql.Tx, report *domain.Report) error {
+	// NO KEY UPDATE still lets other transactions insert rows referencing the user
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE id = $1 FOR NO KEY UPDATE`, userPg.TableName)
+	var userId string
+	if err := tx.QueryRowContext(ctx, query, report.User.Id).Scan(&userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return util.NewValidationError(domain.ErrWrongEmployeeId)
+		}
+		return err
+	}
+
+	query = fmt.Sprintf(`
+		SELECT COALESCE(SUM(working_minutes + maintenance_minutes), 0) FROM %s
+		WHERE user_id = $1 AND work_date = $2 AND status <> $3 AND id <> $4
+	`, TableName)
+
+	var reportedMinutes uint64
+	err := tx.QueryRowContext(ctx, query, report.User.Id, report.WorkDate, domain.Denied, report.Id).
+		Scan(&reportedMinutes)
 	if err != nil {
-		return false, err
+		return err
+	}
+	if reportedMinutes+report.WorkingMinutes+report.MaintenanceMinutes > domain.MaxDailyMinutes {
+		return util.NewValidationError(domain.ErrDailyHoursExceeded)
 	}
 
-	return exists, nil
+	return nil
 }
 
-func (r *PgReportRepository) checkIfReportMatchesStatus(
-	ctx context.Context,
-	id string,
-	status domain.ReportStatus,
-) (bool, error) {
-	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1 AND status = $2)`, TableName)
+// claimEntries links the time entries to the report they were converted into, failing with
+// ErrEntriesAlreadyConverted when any of them was linked to a report meanwhile
+func (r *PgReportRepository) claimEntries(ctx context.Context, tx *sql.Tx, entryIds []string, reportId string) error {
+	args := []any{reportId}
+	placeholders := make([]string, 0, len(entryIds))
+	for _, id := range entryIds {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
 
-	var matches bool
-	err := r.DB.QueryRowContext(ctx, query, id, status).Scan(&matches)
+	// Entries claimed by a concurrent conversion keep their report and fail this one
+	query := fmt.Sprintf(
+		`UPDATE %s SET report_id = $1 WHERE id IN (%s) AND report_id IS NULL`,
+		timeEntryPg.TableName, strings.Join(placeholders, ", "),
+	)
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if claimed != int64(len(entryIds)) {
+		return util.NewConflictError(domain.ErrEntriesAlreadyConverted)
+	}
+
+	return nil
+}
+
+func (r *PgReportRepository) checkIfRecordExists(ctx context.Context, id, table string) (bool, error) {
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)`, table)
+
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
 
-	return matches, nil
+	return exists, nil
 }
 
 func (r *PgReportRepository) getFullReport(
 	ctx context.Context,
 	id string,
-	status *domain.ReportStatus,
+	statuses []domain.ReportStatus,
 ) (*domain.Report, error) {
 	baseQuery := fmt.Sprintf(`
 		SELECT 
-			r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+			r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM %s r
@@ -339,16 +652,139 @@ func (r *PgReportRepository) getFullReport(
 		WHERE r.id = $1
 	`, TableName, userPg.TableName, locationPg.TableName)
 
-	if status != nil {
-		baseQuery += " AND r.status = $2"
+	args := []any{id}
+	if len(statuses) > 0 {
+		var statusIn string
+		statusIn, args = statusCondition(statuses, args)
+		baseQuery += " AND " + statusIn
 	}
 
-	var row *sql.Row
-	if status != nil {
-		row = r.DB.QueryRowContext(ctx, baseQuery, id, *status)
-	} else {
-		row = r.DB.QueryRowContext(ctx, baseQuery, id)
-	}
+	row := r.DB.QueryRowContext(ctx, baseQuery, args...)
 
 	return r.ScanReportRow(row)
 }
+
+// buildReportFilter returns the WHERE clause and its arguments for listing reports.
+// No statuses match reports in every status.
+func buildReportFilter(statuses []domain.ReportStatus, filter domain.ReportFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.Status != nil {
+		if len(statuses) > 0 && !slices.Contains(statuses, *filter.Status) {
+			// A status the list is not limited to, e.g. approved reports on the pending list
+			conditions = append(conditions, "FALSE")
+		}
+		statuses = []domain.ReportStatus{*filter.Status}
+	}
+	if len(statuses) > 0 {
+		var statusIn string
+		statusIn, args = statusCondition(statuses, args)
+		conditions = append(conditions, statusIn)
+	}
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("r.user_id = $%d", len(args)))
+	}
+	if filter.LocationId != "" {
+		args = append(args, filter.LocationId)
+		conditions = append(conditions, fmt.Sprintf("r.location_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("r.work_date >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("r.work_date <= $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// statusCondition appends the statuses to args and returns the condition matching any of them
+func statusCondition(statuses []domain.ReportStatus, args []any) (string, []any) {
+	placeholders := make([]string, len(statuses))
+	for i, status := range statuses {
+		args = append(args, status)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	return fmt.Sprintf("r.status IN (%s)", strings.Join(placeholders, ", ")), args
+}
+
+// changeStatus locks the report, checks the transition is allowed, updates the status and
+// records the change in one transaction. A non-empty ownerId restricts the change to reports
+// of that user and edit, when set, applies further changes inside the same transaction.
+func (r *PgReportRepository) changeStatus(
+	ctx context.Context,
+	change *domain.StatusChange,
+	ownerId string,
+	edit func(tx *sql.Tx) error,
+) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`SELECT status FROM %s WHERE id = $1`, TableName)
+	args := []any{change.ReportId}
+	if ownerId != "" {
+		query += ` AND user_id = $2`
+		args = append(args, ownerId)
+	}
+	query += ` FOR UPDATE`
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&change.FromStatus); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return util.NewNotFoundError(domain.ErrReportNotFound)
+		}
+		return err
+	}
+
+	if err := domain.CheckTransition(change.FromStatus, change.ToStatus); err != nil {
+		tx.Rollback()
+		return util.NewConflictError(err)
+	}
+
+	if edit != nil {
+		if err := edit(tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET status = $1 WHERE id = $2`, TableName)
+	if _, err := tx.ExecContext(ctx, query, change.ToStatus, change.ReportId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(`
+		INSERT INTO %s (id, report_id, from_status, to_status, reason, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, HistoryTableName)
+
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		change.Id,
+		change.ReportId,
+		change.FromStatus,
+		change.ToStatus,
+		change.Reason,
+		change.ChangedBy.Id,
+		change.ChangedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
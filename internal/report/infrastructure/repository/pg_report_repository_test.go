@@ -2,15 +2,20 @@ package repository_test
 
 import (
 	"context"
-	_ "errors"
+	"database/sql/driver"
 	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 	locationPg "time-management/internal/location/infrastructure/repository"
+	"time-management/internal/report/application/query"
 	"time-management/internal/report/domain"
 	"time-management/internal/report/infrastructure/repository"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
 	userPg "time-management/internal/user/infrastructure/repository"
 )
 
@@ -21,13 +26,17 @@ func TestPgReportRepository_Create(t *testing.T) {
 	mockCheckRecordExists(mock, locationPg.TableName, rep1.Location.Id, true)
 
 	mock.ExpectBegin()
+	mockDailyMinutes(mock, rep1, 0)
 	mock.ExpectQuery("INSERT INTO reports").
 		WithArgs(
 			rep1.Id,
 			rep1.User.Id,
 			rep1.Location.Id,
-			rep1.WorkingHours,
-			rep1.MaintenanceHours,
+			rep1.WorkingMinutes,
+			rep1.MaintenanceMinutes,
+			rep1.WorkDate,
+			rep1.StartTime,
+			rep1.EndTime,
 			rep1.Status,
 			rep1.CreatedAt,
 		).
@@ -46,40 +55,240 @@ func TestPgReportRepository_Create(t *testing.T) {
 	assertMockExpectations(t, mock)
 }
 
+func TestPgReportRepository_CreateFromEntries(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mockCheckRecordExists(mock, userPg.TableName, rep1.User.Id, true)
+	mockCheckRecordExists(mock, locationPg.TableName, rep1.Location.Id, true)
+
+	mock.ExpectBegin()
+	mockDailyMinutes(mock, rep1, 0)
+	mock.ExpectQuery("INSERT INTO reports").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(rep1.Id))
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE time_entries SET report_id = $1 WHERE id IN ($2, $3) AND report_id IS NULL`,
+	)).
+		WithArgs(rep1.Id, "te1", "te2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	mockFullReportQuery(mock, rep1.Id, nil, rep1)
+
+	// Execute test
+	ctx := context.Background()
+	createdReport, err := repo.CreateFromEntries(ctx, &rep1, []string{"te1", "te2"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, rep1.Id, createdReport.Id)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_CreateFromEntries_AlreadyConverted(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mockCheckRecordExists(mock, userPg.TableName, rep1.User.Id, true)
+	mockCheckRecordExists(mock, locationPg.TableName, rep1.Location.Id, true)
+
+	// One of the entries was claimed by a concurrent conversion, the report is rolled back
+	mock.ExpectBegin()
+	mockDailyMinutes(mock, rep1, 0)
+	mock.ExpectQuery("INSERT INTO reports").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(rep1.Id))
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE time_entries SET report_id = $1 WHERE id IN ($2, $3) AND report_id IS NULL`,
+	)).
+		WithArgs(rep1.Id, "te1", "te2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	// Execute test
+	ctx := context.Background()
+	createdReport, err := repo.CreateFromEntries(ctx, &rep1, []string{"te1", "te2"})
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrEntriesAlreadyConverted)
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Nil(t, createdReport)
+	assertMockExpectations(t, mock)
+}
+
 func TestPgReportRepository_GetAll(t *testing.T) {
 	mock, repo := setupMockAndRepo(t)
 
 	// Mock query response
-	mockReportsQuery(mock, nil, domain.Pending, []domain.Report{rep1})
+	mockReportsQuery(mock, nil, domain.PendingStatuses, []domain.Report{rep1})
 
 	// Execute test
 	ctx := context.Background()
-	reports, err := repo.GetAll(ctx, domain.Pending)
+	reports, total, err := repo.GetAll(ctx, domain.PendingStatuses, domain.ReportFilter{}, pagination.Request{Limit: 50})
 
 	// Assertions
 	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
 	assert.Len(t, reports, 1)
-	assertReportEqual(t, reports[0], rep1.Id, rep1.User.Id, rep1.Location.Id, rep1.WorkingHours, rep1.MaintenanceHours)
+	assertReportEqual(t, reports[0], rep1.Id, rep1.User.Id, rep1.Location.Id, rep1.WorkingMinutes, rep1.MaintenanceMinutes)
 	assertMockExpectations(t, mock)
 }
 
-func TestPgReportRepository_GetAllWithUserId(t *testing.T) {
+func TestPgReportRepository_GetAll_WithUserId(t *testing.T) {
 	mock, repo := setupMockAndRepo(t)
 
 	userId := "usr123"
 
 	// Mock query response
-	mockReportsQuery(mock, &userId, domain.Pending, []domain.Report{rep1, rep2})
+	mockReportsQuery(mock, &userId, domain.PendingStatuses, []domain.Report{rep1, rep2})
 
 	// Execute test
 	ctx := context.Background()
-	reports, err := repo.GetAllWithUserId(ctx, userId, domain.Pending)
+	filter := domain.ReportFilter{UserId: userId}
+	reports, total, err := repo.GetAll(ctx, domain.PendingStatuses, filter, pagination.Request{Limit: 50})
 
 	// Assertions
 	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
 	assert.Len(t, reports, 2)
-	assertReportEqual(t, reports[0], rep1.Id, rep1.User.Id, rep1.Location.Id, rep1.WorkingHours, rep1.MaintenanceHours)
-	assertReportEqual(t, reports[1], rep2.Id, rep2.User.Id, rep2.Location.Id, rep2.WorkingHours, rep2.MaintenanceHours)
+	assertReportEqual(t, reports[0], rep1.Id, rep1.User.Id, rep1.Location.Id, rep1.WorkingMinutes, rep1.MaintenanceMinutes)
+	assertReportEqual(t, reports[1], rep2.Id, rep2.User.Id, rep2.Location.Id, rep2.WorkingMinutes, rep2.MaintenanceMinutes)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_GetAll_FilterAndSort(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	from := domain.NewDate(time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+	to := domain.NewDate(time.Date(2024, 10, 31, 0, 0, 0, 0, time.UTC))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT COUNT(*) FROM reports r
+		WHERE r.status IN ($1) AND r.location_id = $2 AND r.work_date >= $3 AND r.work_date <= $4`)).
+		WithArgs(domain.Approved, "loc123", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		WHERE r.status IN ($1) AND r.location_id = $2 AND r.work_date >= $3 AND r.work_date <= $4
+		ORDER BY r.working_minutes DESC, r.id LIMIT $5 OFFSET $6`)).
+		WithArgs(domain.Approved, "loc123", from, to, 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Execute test
+	ctx := context.Background()
+	filter := domain.ReportFilter{LocationId: "loc123", From: &from, To: &to}
+	page := pagination.Request{Limit: 10, Offset: 20, Sort: "working_minutes", Desc: true}
+	reports, total, err := repo.GetAll(ctx, []domain.ReportStatus{domain.Approved}, filter, page)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, reports)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_GetAll_StatusFilter(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Only the requested one of the pending statuses is listed
+	reopened := rep1
+	reopened.Status = domain.Reopened
+	mockReportsQuery(mock, nil, []domain.ReportStatus{domain.Reopened}, []domain.Report{reopened})
+
+	// Execute test
+	ctx := context.Background()
+	status := domain.Reopened
+	filter := domain.ReportFilter{Status: &status}
+	reports, total, err := repo.GetAll(ctx, domain.PendingStatuses, filter, pagination.Request{Limit: 50})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, domain.Reopened, reports[0].Status)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_GetAll_StatusFilterOutsideStatuses(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Approved reports are not listed with the pending ones, whatever the filter asks for
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM reports r WHERE FALSE AND r.status IN ($1)`)).
+		WithArgs(domain.Approved).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE FALSE AND r.status IN ($1)`)).
+		WithArgs(domain.Approved, 50, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Execute test
+	ctx := context.Background()
+	status := domain.Approved
+	filter := domain.ReportFilter{Status: &status}
+	reports, total, err := repo.GetAll(ctx, domain.PendingStatuses, filter, pagination.Request{Limit: 50})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, reports)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_GetAll_InvalidSort(t *testing.T) {
+	_, repo := setupMockAndRepo(t)
+
+	// Execute test
+	ctx := context.Background()
+	page := pagination.Request{Limit: 10, Sort: "password"}
+	_, _, err := repo.GetAll(ctx, []domain.ReportStatus{domain.Approved}, domain.ReportFilter{}, page)
+
+	// Assertions
+	assert.ErrorIs(t, err, pagination.ErrInvalidSort)
+}
+
+func TestPgReportRepository_Stream(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	query := `
+		SELECT 
+		    r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
+			u.id, u.first_name, u.last_name, u.email,
+			l.id, l.name
+		FROM reports r
+		JOIN users u ON r.user_id = u.id
+		JOIN locations l ON r.location_id = l.id
+		WHERE r.status IN ($1) AND r.location_id = $2
+		ORDER BY u.last_name, u.first_name, u.id, r.work_date, r.created_at`
+
+	rows := sqlmock.NewRows([]string{
+		"id", "working_minutes", "maintenance_minutes",
+		"work_date", "start_time", "end_time",
+		"status", "created_at",
+		"id", "first_name", "last_name", "email",
+		"id", "name",
+	})
+	for _, r := range []domain.Report{rep1, rep2} {
+		rows.AddRow(
+			r.Id, r.WorkingMinutes, r.MaintenanceMinutes,
+			r.WorkDate, r.StartTime, r.EndTime,
+			r.Status, r.CreatedAt,
+			r.User.Id, r.User.FirstName, r.User.LastName, r.User.Email,
+			r.Location.Id, r.Location.Name,
+		)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.Approved, rep1.Location.Id).
+		WillReturnRows(rows)
+
+	// Execute test
+	ctx := context.Background()
+	var streamed []string
+	filter := domain.ReportFilter{LocationId: rep1.Location.Id}
+	err := repo.Stream(ctx, []domain.ReportStatus{domain.Approved}, filter, func(report domain.Report) error {
+		streamed = append(streamed, report.Id)
+		return nil
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, []string{rep1.Id, rep2.Id}, streamed)
 	assertMockExpectations(t, mock)
 }
 
@@ -87,11 +296,11 @@ func TestPgReportRepository_GetById(t *testing.T) {
 	mock, repo := setupMockAndRepo(t)
 
 	// Mock query response
-	mockReportQuery(mock, rep1.Id, nil, domain.Pending, rep1)
+	mockReportQuery(mock, rep1.Id, nil, []domain.ReportStatus{domain.Pending}, rep1)
 
 	// Execute test
 	ctx := context.Background()
-	report, err := repo.GetById(ctx, rep1.Id, domain.Pending)
+	report, err := repo.GetById(ctx, rep1.Id, []domain.ReportStatus{domain.Pending})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -106,11 +315,11 @@ func TestPgReportRepository_GetByIdWithUserId(t *testing.T) {
 	userId := "user123"
 
 	// Mock query response
-	mockReportQuery(mock, rep1.Id, &userId, domain.Approved, rep1)
+	mockReportQuery(mock, rep1.Id, &userId, []domain.ReportStatus{domain.Approved}, rep1)
 
 	// Execute test
 	ctx := context.Background()
-	report, err := repo.GetByIdWithUserId(ctx, rep1.Id, rep1.User.Id, domain.Approved)
+	report, err := repo.GetByIdWithUserId(ctx, rep1.Id, rep1.User.Id, []domain.ReportStatus{domain.Approved})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -121,6 +330,97 @@ func TestPgReportRepository_GetByIdWithUserId(t *testing.T) {
 	assertMockExpectations(t, mock)
 }
 
+func TestPgReportRepository_GetSummary(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	from := domain.NewDate(time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+	to := domain.NewDate(time.Date(2024, 10, 31, 0, 0, 0, 0, time.UTC))
+
+	// Mock summary query
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT l.id AS key, l.name AS label,
+			COALESCE(SUM(r.working_minutes) FILTER (WHERE r.status IN ($3)), 0),
+			COALESCE(SUM(r.maintenance_minutes) FILTER (WHERE r.status IN ($3)), 0),
+			COALESCE(SUM(r.working_minutes) FILTER (WHERE r.status IN ($4, $5)), 0),
+			COALESCE(SUM(r.maintenance_minutes) FILTER (WHERE r.status IN ($4, $5)), 0),
+			COALESCE(SUM(r.working_minutes) FILTER (WHERE r.status IN ($6)), 0),
+			COALESCE(SUM(r.maintenance_minutes) FILTER (WHERE r.status IN ($6)), 0)
+		FROM reports r
+		JOIN users u ON r.user_id = u.id
+		JOIN locations l ON r.location_id = l.id
+		WHERE r.work_date >= $1 AND r.work_date <= $2
+		GROUP BY 1, 2
+		ORDER BY 1`)).
+		WithArgs(from, to, domain.Approved, domain.Pending, domain.Reopened, domain.Denied).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"key", "label",
+			"approved_working", "approved_maintenance",
+			"pending_working", "pending_maintenance",
+			"denied_working", "denied_maintenance",
+		}).
+			AddRow("loc123", "Main Office", 40, 5, 8, 0, 0, 2).
+			AddRow("loc456", "Remote", 16, 0, 0, 0, 0, 0))
+
+	// Execute test
+	ctx := context.Background()
+	filter := domain.ReportFilter{From: &from, To: &to}
+	rows, err := repo.GetSummary(ctx, domain.GroupByLocation, filter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "Main Office", rows[0].Label)
+	assert.Equal(t, domain.Hours{WorkingMinutes: 40, MaintenanceMinutes: 5, TotalMinutes: 45}, rows[0].Approved)
+	assert.Equal(t, uint64(8), rows[0].Pending.TotalMinutes)
+	assert.Equal(t, uint64(2), rows[0].Denied.MaintenanceMinutes)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_IsEmployeeActive(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT active FROM users WHERE id = $1`)).
+		WithArgs("user123").
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT active FROM users WHERE id = $1`)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"active"}))
+
+	// Execute test
+	ctx := context.Background()
+	active, err := repo.IsEmployeeActive(ctx, "user123")
+	_, missingErr := repo.IsEmployeeActive(ctx, "missing")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assert.ErrorIs(t, missingErr, domain.ErrWrongEmployeeId)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Create_DailyMinutesExceeded(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mockCheckRecordExists(mock, userPg.TableName, rep1.User.Id, true)
+	mockCheckRecordExists(mock, locationPg.TableName, rep1.Location.Id, true)
+
+	// The minutes are summed in the transaction, after the user is locked, and nothing is inserted
+	mock.ExpectBegin()
+	mockDailyMinutes(mock, rep1, domain.MaxDailyMinutes-rep1.WorkingMinutes)
+	mock.ExpectRollback()
+
+	// Execute test
+	ctx := context.Background()
+	createdReport, err := repo.Create(ctx, &rep1)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrDailyHoursExceeded)
+	var validationErr *util.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Nil(t, createdReport)
+	assertMockExpectations(t, mock)
+}
+
 func TestPgReportRepository_Update(t *testing.T) {
 	mock, repo := setupMockAndRepo(t)
 
@@ -129,38 +429,87 @@ func TestPgReportRepository_Update(t *testing.T) {
 	reportId := "report123"
 	userId := "user123"
 	locationId := "loc123"
-	workingHours := uint64(40)
-	maintenanceHours := uint64(5)
-	status := domain.Pending
+	workingMinutes := uint64(40)
+	maintenanceMinutes := uint64(5)
+	workDate := domain.NewDate(time.Date(2024, 10, 7, 0, 0, 0, 0, time.UTC))
+	startTime := "07:00"
+	endTime := "15:30"
+	status := domain.Reopened
 
 	// Mock check location existence
 	mockCheckRecordExists(mock, "locations", locationId, true)
 
-	// Mock update query
-	mock.ExpectExec(regexp.QuoteMeta(`
-        UPDATE reports SET working_hours=$1, maintenance_hours=$2, location_id=$3 
-        WHERE id=$4 AND user_id=$5 AND status=$6`)).
-		WithArgs(workingHours, maintenanceHours, locationId, reportId, userId, status).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-
 	report := domain.Report{
-		Id:               reportId,
-		User:             domain.User{Id: userId, FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
-		Location:         domain.Location{Id: locationId, Name: "Location A"},
-		WorkingHours:     workingHours,
-		MaintenanceHours: maintenanceHours,
-		Status:           status,
+		Id:                 reportId,
+		User:               domain.User{Id: userId, FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		Location:           domain.Location{Id: locationId, Name: "Location A"},
+		WorkingMinutes:     workingMinutes,
+		MaintenanceMinutes: maintenanceMinutes,
+		WorkDate:           workDate,
+		StartTime:          &startTime,
+		EndTime:            &endTime,
+		Status:             status,
 	}
 
+	// Mock update query, reopened reports are edited like pending ones
+	mock.ExpectBegin()
+	mockDailyMinutes(mock, report, 60)
+	mock.ExpectExec(regexp.QuoteMeta(`
+        UPDATE reports r SET working_minutes=$1, maintenance_minutes=$2, location_id=$3,
+			work_date=$4, start_time=$5, end_time=$6
+        WHERE id=$7 AND user_id=$8 AND r.status IN ($9, $10)`)).
+		WithArgs(
+			workingMinutes, maintenanceMinutes, locationId,
+			workDate, &startTime, &endTime,
+			reportId, userId, domain.Pending, domain.Reopened,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
 	// Mock full report query after update
-	mockFullReportQuery(mock, reportId, &status, report)
+	mockFullReportQuery(mock, reportId, domain.PendingStatuses, report)
 
 	// Execute test
-	updatedReport, err := repo.Update(ctx, reportId, userId, locationId, workingHours, maintenanceHours, status)
+	updatedReport, err := repo.Update(ctx, &report)
 
 	// Assertions
 	assert.NoError(t, err)
 	assert.NotNil(t, updatedReport)
+	assert.Equal(t, workDate, updatedReport.WorkDate)
+	assert.Equal(t, uint64(510), *updatedReport.ShiftMinutes)
+	assert.Equal(t, domain.Reopened, updatedReport.Status)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Update_NotPending(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	report := rep1
+	report.Id = "report123"
+
+	// Mock check location existence
+	mockCheckRecordExists(mock, "locations", report.Location.Id, true)
+
+	// Mock update query matching no pending report
+	mock.ExpectBegin()
+	mockDailyMinutes(mock, report, 0)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports r SET working_minutes=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM reports WHERE id = $1 AND user_id = $2)`)).
+		WithArgs(report.Id, report.User.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	// Execute test
+	updatedReport, err := repo.Update(ctx, &report)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrCannotUpdateReport)
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Nil(t, updatedReport)
 	assertMockExpectations(t, mock)
 }
 
@@ -169,18 +518,17 @@ func TestPgReportRepository_Approve(t *testing.T) {
 
 	// Input variables
 	ctx := context.Background()
-	reportId := "report123"
+	change := domain.NewStatusChange("change123", "report123", domain.Approved, "manager123", nil, 123456789)
 
-	// Mock approval query
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports SET status = $1 WHERE id = $2`)).
-		WithArgs(domain.Approved, reportId).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	// Mock approval queries
+	mockStatusChange(mock, change, domain.Pending)
 
 	// Execute test
-	err := repo.Approve(ctx, reportId)
+	err := repo.Approve(ctx, change)
 
 	// Assertions
 	assert.NoError(t, err)
+	assert.Equal(t, domain.Pending, change.FromStatus)
 	assertMockExpectations(t, mock)
 }
 
@@ -189,18 +537,216 @@ func TestPgReportRepository_Deny(t *testing.T) {
 
 	// Input variables
 	ctx := context.Background()
-	reportId := "report123"
+	reason := "hours do not match the schedule"
+	change := domain.NewStatusChange("change123", "report123", domain.Denied, "manager123", &reason, 123456789)
+
+	// Mock denial queries
+	mockStatusChange(mock, change, domain.Pending)
+
+	// Execute test
+	err := repo.Deny(ctx, change)
+
+	// Assertions
+	assert.NoError(t, err)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Deny_NotFound(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	reason := "wrong location"
+	change := domain.NewStatusChange("change123", "missing", domain.Denied, "manager123", &reason, 123456789)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM reports WHERE id = $1 FOR UPDATE`)).
+		WithArgs(change.ReportId).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	mock.ExpectRollback()
+
+	// Execute test
+	err := repo.Deny(ctx, change)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrReportNotFound)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Deny_InvalidTransition(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	reason := "wrong location"
+	change := domain.NewStatusChange("change123", "report123", domain.Denied, "manager123", &reason, 123456789)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM reports WHERE id = $1 FOR UPDATE`)).
+		WithArgs(change.ReportId).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(domain.Approved))
+	mock.ExpectRollback()
+
+	// Execute test
+	err := repo.Deny(ctx, change)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Reopen(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	change := domain.NewStatusChange("change123", "report123", domain.Reopened, "admin123", nil, 123456789)
+
+	// Mock reopen queries
+	mockStatusChange(mock, change, domain.Approved)
+
+	// Execute test
+	err := repo.Reopen(ctx, change)
+
+	// Assertions
+	assert.NoError(t, err)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Reopen_ListedAsPending(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	reopened := rep1
+	reopened.Status = domain.Reopened
+	change := domain.NewStatusChange("change123", reopened.Id, domain.Reopened, "admin123", nil, 123456789)
+
+	// Mock reopen queries and the pending list behind /reports/pending/users/all
+	mockStatusChange(mock, change, domain.Approved)
+	mockReportsQuery(mock, nil, domain.PendingStatuses, []domain.Report{reopened})
+
+	// Execute test
+	err := repo.Reopen(ctx, change)
+	assert.NoError(t, err)
+
+	handler := query.GetPendingReportsHandler{Repo: repo}
+	page, err := handler.Handle(ctx, query.GetPendingReportsQuery{Page: pagination.Request{Limit: 50}})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Equal(t, reopened.Id, page.Data[0].Id)
+	assert.Equal(t, domain.Reopened, page.Data[0].Status)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_Resubmit(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	report := rep1
+	report.Status = domain.Pending
+	change := domain.NewStatusChange("change123", report.Id, domain.Pending, report.User.Id, nil, 123456789)
+
+	// Mock check location existence
+	mockCheckRecordExists(mock, "locations", report.Location.Id, true)
 
-	// Mock denial query
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM reports WHERE id = $1 AND user_id = $2 FOR UPDATE`)).
+		WithArgs(report.Id, report.User.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(domain.Denied))
+	mockDailyMinutes(mock, report, 0)
+	mock.ExpectExec(regexp.QuoteMeta(`
+		UPDATE reports SET working_minutes=$1, maintenance_minutes=$2, location_id=$3,
+			work_date=$4, start_time=$5, end_time=$6
+		WHERE id=$7`)).
+		WithArgs(
+			report.WorkingMinutes, report.MaintenanceMinutes, report.Location.Id,
+			report.WorkDate, report.StartTime, report.EndTime,
+			report.Id,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports SET status = $1 WHERE id = $2`)).
-		WithArgs(domain.Denied, reportId).
+		WithArgs(domain.Pending, report.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO report_status_history`)).
+		WithArgs(change.Id, report.Id, domain.Denied, domain.Pending, nil, report.User.Id, change.ChangedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mockFullReportQuery(mock, report.Id, []domain.ReportStatus{domain.Pending}, report)
+
+	// Execute test
+	resubmittedReport, err := repo.Resubmit(ctx, &report, change)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Pending, resubmittedReport.Status)
+	assert.Equal(t, domain.Denied, change.FromStatus)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_GetStatusHistory(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	// Input variables
+	ctx := context.Background()
+	reportId := "report123"
+	userId := "user123"
+	reason := "missing shift times"
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM reports WHERE id = $1 AND user_id = $2)`)).
+		WithArgs(reportId, userId).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	query := `
+		SELECT
+			h.id, h.report_id, h.from_status, h.to_status, h.reason, h.changed_at,
+			COALESCE(u.id, ''), COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(u.email, '')
+		FROM report_status_history h
+		LEFT JOIN users u ON h.changed_by = u.id
+		WHERE h.report_id = $1
+		ORDER BY h.changed_at, h.id`
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(reportId).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "report_id", "from_status", "to_status", "reason", "changed_at",
+			"id", "first_name", "last_name", "email",
+		}).AddRow(
+			"change123", reportId, domain.Pending, domain.Denied, reason, 123456789,
+			"manager123", "Jane", "Smith", "jane@example.com",
+		))
 
 	// Execute test
-	err := repo.Deny(ctx, reportId)
+	history, err := repo.GetStatusHistory(ctx, reportId, userId)
 
 	// Assertions
 	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, domain.Denied, history[0].ToStatus)
+	assert.Equal(t, reason, *history[0].Reason)
+	assert.Equal(t, "manager123", history[0].ChangedBy.Id)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgReportRepository_GetStatusHistory_NotFound(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM reports WHERE id = $1)`)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	// Execute test
+	history, err := repo.GetStatusHistory(context.Background(), "missing", "")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrReportNotFound)
+	assert.Nil(t, history)
 	assertMockExpectations(t, mock)
 }
 
@@ -229,9 +775,6 @@ func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *repository.PgReportReposi
 	assert.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
 
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS reports").
-		WillReturnResult(sqlmock.NewResult(0, 0))
-
 	repo := repository.NewPgReportRepository(db)
 	return mock, repo
 }
@@ -252,12 +795,14 @@ func mockCheckRecordExists(mock sqlmock.Sqlmock, tableName, id string, exists bo
 func mockFullReportQuery(
 	mock sqlmock.Sqlmock,
 	reportId string,
-	status *domain.ReportStatus,
+	statuses []domain.ReportStatus,
 	report domain.Report,
 ) {
 	query :=
 		`SELECT 
-		    r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+		    r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM reports r
@@ -265,70 +810,90 @@ func mockFullReportQuery(
 		JOIN locations l ON r.location_id = l.id
 		WHERE r.id = $1`
 
-	if status != nil {
-		query += " AND r.status = $2"
+	args := []driver.Value{reportId}
+	if len(statuses) > 0 {
+		query += " AND " + statusIn(statuses, 2)
+		for _, status := range statuses {
+			args = append(args, status)
+		}
 	}
 
 	// Updated the row columns to match the actual query without column aliases
 	rows := sqlmock.NewRows([]string{
-		"id", "working_hours", "maintenance_hours", "status", "created_at",
+		"id", "working_minutes", "maintenance_minutes",
+		"work_date", "start_time", "end_time",
+		"status", "created_at",
 		"id", "first_name", "last_name", "email",
 		"id", "name",
 	}).AddRow(
-		report.Id, report.WorkingHours, report.MaintenanceHours, report.Status, report.CreatedAt,
+		report.Id, report.WorkingMinutes, report.MaintenanceMinutes,
+		report.WorkDate, report.StartTime, report.EndTime,
+		report.Status, report.CreatedAt,
 		report.User.Id, report.User.FirstName, report.User.LastName, report.User.Email,
 		report.Location.Id, report.Location.Name,
 	)
 
-	if status != nil {
-		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(reportId, &status).WillReturnRows(rows)
-	} else {
-		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(reportId).WillReturnRows(rows)
-	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnRows(rows)
 }
 
-// mockReportsQuery mocks the query that retrieves multiple reports by status
+// mockReportsQuery mocks the count and list queries that retrieve reports by status
 func mockReportsQuery(
 	mock sqlmock.Sqlmock,
 	userId *string,
-	status domain.ReportStatus,
+	statuses []domain.ReportStatus,
 	reports []domain.Report,
 ) {
+	where := `WHERE ` + statusIn(statuses, 1)
+	var args []driver.Value
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+
+	// Append userId only if it's provided
+	if userId != nil {
+		where += fmt.Sprintf(" AND r.user_id = $%d", len(args)+1)
+		args = append(args, *userId)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM reports r ` + where)).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(reports)))
+
 	query := `
 		SELECT 
-		    r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+		    r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM reports r
 		JOIN users u ON r.user_id = u.id
 		JOIN locations l ON r.location_id = l.id
-		WHERE r.status = $1`
-
-	// Append userId only if it's provided
-	if userId != nil {
-		query += " AND r.user_id = $2"
-	}
+		` + where + fmt.Sprintf(
+		` ORDER BY r.work_date DESC, r.created_at DESC, r.id LIMIT $%d OFFSET $%d`,
+		len(args)+1, len(args)+2,
+	)
 
 	rows := sqlmock.NewRows([]string{
-		"id", "working_hours", "maintenance_hours", "status", "created_at",
+		"id", "working_minutes", "maintenance_minutes",
+		"work_date", "start_time", "end_time",
+		"status", "created_at",
 		"id", "first_name", "last_name", "email",
 		"id", "name",
 	})
 
 	for _, r := range reports {
 		rows.AddRow(
-			r.Id, r.WorkingHours, r.MaintenanceHours, r.Status, r.CreatedAt,
+			r.Id, r.WorkingMinutes, r.MaintenanceMinutes,
+			r.WorkDate, r.StartTime, r.EndTime,
+			r.Status, r.CreatedAt,
 			r.User.Id, r.User.FirstName, r.User.LastName, r.User.Email,
 			r.Location.Id, r.Location.Name,
 		)
 	}
 
-	// Conditionally expect query based on whether status is provided
-	if userId != nil {
-		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(status, &userId).WillReturnRows(rows)
-	} else {
-		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(status).WillReturnRows(rows)
-	}
+	args = append(args, 50, 0)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnRows(rows)
 }
 
 // mockReportQuery mocks the query that retrieves a single report by ID and status
@@ -336,48 +901,102 @@ func mockReportQuery(
 	mock sqlmock.Sqlmock,
 	reportId string,
 	userId *string,
-	status domain.ReportStatus,
+	statuses []domain.ReportStatus,
 	report domain.Report,
 ) {
 	query := `
 		SELECT 
-		    r.id, r.working_hours, r.maintenance_hours, r.status, r.created_at,
+		    r.id, r.working_minutes, r.maintenance_minutes,
+			r.work_date, to_char(r.start_time, 'HH24:MI'), to_char(r.end_time, 'HH24:MI'),
+			r.status, r.created_at,
 			u.id, u.first_name, u.last_name, u.email,
 			l.id, l.name
 		FROM reports r
 		JOIN users u ON r.user_id = u.id
 		JOIN locations l ON r.location_id = l.id
-		WHERE r.id = $1 AND r.status = $2`
+		WHERE r.id = $1`
 
+	args := []driver.Value{reportId}
 	// Append userId only if it's provided
 	if userId != nil {
-		query += " AND r.user_id = $3"
+		query += " AND r.user_id = $2"
+		args = append(args, *userId)
+	}
+	query += " AND " + statusIn(statuses, len(args)+1)
+	for _, status := range statuses {
+		args = append(args, status)
 	}
 
 	rows := sqlmock.NewRows([]string{
-		"id", "working_hours", "maintenance_hours", "status", "created_at",
+		"id", "working_minutes", "maintenance_minutes",
+		"work_date", "start_time", "end_time",
+		"status", "created_at",
 		"id", "first_name", "last_name", "email",
 		"id", "name",
 	}).AddRow(
-		report.Id, report.WorkingHours, report.MaintenanceHours, report.Status, report.CreatedAt,
+		report.Id, report.WorkingMinutes, report.MaintenanceMinutes,
+		report.WorkDate, report.StartTime, report.EndTime,
+		report.Status, report.CreatedAt,
 		report.User.Id, report.User.FirstName, report.User.LastName, report.User.Email,
 		report.Location.Id, report.Location.Name,
 	)
 
-	if userId != nil {
-		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(reportId, status, &userId).WillReturnRows(rows)
-	} else {
-		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(reportId, status).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnRows(rows)
+}
+
+// statusIn renders the status condition for the statuses with placeholders starting at first
+func statusIn(statuses []domain.ReportStatus, first int) string {
+	placeholders := make([]string, len(statuses))
+	for i := range statuses {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
 	}
+
+	return "r.status IN (" + strings.Join(placeholders, ", ") + ")"
+}
+
+// mockStatusChange mocks the transaction that updates a report status and records the change
+// mockDailyMinutes mocks locking the report's user and summing their other minutes on its
+// work date, both inside the transaction that stores the report
+func mockDailyMinutes(mock sqlmock.Sqlmock, report domain.Report, reportedMinutes uint64) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM users WHERE id = $1 FOR NO KEY UPDATE`)).
+		WithArgs(report.User.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(report.User.Id))
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT COALESCE(SUM(working_minutes + maintenance_minutes), 0) FROM reports
+		WHERE user_id = $1 AND work_date = $2 AND status <> $3 AND id <> $4`)).
+		WithArgs(report.User.Id, report.WorkDate, domain.Denied, report.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(reportedMinutes))
+}
+
+func mockStatusChange(mock sqlmock.Sqlmock, change *domain.StatusChange, fromStatus domain.ReportStatus) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM reports WHERE id = $1 FOR UPDATE`)).
+		WithArgs(change.ReportId).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(fromStatus))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reports SET status = $1 WHERE id = $2`)).
+		WithArgs(change.ToStatus, change.ReportId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO report_status_history`)).
+		WithArgs(
+			change.Id,
+			change.ReportId,
+			fromStatus,
+			change.ToStatus,
+			change.Reason,
+			change.ChangedBy.Id,
+			change.ChangedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 }
 
 // assertReportEqual is a helper function for comparing report fields in the assertions
-func assertReportEqual(t *testing.T, report domain.Report, id, userId, locationId string, workingHours, maintenanceHours uint64) {
+func assertReportEqual(t *testing.T, report domain.Report, id, userId, locationId string, workingMinutes, maintenanceMinutes uint64) {
 	assert.Equal(t, id, report.Id)
 	assert.Equal(t, userId, report.User.Id)
 	assert.Equal(t, locationId, report.Location.Id)
-	assert.Equal(t, workingHours, report.WorkingHours)
-	assert.Equal(t, maintenanceHours, report.MaintenanceHours)
+	assert.Equal(t, workingMinutes, report.WorkingMinutes)
+	assert.Equal(t, maintenanceMinutes, report.MaintenanceMinutes)
 }
 
 // assertMockExpectations is a helper to ensure all expectations of the mock are met
@@ -388,21 +1007,23 @@ func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
 }
 
 var rep1 = domain.Report{
-	Id:               "123",
-	User:             domain.User{Id: "user123", FirstName: "John", LastName: "Doe", Email: "john@example.com"},
-	Location:         domain.Location{Id: "loc123", Name: "Main Office"},
-	WorkingHours:     7,
-	MaintenanceHours: 4,
-	Status:           domain.Pending,
-	CreatedAt:        123456789,
+	Id:                 "123",
+	User:               domain.User{Id: "user123", FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+	Location:           domain.Location{Id: "loc123", Name: "Main Office"},
+	WorkingMinutes:     7,
+	MaintenanceMinutes: 4,
+	WorkDate:           domain.NewDate(time.Date(2024, 10, 7, 0, 0, 0, 0, time.UTC)),
+	Status:             domain.Pending,
+	CreatedAt:          123456789,
 }
 
 var rep2 = domain.Report{
-	Id:               "123",
-	User:             domain.User{Id: "user123", FirstName: "John", LastName: "Doe", Email: "john@example.com"},
-	Location:         domain.Location{Id: "loc123", Name: "Remote"},
-	WorkingHours:     8,
-	MaintenanceHours: 3,
-	Status:           domain.Pending,
-	CreatedAt:        123456789,
+	Id:                 "123",
+	User:               domain.User{Id: "user123", FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+	Location:           domain.Location{Id: "loc123", Name: "Remote"},
+	WorkingMinutes:     8,
+	MaintenanceMinutes: 3,
+	WorkDate:           domain.NewDate(time.Date(2024, 10, 8, 0, 0, 0, 0, time.UTC)),
+	Status:             domain.Pending,
+	CreatedAt:          123456789,
 }
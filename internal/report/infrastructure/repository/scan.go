@@ -13,7 +13,9 @@ func (r *PgReportRepository) ScanReportRow(row *sql.Row) (*domain.Report, error)
 	var location domain.Location
 
 	err := row.Scan(
-		&report.Id, &report.WorkingHours, &report.MaintenanceHours, &report.Status, &report.CreatedAt,
+		&report.Id, &report.WorkingMinutes, &report.MaintenanceMinutes,
+		&report.WorkDate, &report.StartTime, &report.EndTime,
+		&report.Status, &report.CreatedAt,
 		&employee.Id, &employee.FirstName, &employee.LastName, &employee.Email,
 		&location.Id, &location.Name,
 	)
@@ -27,6 +29,7 @@ func (r *PgReportRepository) ScanReportRow(row *sql.Row) (*domain.Report, error)
 
 	report.User = employee
 	report.Location = location
+	report.ComputeShift()
 
 	return &report, nil
 }
@@ -34,28 +37,93 @@ func (r *PgReportRepository) ScanReportRow(row *sql.Row) (*domain.Report, error)
 func (r *PgReportRepository) ScanReportRows(rows *sql.Rows) ([]domain.Report, error) {
 	var reports []domain.Report
 
+	err := r.EachReportRow(rows, func(report domain.Report) error {
+		reports = append(reports, report)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// EachReportRow scans rows one at a time and passes every report to fn
+func (r *PgReportRepository) EachReportRow(rows *sql.Rows, fn func(report domain.Report) error) error {
 	for rows.Next() {
 		var report domain.Report
 		var user domain.User
 		var location domain.Location
 
 		err := rows.Scan(
-			&report.Id, &report.WorkingHours, &report.MaintenanceHours, &report.Status, &report.CreatedAt,
+			&report.Id, &report.WorkingMinutes, &report.MaintenanceMinutes,
+			&report.WorkDate, &report.StartTime, &report.EndTime,
+			&report.Status, &report.CreatedAt,
 			&user.Id, &user.FirstName, &user.LastName, &user.Email,
 			&location.Id, &location.Name,
 		)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		report.User = user
 		report.Location = location
-		reports = append(reports, report)
+		report.ComputeShift()
+
+		if err := fn(report); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func ScanSummaryRows(rows *sql.Rows) ([]domain.SummaryRow, error) {
+	var summaryRows []domain.SummaryRow
+
+	for rows.Next() {
+		var row domain.SummaryRow
+		err := rows.Scan(
+			&row.Key, &row.Label,
+			&row.Approved.WorkingMinutes, &row.Approved.MaintenanceMinutes,
+			&row.Pending.WorkingMinutes, &row.Pending.MaintenanceMinutes,
+			&row.Denied.WorkingMinutes, &row.Denied.MaintenanceMinutes,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Approved.TotalMinutes = row.Approved.WorkingMinutes + row.Approved.MaintenanceMinutes
+		row.Pending.TotalMinutes = row.Pending.WorkingMinutes + row.Pending.MaintenanceMinutes
+		row.Denied.TotalMinutes = row.Denied.WorkingMinutes + row.Denied.MaintenanceMinutes
+		summaryRows = append(summaryRows, row)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return reports, nil
+	return summaryRows, nil
+}
+
+func ScanStatusChangeRows(rows *sql.Rows) ([]domain.StatusChange, error) {
+	history := []domain.StatusChange{}
+	for rows.Next() {
+		var change domain.StatusChange
+		err := rows.Scan(
+			&change.Id, &change.ReportId, &change.FromStatus, &change.ToStatus, &change.Reason, &change.ChangedAt,
+			&change.ChangedBy.Id, &change.ChangedBy.FirstName, &change.ChangedBy.LastName, &change.ChangedBy.Email,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
 }
@@ -0,0 +1,46 @@
+package http
+
+import (
+	repDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+)
+
+// durationInput accepts the reported durations either in minutes or in decimal hours,
+// such as 7.75 for 7h45m. Minutes take precedence when both are sent.
+type durationInput struct {
+	WorkingMinutes     *int64   `json:"working_minutes"`
+	MaintenanceMinutes *int64   `json:"maintenance_minutes"`
+	WorkingHours       *float64 `json:"working_hours"`
+	MaintenanceHours   *float64 `json:"maintenance_hours"`
+}
+
+// minutes returns the working and maintenance time in minutes
+func (d durationInput) minutes() (working uint64, maintenance uint64, err error) {
+	working, err = toMinutes("working_minutes", d.WorkingMinutes, d.WorkingHours)
+	if err != nil {
+		return 0, 0, err
+	}
+	maintenance, err = toMinutes("maintenance_minutes", d.MaintenanceMinutes, d.MaintenanceHours)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return working, maintenance, nil
+}
+
+func toMinutes(field string, minutes *int64, hours *float64) (uint64, error) {
+	switch {
+	case minutes != nil:
+		if *minutes < 0 {
+			return 0, util.NewFieldError(field, repDomain.ErrInvalidHoursInput)
+		}
+		return uint64(*minutes), nil
+	case hours != nil:
+		if *hours < 0 {
+			return 0, util.NewFieldError(field, repDomain.ErrInvalidHoursInput)
+		}
+		return repDomain.HoursToMinutes(*hours), nil
+	default:
+		return 0, nil
+	}
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"net/http"
+	"time"
+	"time-management/internal/report/application/query"
+	repDomain "time-management/internal/report/domain"
+	"time-management/internal/report/infrastructure/export"
+	"time-management/internal/shared/util"
+)
+
+func (h *ReportHandler) ExportReports(w http.ResponseWriter, r *http.Request) error {
+	return h.writeExport(w, r, r.URL.Query().Get("user_id"))
+}
+
+func (h *ReportHandler) ExportReportsForUser(w http.ResponseWriter, r *http.Request) error {
+	return h.writeExport(w, r, chi.URLParam(r, "user_id"))
+}
+
+// writeExport validates the request before anything is written, since the
+// response status cannot change once the rows start streaming
+func (h *ReportHandler) writeExport(w http.ResponseWriter, r *http.Request, userId string) error {
+	filter, err := parseReportFilter(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	filter.UserId = userId
+
+	out := &attachment{w: w}
+	exporter, err := newExporter(r.URL.Query().Get("format"), out)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	out.contentType = exporter.ContentType()
+	out.filename = fmt.Sprintf("reports-%s.%s", time.Now().UTC().Format(repDomain.DateLayout), exporter.FileExtension())
+
+	return h.ExportReportsHandler.Handle(r.Context(), query.ExportReportsQuery{Filter: filter}, exporter)
+}
+
+// attachment sets the download headers with the first byte of the file, so that an export
+// failing before then is answered with a problem instead of an empty file
+type attachment struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (a *attachment) Write(p []byte) (int, error) {
+	if !a.started {
+		a.started = true
+		a.w.Header().Set("Content-Type", a.contentType)
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, a.filename))
+	}
+
+	return a.w.Write(p)
+}
+
+func newExporter(format string, w io.Writer) (repDomain.ReportExporter, error) {
+	switch format {
+	case "", "csv":
+		return export.NewCsvExporter(w), nil
+	case "xlsx":
+		return export.NewXlsxExporter(w), nil
+	default:
+		return nil, util.NewValidationError(repDomain.ErrInvalidExportFormat)
+	}
+}
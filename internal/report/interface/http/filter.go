@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	repDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+)
+
+// parseListRequest reads the filter and pagination query parameters of the report list endpoints
+func parseListRequest(r *http.Request) (repDomain.ReportFilter, pagination.Request, error) {
+	page, err := pagination.FromRequest(r)
+	if err != nil {
+		return repDomain.ReportFilter{}, pagination.Request{}, err
+	}
+
+	filter, err := parseReportFilter(r)
+	if err != nil {
+		return repDomain.ReportFilter{}, pagination.Request{}, err
+	}
+
+	return filter, page, nil
+}
+
+// parseReportFilter reads the user_id, location_id, status, from and to query parameters
+func parseReportFilter(r *http.Request) (repDomain.ReportFilter, error) {
+	values := r.URL.Query()
+	filter := repDomain.ReportFilter{
+		UserId:     values.Get("user_id"),
+		LocationId: values.Get("location_id"),
+	}
+
+	if value := values.Get("status"); value != "" {
+		status, err := repDomain.ParseReportStatus(value)
+		if err != nil {
+			return repDomain.ReportFilter{}, util.NewFieldError("status", err)
+		}
+		filter.Status = &status
+	}
+	if from := values.Get("from"); from != "" {
+		date, err := repDomain.ParseDate(from)
+		if err != nil {
+			return repDomain.ReportFilter{}, util.NewValidationError(repDomain.ErrInvalidWorkDate)
+		}
+		filter.From = &date
+	}
+	if to := values.Get("to"); to != "" {
+		date, err := repDomain.ParseDate(to)
+		if err != nil {
+			return repDomain.ReportFilter{}, util.NewValidationError(repDomain.ErrInvalidWorkDate)
+		}
+		filter.To = &date
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(filter.To.Time) {
+		return repDomain.ReportFilter{}, util.NewValidationError(repDomain.ErrInvalidDateRange)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,73 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time-management/internal/report/application/query"
+	"time-management/internal/report/domain"
+	repHttp "time-management/internal/report/interface/http"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeReportRepository records the statuses and filter reports are listed with, the methods
+// it does not override are not expected to be called
+type fakeReportRepository struct {
+	domain.ReportRepository
+	statuses []domain.ReportStatus
+	filter   domain.ReportFilter
+}
+
+func (r *fakeReportRepository) GetAll(
+	ctx context.Context,
+	statuses []domain.ReportStatus,
+	filter domain.ReportFilter,
+	page pagination.Request,
+) ([]domain.Report, int, error) {
+	r.statuses = statuses
+	r.filter = filter
+	return []domain.Report{}, 0, nil
+}
+
+func TestReportHandler_GetPendingReports_StatusFilter(t *testing.T) {
+	repo := &fakeReportRepository{}
+	handler := repHttp.ReportHandler{GetPendingReportsHandler: query.GetPendingReportsHandler{Repo: repo}}
+	rec := httptest.NewRecorder()
+
+	// Execute test
+	req := httptest.NewRequest(http.MethodGet, "/reports/pending/users/all?status=reopened", nil)
+	util.HttpHandler(handler.GetPendingReports).ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, domain.PendingStatuses, repo.statuses)
+	if assert.NotNil(t, repo.filter.Status) {
+		assert.Equal(t, domain.Reopened, *repo.filter.Status)
+	}
+}
+
+func TestReportHandler_GetPendingReports_InvalidStatus(t *testing.T) {
+	util.RegisterErrorCodes(domain.ErrorCodes)
+	repo := &fakeReportRepository{}
+	handler := repHttp.ReportHandler{GetPendingReportsHandler: query.GetPendingReportsHandler{Repo: repo}}
+	rec := httptest.NewRecorder()
+
+	// Execute test
+	req := httptest.NewRequest(http.MethodGet, "/reports/pending/users/all?status=archived", nil)
+	util.HttpHandler(handler.GetPendingReports).ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var problem util.Problem
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, "report_status_invalid", problem.Code)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "status", problem.Errors[0].Field)
+	}
+	assert.Nil(t, repo.statuses)
+}
@@ -10,6 +10,7 @@ import (
 	"time-management/internal/report/infrastructure/repository"
 	"time-management/internal/shared/util"
 	"time-management/internal/user/domain"
+	"time-management/internal/user/role"
 )
 
 type ReportHandler struct {
@@ -26,15 +27,34 @@ type ReportHandler struct {
 	GetDeniedReportHandler           query.GetDeniedReportHandler
 	GetDeniedReportsByUserIdHandler  query.GetDeniedReportsByUserIdHandler
 	GetDeniedReportByUserIdHandler   query.GetDeniedReportByUserIdHandler
+	GetReportSummaryHandler          query.GetReportSummaryHandler
+	ExportReportsHandler             query.ExportReportsHandler
+	GetReportHistoryHandler          query.GetReportHistoryHandler
 	UpdatePendingReportHandler       command.UpdatePendingReportHandler
 	ApproveReportHandler             command.ApproveReportHandler
 	DenyReportHandler                command.DenyReportHandler
+	ReopenReportHandler              command.ReopenReportHandler
+	ResubmitReportHandler            command.ResubmitReportHandler
 	DeleteReportHandler              command.DeleteReportHandler
 }
 
-func NewReportHandler(repository *repository.PgReportRepository) *ReportHandler {
+// NewReportHandler wires the report endpoints. A nil compliance checker skips the working
+// time rules, a nil absence checker accepts reports on days off, a nil overtime calculator
+// leaves overtime out of summaries and exports.
+func NewReportHandler(
+	repository *repository.PgReportRepository,
+	rounding repDomain.RoundingRule,
+	compliance repDomain.ComplianceChecker,
+	absences repDomain.AbsenceChecker,
+	overtime repDomain.OvertimeCalculator,
+) *ReportHandler {
 	return &ReportHandler{
-		CreateReportHandler:              command.CreateReportHandler{Repo: repository},
+		CreateReportHandler: command.CreateReportHandler{
+			Repo:       repository,
+			Rounding:   rounding,
+			Compliance: compliance,
+			Absences:   absences,
+		},
 		GetReportsHandler:                query.GetReportsHandler{Repo: repository},
 		GetReportHandler:                 query.GetReportHandler{Repo: repository},
 		GetReportsByUserIdHandler:        query.GetReportsByUserIdHandler{Repo: repository},
@@ -47,10 +67,25 @@ func NewReportHandler(repository *repository.PgReportRepository) *ReportHandler
 		GetDeniedReportHandler:           query.GetDeniedReportHandler{Repo: repository},
 		GetDeniedReportsByUserIdHandler:  query.GetDeniedReportsByUserIdHandler{Repo: repository},
 		GetDeniedReportByUserIdHandler:   query.GetDeniedReportByUserIdHandler{Repo: repository},
-		UpdatePendingReportHandler:       command.UpdatePendingReportHandler{Repo: repository},
-		ApproveReportHandler:             command.ApproveReportHandler{Repo: repository},
-		DenyReportHandler:                command.DenyReportHandler{Repo: repository},
-		DeleteReportHandler:              command.DeleteReportHandler{Repo: repository},
+		GetReportSummaryHandler:          query.GetReportSummaryHandler{Repo: repository, Overtime: overtime},
+		ExportReportsHandler:             query.ExportReportsHandler{Repo: repository, Overtime: overtime},
+		GetReportHistoryHandler:          query.GetReportHistoryHandler{Repo: repository},
+		UpdatePendingReportHandler: command.UpdatePendingReportHandler{
+			Repo:       repository,
+			Rounding:   rounding,
+			Compliance: compliance,
+			Absences:   absences,
+		},
+		ApproveReportHandler: command.ApproveReportHandler{Repo: repository, Compliance: compliance},
+		DenyReportHandler:    command.DenyReportHandler{Repo: repository},
+		ReopenReportHandler:  command.ReopenReportHandler{Repo: repository},
+		ResubmitReportHandler: command.ResubmitReportHandler{
+			Repo:       repository,
+			Rounding:   rounding,
+			Compliance: compliance,
+			Absences:   absences,
+		},
+		DeleteReportHandler: command.DeleteReportHandler{Repo: repository},
 	}
 }
 
@@ -59,34 +94,40 @@ func (h *ReportHandler) CreateReport(w http.ResponseWriter, r *http.Request) err
 	if employeeId == "" {
 		user, ok := r.Context().Value("user").(*domain.User)
 		if !ok {
-			return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: "Unauthorized: unable to get user"})
+			return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 		}
 		employeeId = user.Id
 	}
 
 	var req struct {
-		LocationId       string `json:"location_id"`
-		WorkingHours     int64  `json:"working_hours"`
-		MaintenanceHours int64  `json:"maintenance_hours"`
+		durationInput
+		LocationId string `json:"location_id"`
+		WorkDate   string `json:"work_date"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
-	if req.WorkingHours < 0 || req.MaintenanceHours < 0 {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: repDomain.ErrInvalidHoursInput.Error()})
+	working, maintenance, err := req.minutes()
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	cmd := command.CreateReportCommand{
-		EmployeeId:       employeeId,
-		LocationId:       req.LocationId,
-		WorkingHours:     uint64(req.WorkingHours),
-		MaintenanceHours: uint64(req.MaintenanceHours),
+		EmployeeId:         employeeId,
+		LocationId:         req.LocationId,
+		WorkingMinutes:     working,
+		MaintenanceMinutes: maintenance,
+		WorkDate:           req.WorkDate,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
 	}
 
 	report, err := h.CreateReportHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusCreated, report)
@@ -95,17 +136,18 @@ func (h *ReportHandler) CreateReport(w http.ResponseWriter, r *http.Request) err
 func (h *ReportHandler) GetOwnReports(w http.ResponseWriter, r *http.Request) error {
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
-	reportsQuery := query.GetReportsByUserIdQuery{UserId: user.Id}
-	reports, err := h.GetReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetReportsByUserIdQuery{UserId: user.Id, Filter: filter, Page: page}
+	reports, err := h.GetReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -116,26 +158,28 @@ func (h *ReportHandler) GetOwnReport(w http.ResponseWriter, r *http.Request) err
 
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
 	reportQuery := query.GetReportByUserIdQuery{Id: id, UserId: user.Id}
 	report, err := h.GetReportByUserIdHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
 }
 
 func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) error {
-	reports, err := h.GetReportsHandler.Handle(r.Context())
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetReportsQuery{Filter: filter, Page: page}
+	reports, err := h.GetReportsHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -146,7 +190,7 @@ func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) error
 
 	report, err := h.GetReportHandler.Handle(r.Context(), query.GetReportQuery{Id: id})
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
@@ -155,14 +199,15 @@ func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) error
 func (h *ReportHandler) GetReportsForUser(w http.ResponseWriter, r *http.Request) error {
 	userId := chi.URLParam(r, "user_id")
 
-	reportsQuery := query.GetReportsByUserIdQuery{UserId: userId}
-	reports, err := h.GetReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetReportsByUserIdQuery{UserId: userId, Filter: filter, Page: page}
+	reports, err := h.GetReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -175,7 +220,7 @@ func (h *ReportHandler) GetReportForUser(w http.ResponseWriter, r *http.Request)
 	reportQuery := query.GetReportByUserIdQuery{Id: id, UserId: userId}
 	report, err := h.GetReportByUserIdHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
@@ -184,17 +229,18 @@ func (h *ReportHandler) GetReportForUser(w http.ResponseWriter, r *http.Request)
 func (h *ReportHandler) GetOwnPendingReports(w http.ResponseWriter, r *http.Request) error {
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
-	reportsQuery := query.GetPendingReportsByUserIdQuery{UserId: user.Id}
-	reports, err := h.GetPendingReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetPendingReportsByUserIdQuery{UserId: user.Id, Filter: filter, Page: page}
+	reports, err := h.GetPendingReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -205,27 +251,28 @@ func (h *ReportHandler) GetOwnPendingReport(w http.ResponseWriter, r *http.Reque
 
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
 	reportQuery := query.GetPendingReportByUserIdQuery{Id: id, UserId: user.Id}
 	report, err := h.GetPendingReportByUserIdHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
 }
 
 func (h *ReportHandler) GetPendingReports(w http.ResponseWriter, r *http.Request) error {
-
-	reports, err := h.GetPendingReportsHandler.Handle(r.Context())
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetPendingReportsQuery{Filter: filter, Page: page}
+	reports, err := h.GetPendingReportsHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -237,7 +284,7 @@ func (h *ReportHandler) GetPendingReport(w http.ResponseWriter, r *http.Request)
 	reportQuery := query.GetPendingReportQuery{Id: id}
 	report, err := h.GetPendingReportHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
@@ -246,14 +293,15 @@ func (h *ReportHandler) GetPendingReport(w http.ResponseWriter, r *http.Request)
 func (h *ReportHandler) GetPendingReportsForUser(w http.ResponseWriter, r *http.Request) error {
 	userId := chi.URLParam(r, "user_id")
 
-	reportsQuery := query.GetPendingReportsByUserIdQuery{UserId: userId}
-	reports, err := h.GetPendingReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetPendingReportsByUserIdQuery{UserId: userId, Filter: filter, Page: page}
+	reports, err := h.GetPendingReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -266,7 +314,7 @@ func (h *ReportHandler) GetPendingReportForUser(w http.ResponseWriter, r *http.R
 	reportQuery := query.GetPendingReportByUserIdQuery{Id: id, UserId: userId}
 	report, err := h.GetPendingReportByUserIdHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
@@ -275,17 +323,18 @@ func (h *ReportHandler) GetPendingReportForUser(w http.ResponseWriter, r *http.R
 func (h *ReportHandler) GetOwnDeniedReports(w http.ResponseWriter, r *http.Request) error {
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
-	reportsQuery := query.GetDeniedReportsByUserIdQuery{UserId: user.Id}
-	reports, err := h.GetDeniedReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetDeniedReportsByUserIdQuery{UserId: user.Id, Filter: filter, Page: page}
+	reports, err := h.GetDeniedReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -296,26 +345,28 @@ func (h *ReportHandler) GetOwnDeniedReport(w http.ResponseWriter, r *http.Reques
 
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
 	reportQuery := query.GetDeniedReportByUserIdQuery{Id: id, UserId: user.Id}
 	report, err := h.GetDeniedReportByUserIdHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
 }
 
 func (h *ReportHandler) GetDeniedReports(w http.ResponseWriter, r *http.Request) error {
-	reports, err := h.GetDeniedReportsHandler.Handle(r.Context())
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetDeniedReportsQuery{Filter: filter, Page: page}
+	reports, err := h.GetDeniedReportsHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -327,7 +378,7 @@ func (h *ReportHandler) GetDeniedReport(w http.ResponseWriter, r *http.Request)
 	reportQuery := query.GetDeniedReportQuery{Id: id}
 	report, err := h.GetDeniedReportHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
@@ -336,14 +387,15 @@ func (h *ReportHandler) GetDeniedReport(w http.ResponseWriter, r *http.Request)
 func (h *ReportHandler) GetDeniedReportsForUser(w http.ResponseWriter, r *http.Request) error {
 	userId := chi.URLParam(r, "user_id")
 
-	reportsQuery := query.GetDeniedReportsByUserIdQuery{UserId: userId}
-	reports, err := h.GetDeniedReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	filter, page, err := parseListRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
-	if reports == nil {
-		reports = []repDomain.Report{}
+	reportsQuery := query.GetDeniedReportsByUserIdQuery{UserId: userId, Filter: filter, Page: page}
+	reports, err := h.GetDeniedReportsByUserIdHandler.Handle(r.Context(), reportsQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, reports)
@@ -356,39 +408,83 @@ func (h *ReportHandler) GetDeniedReportForUser(w http.ResponseWriter, r *http.Re
 	reportQuery := query.GetDeniedReportByUserIdQuery{Id: id, UserId: userId}
 	report, err := h.GetDeniedReportByUserIdHandler.Handle(r.Context(), reportQuery)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, report)
 }
 
+func (h *ReportHandler) GetOwnSummary(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	return h.writeSummary(w, r, user.Id)
+}
+
+func (h *ReportHandler) GetSummary(w http.ResponseWriter, r *http.Request) error {
+	return h.writeSummary(w, r, r.URL.Query().Get("user_id"))
+}
+
+func (h *ReportHandler) GetSummaryForUser(w http.ResponseWriter, r *http.Request) error {
+	return h.writeSummary(w, r, chi.URLParam(r, "user_id"))
+}
+
+func (h *ReportHandler) writeSummary(w http.ResponseWriter, r *http.Request, userId string) error {
+	filter, err := parseReportFilter(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	filter.UserId = userId
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = string(repDomain.GroupByDay)
+	}
+
+	summaryQuery := query.GetReportSummaryQuery{GroupBy: groupBy, Filter: filter}
+	summary, err := h.GetReportSummaryHandler.Handle(r.Context(), summaryQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, summary)
+}
+
 func (h *ReportHandler) UpdatePendingReport(w http.ResponseWriter, r *http.Request) error {
 	id := chi.URLParam(r, "id")
 	userId := chi.URLParam(r, "user_id")
 
 	var req struct {
-		LocationId       string `json:"location_id"`
-		WorkingHours     int64  `json:"working_hours"`
-		MaintenanceHours int64  `json:"maintenance_hours"`
+		durationInput
+		LocationId string `json:"location_id"`
+		WorkDate   string `json:"work_date"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
-	if req.WorkingHours < 0 || req.MaintenanceHours < 0 {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: "hours cannot be negative"})
+	working, maintenance, err := req.minutes()
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	reportCmd := command.UpdatePendingReportCommand{
-		UserId:           userId,
-		Id:               id,
-		LocationId:       req.LocationId,
-		WorkingHours:     uint64(req.WorkingHours),
-		MaintenanceHours: uint64(req.MaintenanceHours),
+		UserId:             userId,
+		Id:                 id,
+		LocationId:         req.LocationId,
+		WorkingMinutes:     working,
+		MaintenanceMinutes: maintenance,
+		WorkDate:           req.WorkDate,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
 	}
 	updatedReport, err := h.UpdatePendingReportHandler.Handle(r.Context(), reportCmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, updatedReport)
@@ -399,44 +495,101 @@ func (h *ReportHandler) UpdateOwnPendingReport(w http.ResponseWriter, r *http.Re
 
 	user, ok := r.Context().Value("user").(*domain.User)
 	if !ok || user == nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 	}
 
 	var req struct {
-		LocationId       string `json:"location_id"`
-		WorkingHours     int64  `json:"working_hours"`
-		MaintenanceHours int64  `json:"maintenance_hours"`
+		durationInput
+		LocationId string `json:"location_id"`
+		WorkDate   string `json:"work_date"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
-	if req.WorkingHours < 0 || req.MaintenanceHours < 0 {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: "hours cannot be negative"})
+	working, maintenance, err := req.minutes()
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	reportCmd := command.UpdatePendingReportCommand{
-		UserId:           user.Id,
-		Id:               id,
-		LocationId:       req.LocationId,
-		WorkingHours:     uint64(req.WorkingHours),
-		MaintenanceHours: uint64(req.MaintenanceHours),
+		UserId:             user.Id,
+		Id:                 id,
+		LocationId:         req.LocationId,
+		WorkingMinutes:     working,
+		MaintenanceMinutes: maintenance,
+		WorkDate:           req.WorkDate,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
 	}
 	updatedReport, err := h.UpdatePendingReportHandler.Handle(r.Context(), reportCmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, updatedReport)
 }
 
+// ResubmitDeniedReport edits a denied report of the current user and sends it back for review
+func (h *ReportHandler) ResubmitDeniedReport(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	var req struct {
+		durationInput
+		LocationId string `json:"location_id"`
+		WorkDate   string `json:"work_date"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+	working, maintenance, err := req.minutes()
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	reportCmd := command.ResubmitReportCommand{
+		UserId:             user.Id,
+		Id:                 id,
+		LocationId:         req.LocationId,
+		WorkingMinutes:     working,
+		MaintenanceMinutes: maintenance,
+		WorkDate:           req.WorkDate,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
+	}
+	resubmittedReport, err := h.ResubmitReportHandler.Handle(r.Context(), reportCmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, resubmittedReport)
+}
+
 func (h *ReportHandler) ApproveReport(w http.ResponseWriter, r *http.Request) error {
 	id := chi.URLParam(r, "id")
 
-	cmdReport := command.ApproveReportCommand{Id: id}
-	err := h.ApproveReportHandler.Handle(r.Context(), cmdReport)
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	cmdReport := command.ApproveReportCommand{Id: id, ChangedBy: user.Id}
+	warnings, err := h.ApproveReportHandler.Handle(r.Context(), cmdReport)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
+	}
+	if len(warnings) > 0 {
+		return util.WriteJson(w, http.StatusOK, map[string]any{"compliance_warnings": warnings})
 	}
 
 	return util.WriteJson(w, http.StatusOK, nil)
@@ -445,22 +598,83 @@ func (h *ReportHandler) ApproveReport(w http.ResponseWriter, r *http.Request) er
 func (h *ReportHandler) DenyReport(w http.ResponseWriter, r *http.Request) error {
 	id := chi.URLParam(r, "id")
 
-	cmdReport := command.DenyReportCommand{Id: id}
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmdReport := command.DenyReportCommand{Id: id, ChangedBy: user.Id, Reason: req.Reason}
 	err := h.DenyReportHandler.Handle(r.Context(), cmdReport)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+func (h *ReportHandler) ReopenReport(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return util.WriteError(w, r, util.InvalidBody(err))
+		}
+	}
+
+	cmdReport := command.ReopenReportCommand{Id: id, ChangedBy: user.Id, Reason: req.Reason}
+	err := h.ReopenReportHandler.Handle(r.Context(), cmdReport)
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, nil)
 }
 
+// GetReportHistory returns the status changes of a report. Employees only see
+// the history of their own reports.
+func (h *ReportHandler) GetReportHistory(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	historyQuery := query.GetReportHistoryQuery{Id: id}
+	if user.Role == role.Employee.String() {
+		historyQuery.UserId = user.Id
+	}
+
+	history, err := h.GetReportHistoryHandler.Handle(r.Context(), historyQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, history)
+}
+
 func (h *ReportHandler) DeleteReport(w http.ResponseWriter, r *http.Request) error {
 	id := chi.URLParam(r, "id")
 
 	cmdReport := command.DeleteReport{Id: id}
 	err := h.DeleteReportHandler.Handle(r.Context(), cmdReport)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, nil)
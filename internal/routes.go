@@ -2,16 +2,32 @@ package internal
 
 import (
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
 	"net/http"
+	absenceDomain "time-management/internal/absence/domain"
+	absenceHttp "time-management/internal/absence/interface/http"
+	complianceDomain "time-management/internal/compliance/domain"
+	complianceHttp "time-management/internal/compliance/interface/http"
+	locDomain "time-management/internal/location/domain"
 	locHttp "time-management/internal/location/interface/http"
+	overtimeDomain "time-management/internal/overtime/domain"
+	overtimeHttp "time-management/internal/overtime/interface/http"
+	repDomain "time-management/internal/report/domain"
 	repHttp "time-management/internal/report/interface/http"
+	"time-management/internal/shared/config"
+	"time-management/internal/shared/health"
+	"time-management/internal/shared/metrics"
 	appMiddleware "time-management/internal/shared/middleware"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
+	teDomain "time-management/internal/timeentry/domain"
+	teHttp "time-management/internal/timeentry/interface/http"
+	userDomain "time-management/internal/user/domain"
 	userHttp "time-management/internal/user/interface/http"
 	"time-management/internal/user/role"
 	adminHttp "time-management/internal/user/role/admin/interface/http"
 	empHttp "time-management/internal/user/role/employee/interface/http"
+	mgrHttp "time-management/internal/user/role/manager/interface/http"
 )
 
 func SetupRoutes(
@@ -19,15 +35,60 @@ func SetupRoutes(
 	userHandler *userHttp.UserHandler,
 	adminHandler *adminHttp.AdminHandler,
 	employeeHandler *empHttp.EmployeeHandler,
+	managerHandler *mgrHttp.ManagerHandler,
 	reportHandler *repHttp.ReportHandler,
+	timeEntryHandler *teHttp.TimeEntryHandler,
+	complianceHandler *complianceHttp.ComplianceHandler,
+	overtimeHandler *overtimeHttp.OvertimeHandler,
+	absenceHandler *absenceHttp.AbsenceHandler,
+	healthHandler *health.Handler,
+	sessionRepository userDomain.SessionRepository,
+	cfg *config.Config,
 ) *chi.Mux {
+	util.RegisterErrorCodes(userDomain.ErrorCodes)
+	util.RegisterErrorCodes(userHttp.ErrorCodes)
+	util.RegisterErrorCodes(locDomain.ErrorCodes)
+	util.RegisterErrorCodes(repDomain.ErrorCodes)
+	util.RegisterErrorCodes(teDomain.ErrorCodes)
+	util.RegisterErrorCodes(complianceDomain.ErrorCodes)
+	util.RegisterErrorCodes(overtimeDomain.ErrorCodes)
+	util.RegisterErrorCodes(absenceDomain.ErrorCodes)
+	util.RegisterErrorCodes(pagination.ErrorCodes)
+	util.RegisterErrorCodes(appMiddleware.ErrorCodes)
+
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(appMiddleware.RequestId)
+	r.Use(appMiddleware.AccessLog(slog.Default()))
+
+	r.Get("/healthz", util.HttpHandler(healthHandler.Healthz))
+	r.Get("/readyz", util.HttpHandler(healthHandler.Readyz))
+	r.Get("/version", util.HttpHandler(healthHandler.Version))
+	r.Method(http.MethodGet, "/metrics", metrics.Handler())
 
 	r.Post("/login", util.HttpHandler(userHandler.LoginUser))
+	r.Post("/login/2fa", util.HttpHandler(userHandler.VerifyTwoFactor))
+	r.Post("/login/2fa/enroll", util.HttpHandler(userHandler.EnrollTwoFactorOnLogin))
+	r.Post("/login/2fa/enroll/confirm", util.HttpHandler(userHandler.ConfirmTwoFactorOnLogin))
 	r.Post("/logout", util.HttpHandler(userHandler.LogoutUser))
+	r.Post("/token/refresh", util.HttpHandler(userHandler.RefreshToken))
+	r.Post("/password/forgot", util.HttpHandler(userHandler.ForgotPassword))
+	r.Post("/password/reset", util.HttpHandler(userHandler.ResetPassword))
 
-	r.With(appMiddleware.AuthMiddleware).Group(func(r chi.Router) {
+	r.With(appMiddleware.AuthMiddleware(sessionRepository, []byte(cfg.Jwt.Secret))).Group(func(r chi.Router) {
+		r.With(Role()).
+			Delete("/sessions/users/{user_id}", util.HttpHandler(userHandler.RevokeUserSessions))
+		r.With(Role()).
+			Delete("/lockouts/users/{user_id}", util.HttpHandler(userHandler.UnlockUser))
+		r.Route("/2fa", func(r chi.Router) {
+			r.Post("/enroll", util.HttpHandler(userHandler.EnrollTwoFactor))
+			r.Post("/enroll/confirm", util.HttpHandler(userHandler.ConfirmTwoFactor))
+			r.With(Role()).
+				Delete("/users/{user_id}", util.HttpHandler(userHandler.ResetTwoFactor))
+			r.With(Role()).
+				Get("/policies", util.HttpHandler(userHandler.GetTwoFactorPolicies))
+			r.With(Role()).
+				Put("/policies/{role}", util.HttpHandler(userHandler.SetTwoFactorPolicy))
+		})
 		r.Route("/locations", func(r chi.Router) {
 			r.With(Role(role.Manager)).
 				Post("/", util.HttpHandler(locationHandler.CreateLocation))
@@ -66,6 +127,20 @@ func SetupRoutes(
 			r.With(Role()).
 				Delete("/{id}", util.HttpHandler(employeeHandler.DeleteEmployee))
 		})
+		r.Route("/managers", func(r chi.Router) {
+			r.With(Role()).
+				Post("/", util.HttpHandler(managerHandler.CreateManager))
+			r.With(Role()).
+				Get("/", util.HttpHandler(managerHandler.GetManagers))
+			r.With(Role()).
+				Get("/{id}", util.HttpHandler(managerHandler.GetManager))
+			r.With(Role()).
+				Put("/{id}", util.HttpHandler(managerHandler.UpdateManager))
+			r.With(Role()).
+				Patch("/{id}/status", util.HttpHandler(managerHandler.ToggleManagerStatus))
+			r.With(Role()).
+				Delete("/{id}", util.HttpHandler(managerHandler.DeleteManager))
+		})
 		r.Route("/admins", func(r chi.Router) {
 			r.With(Role()).
 				Post("/", util.HttpHandler(adminHandler.CreateAdmin))
@@ -87,15 +162,29 @@ func SetupRoutes(
 				Get("/", util.HttpHandler(reportHandler.GetOwnReports))
 			r.With(Role(role.Employee, role.Manager)).
 				Get("/{id}", util.HttpHandler(reportHandler.GetOwnReport))
+			r.Route("/summary", func(r chi.Router) {
+				r.With(Role(role.Employee, role.Manager)).
+					Get("/", util.HttpHandler(reportHandler.GetOwnSummary))
+				r.Route("/users", func(r chi.Router) {
+					r.With(Role(role.Manager)).
+						Get("/all", util.HttpHandler(reportHandler.GetSummary))
+					r.With(Role(role.Manager)).
+						Get("/{user_id}", util.HttpHandler(reportHandler.GetSummaryForUser))
+				})
+			})
 			r.Route("/users", func(r chi.Router) {
 				r.Route("/all", func(r chi.Router) {
 					r.With(Role(role.Manager)).
 						Get("/", util.HttpHandler(reportHandler.GetReports))
+					r.With(Role(role.Manager)).
+						Get("/export", util.HttpHandler(reportHandler.ExportReports))
 					r.With(Role(role.Manager)).
 						Get("/{id}", util.HttpHandler(reportHandler.GetReport))
 				})
 				r.With(Role(role.Manager)).
 					Get("/{user_id}", util.HttpHandler(reportHandler.GetReportsForUser))
+				r.With(Role(role.Manager)).
+					Get("/{user_id}/export", util.HttpHandler(reportHandler.ExportReportsForUser))
 				r.With(Role(role.Manager)).
 					Get("/{user_id}/{id}", util.HttpHandler(reportHandler.GetReportForUser))
 			})
@@ -126,6 +215,8 @@ func SetupRoutes(
 					Get("/", util.HttpHandler(reportHandler.GetOwnDeniedReports))
 				r.With(Role(role.Employee, role.Manager)).
 					Get("/{id}", util.HttpHandler(reportHandler.GetOwnDeniedReport))
+				r.With(Role(role.Employee, role.Manager)).
+					Put("/{id}/resubmit", util.HttpHandler(reportHandler.ResubmitDeniedReport))
 				r.Route("/users", func(r chi.Router) {
 					r.Route("/all", func(r chi.Router) {
 						r.With(Role(role.Manager)).
@@ -139,13 +230,93 @@ func SetupRoutes(
 						Get("/{user_id}/{id}", util.HttpHandler(reportHandler.GetDeniedReportForUser))
 				})
 			})
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/{id}/history", util.HttpHandler(reportHandler.GetReportHistory))
 			r.With(Role(role.Manager)).
 				Patch("/{id}/approve", util.HttpHandler(reportHandler.ApproveReport))
 			r.With(Role(role.Manager)).
 				Patch("/{id}/deny", util.HttpHandler(reportHandler.DenyReport))
+			r.With(Role()).
+				Patch("/{id}/reopen", util.HttpHandler(reportHandler.ReopenReport))
 			r.With(Role()).
 				Delete("/{id}", util.HttpHandler(reportHandler.DeleteReport))
 		})
+		r.Route("/time-entries", func(r chi.Router) {
+			r.With(Role(role.Employee, role.Manager)).
+				Post("/clock-in", util.HttpHandler(timeEntryHandler.ClockIn))
+			r.With(Role(role.Employee, role.Manager)).
+				Post("/clock-out", util.HttpHandler(timeEntryHandler.ClockOut))
+			r.With(Role(role.Employee, role.Manager)).
+				Post("/break/start", util.HttpHandler(timeEntryHandler.StartBreak))
+			r.With(Role(role.Employee, role.Manager)).
+				Post("/break/end", util.HttpHandler(timeEntryHandler.EndBreak))
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/current", util.HttpHandler(timeEntryHandler.GetCurrentEntry))
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/", util.HttpHandler(timeEntryHandler.GetOwnEntries))
+			r.With(Role(role.Employee, role.Manager)).
+				Post("/reports", util.HttpHandler(timeEntryHandler.ConvertEntries))
+			r.With(Role(role.Manager)).
+				Post("/reports/{employee_id}", util.HttpHandler(timeEntryHandler.ConvertEntries))
+			r.Route("/users/all", func(r chi.Router) {
+				r.With(Role(role.Manager)).
+					Get("/", util.HttpHandler(timeEntryHandler.GetEntries))
+				r.With(Role(role.Manager)).
+					Get("/open", util.HttpHandler(timeEntryHandler.GetOpenEntries))
+				r.With(Role(role.Manager)).
+					Get("/{id}", util.HttpHandler(timeEntryHandler.GetEntry))
+			})
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/{id}", util.HttpHandler(timeEntryHandler.GetOwnEntry))
+		})
+		r.Route("/compliance", func(r chi.Router) {
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/", util.HttpHandler(complianceHandler.GetOwnComplianceReport))
+			r.With(Role(role.Manager)).
+				Get("/users/all", util.HttpHandler(complianceHandler.GetComplianceReport))
+		})
+		r.Route("/contracts", func(r chi.Router) {
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/", util.HttpHandler(overtimeHandler.GetOwnContract))
+			r.With(Role(role.Manager)).
+				Get("/users/{user_id}", util.HttpHandler(overtimeHandler.GetContract))
+			r.With(Role(role.Manager)).
+				Put("/users/{user_id}", util.HttpHandler(overtimeHandler.SetContract))
+		})
+		r.Route("/overtime", func(r chi.Router) {
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/", util.HttpHandler(overtimeHandler.GetOwnOvertime))
+			r.With(Role(role.Manager)).
+				Get("/users/all", util.HttpHandler(overtimeHandler.GetOvertime))
+		})
+		r.Route("/absences", func(r chi.Router) {
+			r.With(Role(role.Employee, role.Manager)).
+				Post("/", util.HttpHandler(absenceHandler.RequestAbsence))
+			r.With(Role(role.Manager)).
+				Post("/{employee_id}", util.HttpHandler(absenceHandler.RequestAbsence))
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/", util.HttpHandler(absenceHandler.GetOwnAbsences))
+			r.Route("/calendar", func(r chi.Router) {
+				r.With(Role(role.Employee, role.Manager)).
+					Get("/", util.HttpHandler(absenceHandler.GetOwnCalendar))
+				r.With(Role(role.Manager)).
+					Get("/users/all", util.HttpHandler(absenceHandler.GetCalendar))
+			})
+			r.Route("/users/all", func(r chi.Router) {
+				r.With(Role(role.Manager)).
+					Get("/", util.HttpHandler(absenceHandler.GetAbsences))
+				r.With(Role(role.Manager)).
+					Get("/{id}", util.HttpHandler(absenceHandler.GetAbsence))
+			})
+			r.With(Role(role.Employee, role.Manager)).
+				Get("/{id}", util.HttpHandler(absenceHandler.GetOwnAbsence))
+			r.With(Role(role.Manager)).
+				Patch("/{id}/approve", util.HttpHandler(absenceHandler.ApproveAbsence))
+			r.With(Role(role.Manager)).
+				Patch("/{id}/deny", util.HttpHandler(absenceHandler.DenyAbsence))
+			r.With(Role(role.Employee, role.Manager)).
+				Patch("/{id}/cancel", util.HttpHandler(absenceHandler.CancelAbsence))
+		})
 	})
 
 	return r
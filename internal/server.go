@@ -1,61 +1,259 @@
 package internal
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	_ "github.com/jackc/pgx/v4/stdlib"
+	"log/slog"
 	"net/http"
-	"os"
-	"strconv"
 	"time"
+	absenceRepo "time-management/internal/absence/infrastructure/repository"
+	absenceHttp "time-management/internal/absence/interface/http"
+	complianceQuery "time-management/internal/compliance/application/query"
+	complianceRepo "time-management/internal/compliance/infrastructure/repository"
+	complianceRules "time-management/internal/compliance/infrastructure/rules"
+	complianceHttp "time-management/internal/compliance/interface/http"
 	locRepo "time-management/internal/location/infrastructure/repository"
 	locHttp "time-management/internal/location/interface/http"
+	"time-management/internal/migration"
+	overtimeQuery "time-management/internal/overtime/application/query"
+	overtimeRepo "time-management/internal/overtime/infrastructure/repository"
+	overtimeSettings "time-management/internal/overtime/infrastructure/settings"
+	overtimeHttp "time-management/internal/overtime/interface/http"
+	repDomain "time-management/internal/report/domain"
 	repRepo "time-management/internal/report/infrastructure/repository"
 	repHttp "time-management/internal/report/interface/http"
+	"time-management/internal/shared/config"
+	"time-management/internal/shared/health"
+	"time-management/internal/shared/mail"
+	"time-management/internal/shared/metrics"
+	teCommand "time-management/internal/timeentry/application/command"
+	teDomain "time-management/internal/timeentry/domain"
+	teRepo "time-management/internal/timeentry/infrastructure/repository"
+	teHttp "time-management/internal/timeentry/interface/http"
+	userPassword "time-management/internal/user/infrastructure/password"
 	userRepo "time-management/internal/user/infrastructure/repository"
+	"time-management/internal/user/infrastructure/throttle"
 	userHttp "time-management/internal/user/interface/http"
 	adminHttp "time-management/internal/user/role/admin/interface/http"
 	empHttp "time-management/internal/user/role/employee/interface/http"
+	mgrHttp "time-management/internal/user/role/manager/interface/http"
 )
 
+// sweepInterval is how often entries left open for too long are closed
+const sweepInterval = 5 * time.Minute
+
 type Server struct {
 	port int
 	db   *sql.DB
 }
 
-func NewServer() *http.Server {
-	port, err := strconv.Atoi(os.Getenv("PORT"))
+// NewServer wires the application on top of the database pool. The pool stays open
+// until CloseDB is called after the server has shut down, background jobs stop with ctx.
+func NewServer(ctx context.Context, cfg *config.Config) *http.Server {
+	db, err := InitializeDB(cfg.Database)
 	if err != nil {
 		panic(err)
 	}
 
-	db, err := InitializeDB()
+	migrator, err := migration.NewMigrator(db)
 	if err != nil {
 		panic(err)
 	}
 
-	defer CloseDB()
+	// Apply pending migrations when enabled, otherwise they are run with cmd/migrate
+	if cfg.Database.MigrateOnStartup {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			panic(err)
+		}
+		slog.Info("applied migrations", "count", len(applied))
+	}
+
+	if err := metrics.RegisterDB(db, "postgres"); err != nil {
+		panic(err)
+	}
 
 	// Initialize repositories
 	locationRepository := locRepo.NewPgLocationRepository(db)
 	userRepository := userRepo.NewPgUsersRepository(db)
+	sessionRepository := userRepo.NewPgSessionRepository(db)
+	passwordResetRepository := userRepo.NewPgPasswordResetRepository(db)
+	twoFactorRepository := userRepo.NewPgTwoFactorRepository(db)
 	reportRepository := repRepo.NewPgReportRepository(db)
+	timeEntryRepository := teRepo.NewPgTimeEntryRepository(db)
+	workPeriodRepository := complianceRepo.NewPgWorkPeriodRepository(db)
+	overtimeRepository := overtimeRepo.NewPgOvertimeRepository(db)
+	absenceRepository := absenceRepo.NewPgAbsenceRepository(db)
+
+	passwordPolicy := userPassword.PolicyFromConfig(cfg.Password)
+	passwordHasher, err := userPassword.NewHasherFromConfig(cfg.Password)
+	if err != nil {
+		panic(err)
+	}
+
+	// A weak seed password would give the most privileged account the weakest protection
+	if err := passwordPolicy.Validate(cfg.SuperAdmin.Password, cfg.SuperAdmin.Email); err != nil {
+		panic(fmt.Sprintf("SUPER_ADMIN_PASSWORD: %s", err))
+	}
+	err = userRepository.CreateSuperAdmin(
+		context.Background(),
+		cfg.SuperAdmin.Email,
+		cfg.SuperAdmin.Password,
+		passwordHasher,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	mailer, err := mail.NewMailer(cfg.Mail)
+	if err != nil {
+		panic(err)
+	}
 
 	// Initialize handlers
 	locationHandler := locHttp.NewLocationHandler(locationRepository)
-	userHandler := userHttp.NewUserHandler(userRepository)
-	adminHandler := adminHttp.NewAdminHandler(userRepository)
-	employeeHandler := empHttp.NewEmployeeHandler(userRepository)
-	reportHandler := repHttp.NewReportHandler(reportRepository)
+	userHandler := userHttp.NewUserHandler(
+		userRepository,
+		sessionRepository,
+		passwordResetRepository,
+		mailer,
+		twoFactorRepository,
+		throttle.NewMemoryThrottle(),
+		passwordPolicy,
+		passwordHasher,
+		cfg,
+	)
+	adminHandler := adminHttp.NewAdminHandler(userRepository, passwordPolicy, passwordHasher)
+	employeeHandler := empHttp.NewEmployeeHandler(userRepository, passwordPolicy, passwordHasher)
+	managerHandler := mgrHttp.NewManagerHandler(userRepository, passwordPolicy, passwordHasher)
+	rounding := repDomain.RoundingRule{
+		Increment: uint64(cfg.Report.RoundingIncrement),
+		Mode:      repDomain.RoundingMode(cfg.Report.RoundingMode),
+	}
+	ruleSet, err := complianceRules.RuleSetFromConfig(cfg.Compliance)
+	if err != nil {
+		panic(err)
+	}
+	// Without rules the checks are left out rather than run to find nothing
+	var reportCompliance repDomain.ComplianceChecker
+	var entryCompliance teDomain.ComplianceChecker
+	if ruleSet.Enabled() {
+		days := complianceQuery.CheckWorkDayHandler{Repo: workPeriodRepository, Rules: ruleSet}
+		reportCompliance = &complianceQuery.ReportChecker{Days: days}
+		entryCompliance = &complianceQuery.EntryChecker{Days: days}
+	}
+	overtime, err := overtimeSettings.SettingsFromConfig(cfg.Overtime)
+	if err != nil {
+		panic(err)
+	}
+	reportHandler := repHttp.NewReportHandler(
+		reportRepository,
+		rounding,
+		reportCompliance,
+		absenceRepository,
+		&overtimeQuery.ReportOvertime{Repo: overtimeRepository, Settings: overtime},
+	)
+	timeEntryHandler := teHttp.NewTimeEntryHandler(
+		timeEntryRepository,
+		&reportHandler.CreateReportHandler,
+		entryCompliance,
+		cfg.TimeEntry.AutoCloseAfter,
+	)
+	complianceHandler := complianceHttp.NewComplianceHandler(workPeriodRepository, ruleSet)
+	overtimeHandler := overtimeHttp.NewOvertimeHandler(overtimeRepository, overtime)
+	absenceHandler := absenceHttp.NewAbsenceHandler(absenceRepository)
+	healthHandler := health.NewHandler(db, migrator)
 
 	// Declare Server config
 	server := &http.Server{
-		Addr:         fmt.Sprintf(":%d", port),
-		Handler:      SetupRoutes(locationHandler, userHandler, adminHandler, employeeHandler, reportHandler),
+		Addr: fmt.Sprintf(":%d", cfg.Port),
+		Handler: SetupRoutes(
+			locationHandler,
+			userHandler,
+			adminHandler,
+			employeeHandler,
+			managerHandler,
+			reportHandler,
+			timeEntryHandler,
+			complianceHandler,
+			overtimeHandler,
+			absenceHandler,
+			healthHandler,
+			sessionRepository,
+			cfg,
+		),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
 
+	go closeForgottenEntries(ctx, timeEntryHandler.CloseForgottenEntriesHandler)
+
 	return server
 }
+
+// closeForgottenEntries closes entries left open for too long every sweepInterval, so
+// managers do not see people at work who went home hours ago
+func closeForgottenEntries(ctx context.Context, handler teCommand.CloseForgottenEntriesHandler) {
+	if handler.After <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			closed, err := handler.Handle(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "closing forgotten time entries", "error", err)
+				continue
+			}
+			if closed > 0 {
+				slog.InfoContext(ctx, "closed forgotten time entries", "count", closed)
+			}
+		}
+	}
+}
+
+// Run serves until ctx is cancelled, then stops accepting connections, waits up to the
+// shutdown timeout for in-flight requests and only then closes the database pool
+func Run(ctx context.Context, cfg *config.Config) error {
+	defer func() {
+		if err := CloseDB(); err != nil {
+			slog.Error("closing database", "error", err)
+		}
+	}()
+	server := NewServer(ctx, cfg)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "addr", server.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("cannot start server: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, waiting for in-flight requests", "timeout", cfg.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,459 @@
+// Package config loads the application settings from an optional YAML file, a .env
+// file and the environment, in increasing order of precedence.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// MinJwtSecretLength is the shortest accepted HMAC key, 32 bytes match the SHA-256 output size
+const MinJwtSecretLength = 32
+
+// ErrInvalidConfig wraps every configuration problem found at startup
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+type Config struct {
+	Port int `yaml:"port" env:"PORT"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish on shutdown
+	ShutdownTimeout  time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	Database         Database      `yaml:"database"`
+	Jwt              Jwt           `yaml:"jwt"`
+	SuperAdmin       SuperAdmin    `yaml:"super_admin"`
+	Mail             Mail          `yaml:"mail"`
+	Password         Password      `yaml:"password"`
+	PasswordResetUrl string        `yaml:"password_reset_url" env:"PASSWORD_RESET_URL"`
+	Log              Log           `yaml:"log"`
+	Report           Report        `yaml:"report"`
+	TimeEntry        TimeEntry     `yaml:"time_entry"`
+	Compliance       Compliance    `yaml:"compliance"`
+	Overtime         Overtime      `yaml:"overtime"`
+}
+
+type Database struct {
+	Host             string `yaml:"host" env:"DB_HOST"`
+	Port             int    `yaml:"port" env:"DB_PORT"`
+	Name             string `yaml:"name" env:"DB_DATABASE"`
+	Username         string `yaml:"username" env:"DB_USERNAME"`
+	Password         string `yaml:"password" env:"DB_PASSWORD"`
+	Schema           string `yaml:"schema" env:"DB_SCHEMA"`
+	SSLMode          string `yaml:"ssl_mode" env:"DB_SSLMODE"`
+	MigrateOnStartup bool   `yaml:"migrate_on_startup" env:"DB_MIGRATE_ON_STARTUP"`
+	// Pool settings, see sql.DB
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+}
+
+type Jwt struct {
+	Secret string `yaml:"secret" env:"JWT_SECRET"`
+}
+
+type SuperAdmin struct {
+	Email    string `yaml:"email" env:"SUPER_ADMIN_EMAIL"`
+	Password string `yaml:"password" env:"SUPER_ADMIN_PASSWORD"`
+}
+
+type Mail struct {
+	Driver string `yaml:"driver" env:"MAIL_DRIVER"`
+	Dir    string `yaml:"dir" env:"MAIL_DIR"`
+	From   string `yaml:"from" env:"MAIL_FROM"`
+	Smtp   Smtp   `yaml:"smtp"`
+}
+
+type Smtp struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" env:"SMTP_PORT"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+}
+
+type Log struct {
+	// Level is one of debug, info, warn or error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Format is json for log collectors or text for reading logs in a terminal
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// Report holds the rules of the organisation for reported durations
+type Report struct {
+	// RoundingIncrement rounds durations to a multiple of this many minutes, 1 keeps them as reported
+	RoundingIncrement int `yaml:"rounding_increment" env:"REPORT_ROUNDING_INCREMENT"`
+	// RoundingMode is nearest, up or down
+	RoundingMode string `yaml:"rounding_mode" env:"REPORT_ROUNDING_MODE"`
+}
+
+// TimeEntry holds the settings of clock-in and clock-out tracking
+type TimeEntry struct {
+	// AutoCloseAfter closes entries left open for longer, zero keeps them open until clock-out
+	AutoCloseAfter time.Duration `yaml:"auto_close_after" env:"TIME_ENTRY_AUTO_CLOSE_AFTER"`
+}
+
+// Compliance holds the working time rules reports and time entries are checked against
+type Compliance struct {
+	// RuleSet is the preset the settings below start from: none, eu or de
+	RuleSet string `yaml:"rule_set" env:"COMPLIANCE_RULE_SET"`
+	// The limits in minutes replace the ones of the preset when set
+	MaxDailyMinutes  int `yaml:"max_daily_minutes" env:"COMPLIANCE_MAX_DAILY_MINUTES"`
+	MaxWeeklyMinutes int `yaml:"max_weekly_minutes" env:"COMPLIANCE_MAX_WEEKLY_MINUTES"`
+	MinRestMinutes   int `yaml:"min_rest_minutes" env:"COMPLIANCE_MIN_REST_MINUTES"`
+	// Breaks replaces the break rules of the preset, as minutes worked:break minutes, e.g. 360:30,540:45
+	Breaks string `yaml:"breaks" env:"COMPLIANCE_BREAKS"`
+	// HardRules replaces the rules that reject reports, e.g. max_daily,break; none makes every rule a warning
+	HardRules string `yaml:"hard_rules" env:"COMPLIANCE_HARD_RULES"`
+}
+
+// Overtime holds the pay period, public holidays and the contract of employees without one
+type Overtime struct {
+	// PayPeriod is week or month
+	PayPeriod string `yaml:"pay_period" env:"OVERTIME_PAY_PERIOD"`
+	// WeeklyMinutes and WorkDays make up the default contract, work days as mon,tue,...
+	WeeklyMinutes int    `yaml:"weekly_minutes" env:"OVERTIME_WEEKLY_MINUTES"`
+	WorkDays      string `yaml:"work_days" env:"OVERTIME_WORK_DAYS"`
+	// Holidays are comma separated dates, e.g. 2024-12-25,2024-12-26
+	Holidays string `yaml:"holidays" env:"OVERTIME_HOLIDAYS"`
+}
+
+type Password struct {
+	Hasher        string `yaml:"hasher" env:"PASSWORD_HASHER"`
+	BcryptCost    int    `yaml:"bcrypt_cost" env:"BCRYPT_COST"`
+	Argon2Memory  int    `yaml:"argon2_memory" env:"ARGON2_MEMORY"`
+	Argon2Time    int    `yaml:"argon2_time" env:"ARGON2_TIME"`
+	Argon2Threads int    `yaml:"argon2_threads" env:"ARGON2_THREADS"`
+	MinLength     int    `yaml:"min_length" env:"PASSWORD_MIN_LENGTH"`
+	RequireSymbol bool   `yaml:"require_symbol" env:"PASSWORD_REQUIRE_SYMBOL"`
+}
+
+func Default() *Config {
+	return &Config{
+		Port:            8080,
+		ShutdownTimeout: 30 * time.Second,
+		Database: Database{
+			Port:            5432,
+			Schema:          "public",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnMaxLifetime: time.Hour,
+		},
+		Mail: Mail{
+			Driver: "file",
+			Dir:    "tmp/mail",
+		},
+		Password: Password{
+			Hasher:     "bcrypt",
+			BcryptCost: 12,
+			// 64 MiB with a single pass, as recommended by OWASP
+			Argon2Memory:  64 * 1024,
+			Argon2Time:    1,
+			Argon2Threads: 2,
+			MinLength:     10,
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "json",
+		},
+		Report: Report{
+			RoundingIncrement: 1,
+			RoundingMode:      "nearest",
+		},
+		TimeEntry: TimeEntry{
+			AutoCloseAfter: 12 * time.Hour,
+		},
+		Compliance: Compliance{
+			RuleSet: "none",
+		},
+		Overtime: Overtime{
+			PayPeriod:     "month",
+			WeeklyMinutes: 40 * 60,
+			WorkDays:      "mon,tue,wed,thu,fri",
+		},
+	}
+}
+
+// Load reads the configuration and fails on the first start with every missing or weak value
+func Load() (*Config, error) {
+	cfg, err := Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Read reads the configuration without validating it. The YAML file is taken from
+// CONFIG_FILE, values from .env only apply where the environment has none.
+func Read() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: .env: %v", ErrInvalidConfig, err)
+	}
+
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+		if err := yaml.Unmarshal(content, cfg); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides the fields tagged with env by the variables that are set
+func applyEnv(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := v.Type().Field(i).Tag.Get("env")
+		value, ok := os.LookupEnv(name)
+		if name == "" || !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s must be a duration such as 30s or 5m", ErrInvalidConfig, name)
+			}
+			field.SetInt(int64(duration))
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s must be a number", ErrInvalidConfig, name)
+			}
+			field.SetInt(int64(number))
+		case reflect.Bool:
+			flag, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s must be true or false", ErrInvalidConfig, name)
+			}
+			field.SetBool(flag)
+		}
+	}
+
+	return nil
+}
+
+// Validate reports every problem at once so that a deployment can be fixed in one go
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Port < 1 || c.Port > 65535 {
+		problems = append(problems, "PORT must be between 1 and 65535")
+	}
+	if c.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be positive")
+	}
+	problems = append(problems, c.Database.problems()...)
+
+	switch {
+	case c.Jwt.Secret == "":
+		problems = append(problems, "JWT_SECRET is required")
+	case len(c.Jwt.Secret) < MinJwtSecretLength:
+		problems = append(problems, fmt.Sprintf("JWT_SECRET must be at least %d bytes long", MinJwtSecretLength))
+	}
+
+	if _, err := mail.ParseAddress(c.SuperAdmin.Email); err != nil {
+		problems = append(problems, "SUPER_ADMIN_EMAIL must be a valid email")
+	}
+	if c.SuperAdmin.Password == "" {
+		problems = append(problems, "SUPER_ADMIN_PASSWORD is required")
+	}
+
+	switch c.Mail.Driver {
+	case "file", "memory":
+	case "smtp":
+		if c.Mail.Smtp.Host == "" || c.Mail.From == "" {
+			problems = append(problems, "SMTP_HOST and MAIL_FROM are required by the smtp mail driver")
+		}
+		if c.Mail.Smtp.Port < 1 || c.Mail.Smtp.Port > 65535 {
+			problems = append(problems, "SMTP_PORT must be between 1 and 65535")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("MAIL_DRIVER %q is unknown, use smtp, file or memory", c.Mail.Driver))
+	}
+
+	problems = append(problems, c.Password.problems()...)
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL %q is unknown, use debug, info, warn or error", c.Log.Level))
+	}
+	switch c.Log.Format {
+	case "json", "text":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_FORMAT %q is unknown, use json or text", c.Log.Format))
+	}
+
+	if c.Report.RoundingIncrement < 1 || c.Report.RoundingIncrement > 60 {
+		problems = append(problems, "REPORT_ROUNDING_INCREMENT must be between 1 and 60 minutes")
+	}
+	switch c.Report.RoundingMode {
+	case "nearest", "up", "down":
+	default:
+		problems = append(problems, fmt.Sprintf("REPORT_ROUNDING_MODE %q is unknown, use nearest, up or down", c.Report.RoundingMode))
+	}
+
+	if c.TimeEntry.AutoCloseAfter < 0 || c.TimeEntry.AutoCloseAfter > 24*time.Hour {
+		problems = append(problems, "TIME_ENTRY_AUTO_CLOSE_AFTER must be between 0 and 24h")
+	}
+	problems = append(problems, c.Compliance.problems()...)
+
+	switch c.Overtime.PayPeriod {
+	case "week", "month":
+	default:
+		problems = append(problems, fmt.Sprintf("OVERTIME_PAY_PERIOD %q is unknown, use week or month", c.Overtime.PayPeriod))
+	}
+	if c.Overtime.WeeklyMinutes < 0 || c.Overtime.WeeklyMinutes > 7*24*60 {
+		problems = append(problems, "OVERTIME_WEEKLY_MINUTES must be between 0 and 10080")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w:\n  - %s", ErrInvalidConfig, strings.Join(problems, "\n  - "))
+	}
+
+	return nil
+}
+
+// ValidateDatabase checks only the database settings, for tools that need nothing else
+func (c *Config) ValidateDatabase() error {
+	if problems := c.Database.problems(); len(problems) > 0 {
+		return fmt.Errorf("%w:\n  - %s", ErrInvalidConfig, strings.Join(problems, "\n  - "))
+	}
+
+	return nil
+}
+
+func (d Database) problems() []string {
+	var problems []string
+
+	if d.Host == "" {
+		problems = append(problems, "DB_HOST is required")
+	}
+	if d.Port < 1 || d.Port > 65535 {
+		problems = append(problems, "DB_PORT must be between 1 and 65535")
+	}
+	if d.Name == "" {
+		problems = append(problems, "DB_DATABASE is required")
+	}
+	if d.Username == "" {
+		problems = append(problems, "DB_USERNAME is required")
+	}
+	if d.MaxOpenConns < 1 {
+		problems = append(problems, "DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if d.MaxIdleConns < 0 || d.MaxIdleConns > d.MaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+	if d.ConnMaxIdleTime < 0 || d.ConnMaxLifetime < 0 {
+		problems = append(problems, "DB_CONN_MAX_IDLE_TIME and DB_CONN_MAX_LIFETIME must not be negative")
+	}
+
+	return problems
+}
+
+func (p Password) problems() []string {
+	var problems []string
+
+	switch p.Hasher {
+	case "bcrypt":
+		if p.BcryptCost < 10 || p.BcryptCost > 31 {
+			problems = append(problems, "BCRYPT_COST must be between 10 and 31")
+		}
+	case "argon2id":
+		if p.Argon2Memory < 19*1024 {
+			problems = append(problems, "ARGON2_MEMORY must be at least 19456 KiB")
+		}
+		if p.Argon2Time < 1 {
+			problems = append(problems, "ARGON2_TIME must be at least 1")
+		}
+		if p.Argon2Threads < 1 || p.Argon2Threads > 255 {
+			problems = append(problems, "ARGON2_THREADS must be between 1 and 255")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("PASSWORD_HASHER %q is unknown, use bcrypt or argon2id", p.Hasher))
+	}
+
+	if p.MinLength < 8 || p.MinLength > 72 {
+		problems = append(problems, "PASSWORD_MIN_LENGTH must be between 8 and 72")
+	}
+
+	return problems
+}
+
+func (c Compliance) problems() []string {
+	var problems []string
+
+	switch c.RuleSet {
+	case "none", "eu", "de":
+	default:
+		problems = append(problems, fmt.Sprintf("COMPLIANCE_RULE_SET %q is unknown, use none, eu or de", c.RuleSet))
+	}
+	if c.MaxDailyMinutes < 0 || c.MaxDailyMinutes > 24*60 {
+		problems = append(problems, "COMPLIANCE_MAX_DAILY_MINUTES must be between 0 and 1440")
+	}
+	if c.MaxWeeklyMinutes < 0 || c.MaxWeeklyMinutes > 7*24*60 {
+		problems = append(problems, "COMPLIANCE_MAX_WEEKLY_MINUTES must be between 0 and 10080")
+	}
+	if c.MinRestMinutes < 0 || c.MinRestMinutes > 24*60 {
+		problems = append(problems, "COMPLIANCE_MIN_REST_MINUTES must be between 0 and 1440")
+	}
+
+	return problems
+}
+
+// Dsn returns the connection string for the pgx driver
+func (d Database) Dsn() string {
+	query := url.Values{}
+	query.Set("sslmode", d.SSLMode)
+	query.Set("search_path", d.Schema)
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.Username, d.Password),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     "/" + d.Name,
+		RawQuery: query.Encode(),
+	}
+
+	return dsn.String()
+}
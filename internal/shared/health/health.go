@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+	"time-management/internal/migration"
+	"time-management/internal/shared/buildinfo"
+	"time-management/internal/shared/util"
+)
+
+// DefaultReadyTimeout bounds the checks of a readiness probe so that a hanging
+// database makes the probe fail instead of time out
+const DefaultReadyTimeout = 2 * time.Second
+
+const (
+	StatusOk   = "ok"
+	StatusFail = "fail"
+)
+
+type PendingMigrations interface {
+	Pending(ctx context.Context) ([]migration.Migration, error)
+}
+
+type Handler struct {
+	DB           *sql.DB
+	Migrations   PendingMigrations
+	ReadyTimeout time.Duration
+
+	// migrated is set once every migration was applied, the check is skipped from then on
+	migrated atomic.Bool
+}
+
+func NewHandler(db *sql.DB, migrations PendingMigrations) *Handler {
+	return &Handler{DB: db, Migrations: migrations, ReadyTimeout: DefaultReadyTimeout}
+}
+
+type readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Healthz reports that the process is up and serving requests
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) error {
+	return util.WriteJson(w, http.StatusOK, map[string]string{"status": StatusOk})
+}
+
+// Readyz reports whether the database is reachable and its schema is up to date
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) error {
+	ctx, cancel := context.WithTimeout(r.Context(), h.ReadyTimeout)
+	defer cancel()
+
+	result := readiness{
+		Status: StatusOk,
+		Checks: map[string]string{
+			"database":   h.checkDatabase(ctx),
+			"migrations": h.checkMigrations(ctx),
+		},
+	}
+
+	status := http.StatusOK
+	for _, check := range result.Checks {
+		if check != StatusOk {
+			result.Status = StatusFail
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	return util.WriteJson(w, status, result)
+}
+
+// Version returns the build information of the running binary
+func (h *Handler) Version(w http.ResponseWriter, r *http.Request) error {
+	return util.WriteJson(w, http.StatusOK, buildinfo.Get())
+}
+
+func (h *Handler) checkDatabase(ctx context.Context) string {
+	// Errors are only logged since the probe is served without authentication
+	if err := h.DB.PingContext(ctx); err != nil {
+		slog.WarnContext(ctx, "readiness check failed", "check", "database", "error", err)
+		return StatusFail
+	}
+	return StatusOk
+}
+
+func (h *Handler) checkMigrations(ctx context.Context) string {
+	if h.migrated.Load() {
+		return StatusOk
+	}
+
+	pending, err := h.Migrations.Pending(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "readiness check failed", "check", "migrations", "error", err)
+		return StatusFail
+	}
+	if len(pending) > 0 {
+		return fmt.Sprintf("%s: %d pending migration(s)", StatusFail, len(pending))
+	}
+
+	h.migrated.Store(true)
+	return StatusOk
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time-management/internal/migration"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type pendingMigrations struct {
+	pending []migration.Migration
+	calls   int
+}
+
+func (p *pendingMigrations) Pending(ctx context.Context) ([]migration.Migration, error) {
+	p.calls++
+	return p.pending, nil
+}
+
+func TestHandler_Readyz(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrations := &pendingMigrations{}
+	handler := NewHandler(db, migrations)
+	mock.ExpectPing()
+	mock.ExpectPing()
+
+	// Execute test
+	first := httptest.NewRecorder()
+	err = handler.Readyz(first, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.NoError(t, err)
+	second := httptest.NewRecorder()
+	err = handler.Readyz(second, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.NoError(t, err)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, 1, migrations.calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_Readyz_NotReady(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	handler := NewHandler(db, &pendingMigrations{pending: []migration.Migration{{Version: 9}}})
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	// Execute test
+	recorder := httptest.NewRecorder()
+	err = handler.Readyz(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	var body readiness
+	assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+	assert.Equal(t, StatusFail, body.Status)
+	assert.Equal(t, StatusFail, body.Checks["database"])
+	assert.Equal(t, "fail: 1 pending migration(s)", body.Checks["migrations"])
+}
@@ -0,0 +1,99 @@
+// Package logging sets up the slog logger and carries the request id and the
+// authenticated user of a request through its context onto every log line.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"time-management/internal/shared/config"
+)
+
+// Attribute keys shared by every log line of a request
+const (
+	KeyRequestId = "request_id"
+	KeyUserId    = "user_id"
+	KeyUserRole  = "user_role"
+)
+
+// New returns a logger writing to w in the configured format and level
+func New(cfg config.Log, w io.Writer) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// requestInfo is shared by pointer so that the user set by the auth middleware further
+// down the chain is also visible to the access log written by the outer middleware
+type requestInfo struct {
+	mu       sync.RWMutex
+	id       string
+	userId   string
+	userRole string
+}
+
+type contextKey struct{}
+
+// WithRequestId starts the log context of a request
+func WithRequestId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestInfo{id: id})
+}
+
+// RequestId returns the id of the request ctx belongs to, or an empty string outside of requests
+func RequestId(ctx context.Context) string {
+	info, ok := ctx.Value(contextKey{}).(*requestInfo)
+	if !ok {
+		return ""
+	}
+	return info.id
+}
+
+// SetUser attaches the authenticated user to the log lines of the request
+func SetUser(ctx context.Context, id, role string) {
+	info, ok := ctx.Value(contextKey{}).(*requestInfo)
+	if !ok {
+		return
+	}
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	info.userId = id
+	info.userRole = role
+}
+
+// contextHandler adds the request id and the user from the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		info.mu.RLock()
+		record.AddAttrs(slog.String(KeyRequestId, info.id))
+		if info.userId != "" {
+			record.AddAttrs(slog.String(KeyUserId, info.userId), slog.String(KeyUserRole, info.userRole))
+		}
+		info.mu.RUnlock()
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
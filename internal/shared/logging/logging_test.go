@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time-management/internal/shared/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_AddsRequestAndUser(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(config.Log{Level: "info", Format: "json"}, &out)
+	ctx := WithRequestId(context.Background(), "req-1")
+	SetUser(ctx, "user-1", "manager")
+
+	// Execute test
+	logger.InfoContext(ctx, "hello", "answer", 42)
+	logger.DebugContext(ctx, "below the level")
+
+	// Assertions
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "hello", line[slog.MessageKey])
+	assert.Equal(t, "req-1", line[KeyRequestId])
+	assert.Equal(t, "user-1", line[KeyUserId])
+	assert.Equal(t, "manager", line[KeyUserRole])
+	assert.Equal(t, float64(42), line["answer"])
+	assert.Equal(t, "req-1", RequestId(ctx))
+}
+
+func TestNew_OutsideOfRequests(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(config.Log{Level: "debug", Format: "json"}, &out)
+
+	// Execute test
+	logger.Debug("startup")
+
+	// Assertions
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.NotContains(t, line, KeyRequestId)
+	assert.NotContains(t, line, KeyUserId)
+	assert.Empty(t, RequestId(context.Background()))
+}
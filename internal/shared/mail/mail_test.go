@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMemoryMailer(t *testing.T) {
+	mailer := NewMemoryMailer()
+
+	// Execute test
+	err := mailer.Send(context.Background(), Message{To: "john@example.com", Subject: "Hello", Body: "Hi"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{{To: "john@example.com", Subject: "Hello", Body: "Hi"}}, mailer.Messages())
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(filepath.Join(dir, "mail"))
+
+	// Execute test
+	err := mailer.Send(context.Background(), Message{To: "john@example.com", Subject: "Hello", Body: "Hi"})
+
+	// Assertions
+	assert.NoError(t, err)
+	files, err := os.ReadDir(filepath.Join(dir, "mail"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, "mail", files[0].Name()))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "To: john@example.com\nSubject: Hello\n"))
+}
+
+func TestSmtpMailer_Format(t *testing.T) {
+	mailer := &SmtpMailer{From: "noreply@example.com"}
+
+	// Execute test
+	raw := string(mailer.format(Message{To: "john@example.com", Subject: "Hello", Body: "line 1\nline 2"}))
+
+	// Assertions
+	assert.Contains(t, raw, "From: noreply@example.com\r\n")
+	assert.Contains(t, raw, "Subject: Hello\r\n")
+	assert.True(t, strings.HasSuffix(raw, "\r\n\r\nline 1\r\nline 2"))
+}
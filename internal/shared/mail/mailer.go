@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time-management/internal/shared/config"
+)
+
+var (
+	ErrUnknownDriver  = errors.New("unknown mail driver")
+	ErrMissingSetting = errors.New("missing mail setting")
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends plain text emails
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewMailer builds the mailer selected by the driver: smtp, file or memory.
+// The file driver is the default so that local development needs no mail server.
+func NewMailer(cfg config.Mail) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		if cfg.Smtp.Host == "" || cfg.From == "" {
+			return nil, fmt.Errorf("%w: SMTP_HOST and MAIL_FROM are required", ErrMissingSetting)
+		}
+
+		return &SmtpMailer{
+			Host:     cfg.Smtp.Host,
+			Port:     cfg.Smtp.Port,
+			Username: cfg.Smtp.Username,
+			Password: cfg.Smtp.Password,
+			From:     cfg.From,
+		}, nil
+	case "", "file":
+		dir := cfg.Dir
+		if dir == "" {
+			dir = "tmp/mail"
+		}
+
+		return NewFileMailer(dir), nil
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, cfg.Driver)
+	}
+}
@@ -2,50 +2,79 @@ package middleware
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/golang-jwt/jwt/v5"
+	"log/slog"
 	"net/http"
-	"os"
 	"time"
+	"time-management/internal/shared/logging"
 	"time-management/internal/shared/util"
 	"time-management/internal/user/application/command"
 	"time-management/internal/user/domain"
 	userHttp "time-management/internal/user/interface/http"
 )
 
-var jwtSecretKey = []byte(os.Getenv("JWT_SECRET"))
+// AuthMiddleware accepts requests carrying a valid access token whose session has not
+// been revoked and puts the user into the request context
+func AuthMiddleware(sessions domain.SessionRepository, jwtSecret []byte) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(userHttp.CookieAuthName)
+			if err != nil {
+				_ = util.WriteError(w, r, util.NewUnauthorizedError(ErrNoValidToken))
+				return
+			}
 
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		cookie, err := r.Cookie(userHttp.CookieAuthName)
-		if err != nil {
-			_ = util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: ErrNoValidToken.Error()})
-			return
-		}
+			// Validate the token from the cookie
+			claims, err := validateToken(cookie.Value, jwtSecret)
+			if err != nil {
+				slog.DebugContext(r.Context(), "rejected access token", "error", err)
+				// Tell expired tokens apart so that clients know to refresh them
+				if errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, ErrTokenExpired) {
+					_ = util.WriteError(w, r, util.NewUnauthorizedError(ErrTokenExpired))
+					return
+				}
+				_ = util.WriteError(w, r, util.NewUnauthorizedError(ErrInvalidToken))
+				return
+			}
 
-		// Validate the token from the cookie
-		claims, err := validateToken(cookie.Value)
-		if err != nil {
-			_ = util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: ErrInvalidToken.Error()})
-			return
-		}
+			userId, okId := claims[command.JwtId].(string)
+			userRole, okRole := claims[command.JwtRole].(string)
+			sessionId, okSession := claims[command.JwtSessionId].(string)
+			if !okId || !okRole || !okSession {
+				_ = util.WriteError(w, r, util.NewUnauthorizedError(ErrInvalidToken))
+				return
+			}
 
-		// Add the extracted user to the context
-		user := &domain.User{
-			Id:   claims[command.JwtId].(string),
-			Role: claims[command.JwtRole].(string),
-		}
+			// Reject tokens of sessions that were logged out or revoked by an admin
+			active, err := sessions.IsActive(r.Context(), sessionId, uint64(time.Now().Unix()))
+			if err != nil {
+				_ = util.WriteError(w, r, err)
+				return
+			}
+			if !active {
+				_ = util.WriteError(w, r, util.NewUnauthorizedError(ErrSessionRevoked))
+				return
+			}
 
-		// Add user to the context
-		ctx := context.WithValue(r.Context(), "user", user)
-		r = r.WithContext(ctx)
+			// Add the extracted user to the context
+			user := &domain.User{
+				Id:   userId,
+				Role: userRole,
+			}
 
-		// Proceed to the next handler
-		next.ServeHTTP(w, r)
-	})
+			// Add user to the context and to the log lines of the request
+			logging.SetUser(r.Context(), user.Id, user.Role)
+			ctx := context.WithValue(r.Context(), "user", user)
+			r = r.WithContext(ctx)
+
+			// Proceed to the next handler
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-func validateToken(tokenString string) (jwt.MapClaims, error) {
+func validateToken(tokenString string, jwtSecret []byte) (jwt.MapClaims, error) {
 	// Parse the JWT token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Ensure token uses HMAC for signing
@@ -54,12 +83,11 @@ func validateToken(tokenString string) (jwt.MapClaims, error) {
 		}
 
 		// Return the secret key for validating the token's signature
-		return jwtSecretKey, nil
+		return jwtSecret, nil
 	})
 
 	// Check if there was an error in parsing the token
 	if err != nil {
-		fmt.Println("Token parsing error:", err)
 		return nil, err
 	}
 
@@ -68,7 +96,6 @@ func validateToken(tokenString string) (jwt.MapClaims, error) {
 		// Optionally, validate the claims such as expiration
 		err := validateClaims(claims)
 		if err != nil {
-			fmt.Println("Token claims validation error:", err)
 			return nil, err
 		}
 
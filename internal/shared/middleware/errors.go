@@ -7,4 +7,15 @@ var (
 	ErrInvalidToken            = errors.New("unauthorized: invalid token")
 	ErrUnExpectedSigningMethod = errors.New("unauthorized: unexpected signing method")
 	ErrTokenExpired            = errors.New("unauthorized: token expired")
+	ErrSessionRevoked          = errors.New("unauthorized: session revoked")
+	ErrForbidden               = errors.New("forbidden: your role does not allow this")
 )
+
+var ErrorCodes = map[error]string{
+	ErrNoValidToken:            "token_missing",
+	ErrInvalidToken:            "token_invalid",
+	ErrUnExpectedSigningMethod: "token_invalid",
+	ErrTokenExpired:            "token_expired",
+	ErrSessionRevoked:          "session_revoked",
+	ErrForbidden:               "role_forbidden",
+}
@@ -12,7 +12,7 @@ func RoleMiddleware(allowedRoles ...string) func(next http.Handler) http.Handler
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			user, ok := r.Context().Value("user").(*domain.User)
 			if !ok {
-				_ = util.WriteJson(w, http.StatusForbidden, util.ApiError{Error: "Forbidden"})
+				_ = util.WriteError(w, r, util.NewForbiddenError(ErrForbidden))
 				return
 			}
 
@@ -31,7 +31,7 @@ func RoleMiddleware(allowedRoles ...string) func(next http.Handler) http.Handler
 			}
 
 			// If no roles matched, return forbidden
-			_ = util.WriteJson(w, http.StatusForbidden, util.ApiError{Error: "Forbidden"})
+			_ = util.WriteError(w, r, util.NewForbiddenError(ErrForbidden))
 		})
 	}
 }
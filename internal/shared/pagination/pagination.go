@@ -0,0 +1,113 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time-management/internal/shared/util"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var (
+	ErrInvalidLimit  = errors.New("invalid limit: must be between 1 and 200")
+	ErrInvalidOffset = errors.New("invalid offset: must not be negative")
+	ErrInvalidSort   = errors.New("invalid sort field")
+)
+
+var ErrorCodes = map[error]string{
+	ErrInvalidLimit:  "limit_invalid",
+	ErrInvalidOffset: "offset_invalid",
+	ErrInvalidSort:   "sort_invalid",
+}
+
+// Request describes which slice of a list to return and in which order.
+// An empty Sort uses the repository default ordering.
+type Request struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+type Meta struct {
+	Total      int  `json:"total"`
+	Limit      int  `json:"limit"`
+	Offset     int  `json:"offset"`
+	NextOffset *int `json:"next_offset"`
+}
+
+type Page[T any] struct {
+	Data []T  `json:"data"`
+	Meta Meta `json:"meta"`
+}
+
+// FromRequest reads the limit, offset and sort query parameters.
+// Sort takes a field name, prefixed with "-" for descending order.
+func FromRequest(r *http.Request) (Request, error) {
+	values := r.URL.Query()
+	req := Request{Limit: DefaultLimit}
+
+	if limit := values.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 || parsed > MaxLimit {
+			return Request{}, util.NewValidationError(ErrInvalidLimit)
+		}
+		req.Limit = parsed
+	}
+
+	if offset := values.Get("offset"); offset != "" {
+		parsed, err := strconv.Atoi(offset)
+		if err != nil || parsed < 0 {
+			return Request{}, util.NewValidationError(ErrInvalidOffset)
+		}
+		req.Offset = parsed
+	}
+
+	if sort := values.Get("sort"); sort != "" {
+		req.Desc = strings.HasPrefix(sort, "-")
+		req.Sort = strings.TrimPrefix(sort, "-")
+	}
+
+	return req, nil
+}
+
+// OrderBy builds an ORDER BY clause for the requested sort field. columns maps the
+// sortable field names to SQL columns and fallback is used when no sort was requested.
+// tieBreaker is appended so that rows with equal sort values keep a stable order.
+func (r Request) OrderBy(columns map[string]string, fallback, tieBreaker string) (string, error) {
+	if r.Sort == "" {
+		return fmt.Sprintf("ORDER BY %s, %s", fallback, tieBreaker), nil
+	}
+
+	column, ok := columns[r.Sort]
+	if !ok {
+		return "", util.NewValidationError(fmt.Errorf("%w: %s", ErrInvalidSort, r.Sort))
+	}
+
+	direction := "ASC"
+	if r.Desc {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s, %s", column, direction, tieBreaker), nil
+}
+
+// NewPage wraps items with the pagination metadata for the request
+func NewPage[T any](items []T, total int, req Request) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	meta := Meta{Total: total, Limit: req.Limit, Offset: req.Offset}
+	if next := req.Offset + len(items); next < total {
+		meta.NextOffset = &next
+	}
+
+	return Page[T]{Data: items, Meta: meta}
+}
@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/reports?limit=10&offset=20&sort=-work_date", nil)
+
+	req, err := FromRequest(r)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, Request{Limit: 10, Offset: 20, Sort: "work_date", Desc: true}, req)
+}
+
+func TestFromRequest_Defaults(t *testing.T) {
+	r := httptest.NewRequest("GET", "/reports", nil)
+
+	req, err := FromRequest(r)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, Request{Limit: DefaultLimit}, req)
+}
+
+func TestFromRequest_InvalidLimit(t *testing.T) {
+	r := httptest.NewRequest("GET", "/reports?limit=1000", nil)
+
+	_, err := FromRequest(r)
+
+	// Assertions
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestNewPage(t *testing.T) {
+	page := NewPage([]int{1, 2}, 5, Request{Limit: 2, Offset: 2})
+
+	// Assertions
+	assert.Equal(t, []int{1, 2}, page.Data)
+	assert.Equal(t, 5, page.Meta.Total)
+	assert.Equal(t, 4, *page.Meta.NextOffset)
+}
+
+func TestNewPage_LastPage(t *testing.T) {
+	page := NewPage[int](nil, 4, Request{Limit: 2, Offset: 4})
+
+	// Assertions
+	assert.NotNil(t, page.Data)
+	assert.Empty(t, page.Data)
+	assert.Nil(t, page.Meta.NextOffset)
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// codes maps sentinel errors to the stable codes clients switch on. Messages may be
+// reworded at any time, codes must never change once released.
+var codes = struct {
+	sync.RWMutex
+	m map[error]string
+}{m: map[error]string{
+	ErrInternalServer: CodeInternal,
+	ErrInvalidBody:    CodeInvalidBody,
+}}
+
+// RegisterErrorCodes makes the codes of a package's sentinel errors known to the
+// problem responses
+func RegisterErrorCodes(errorCodes map[error]string) {
+	codes.Lock()
+	defer codes.Unlock()
+	for err, code := range errorCodes {
+		codes.m[err] = code
+	}
+}
+
+// ErrorCode returns the code of the outermost registered error in the chain of err,
+// or fallback if there is none
+func ErrorCode(err error, fallback string) string {
+	codes.RLock()
+	defer codes.RUnlock()
+	for err != nil {
+		// Errors of uncomparable types cannot be map keys, looking them up would panic
+		if reflect.TypeOf(err).Comparable() {
+			if code, ok := codes.m[err]; ok {
+				return code
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+
+	return fallback
+}
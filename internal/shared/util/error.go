@@ -2,18 +2,61 @@ package util
 
 import (
 	"errors"
-	"net/http"
+	"fmt"
+)
+
+// Generic codes of the error kinds, used when the wrapped error has no code of its own
+const (
+	CodeValidation   = "validation_failed"
+	CodeInvalidBody  = "invalid_body"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeForbidden    = "forbidden"
+	CodeUnauthorized = "unauthorized"
+	CodeInternal     = "internal_error"
+)
+
+var (
+	// ErrInternalServer is all a client learns about an unexpected error
+	ErrInternalServer = errors.New("there is an error, try again later")
+	ErrInvalidBody    = errors.New("invalid request body")
 )
 
 // ValidationError Custom error type for validation errors
 type ValidationError struct {
 	Err error
+	// Fields names the offending input fields, if known
+	Fields []FieldError
+}
+
+// FieldError describes why the value of a single input field was rejected. Code and
+// Message are filled from Err when the problem response is written.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Err     error  `json:"-"`
 }
 
 type NotFoundError struct {
 	Err error
 }
 
+// ConflictError is returned when a request clashes with the current state of a resource
+type ConflictError struct {
+	Err error
+}
+
+// ForbiddenError is returned when the user is known but not allowed to do something
+type ForbiddenError struct {
+	Err error
+}
+
+// UnauthorizedError is returned when the user could not be authenticated
+type UnauthorizedError struct {
+	Err error
+}
+
 func (e *ValidationError) Error() string {
 	return e.Err.Error()
 }
@@ -22,25 +65,65 @@ func (e *NotFoundError) Error() string {
 	return e.Err.Error()
 }
 
+func (e *ConflictError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return e.Err
+}
+
+func (e *UnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
 // NewValidationError Factory function for creating ValidationError
-func NewValidationError(err error) error {
-	return &ValidationError{Err: err}
+func NewValidationError(err error, fields ...FieldError) error {
+	return &ValidationError{Err: err, Fields: fields}
+}
+
+// NewFieldError rejects the value of a single input field
+func NewFieldError(field string, err error) error {
+	return NewValidationError(err, FieldError{Field: field, Err: err})
+}
+
+// InvalidBody rejects a request body that cannot be decoded
+func InvalidBody(err error) error {
+	return NewValidationError(fmt.Errorf("%w: %v", ErrInvalidBody, err))
 }
 
 func NewNotFoundError(err error) error {
 	return &NotFoundError{Err: err}
 }
 
-func HandleError(w http.ResponseWriter, err error, statusCode int) error {
-	var validationErr *ValidationError
-	var notFoundErr *NotFoundError
-	if errors.As(err, &validationErr) {
-		return WriteJson(w, statusCode, ApiError{Error: validationErr.Error()})
-	}
-	if errors.As(err, &notFoundErr) {
-		return WriteJson(w, statusCode, ApiError{Error: notFoundErr.Error()})
-	}
+func NewConflictError(err error) error {
+	return &ConflictError{Err: err}
+}
+
+func NewForbiddenError(err error) error {
+	return &ForbiddenError{Err: err}
+}
 
-	return WriteJson(w, http.StatusInternalServerError, ApiError{Error: err.Error()})
-	//return WriteJson(w, http.StatusInternalServerError, ApiError{Error: domain.ErrInternalServer.Error()})
+func NewUnauthorizedError(err error) error {
+	return &UnauthorizedError{Err: err}
 }
@@ -1,20 +1,42 @@
 package util
 
-import "net/http"
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"net/http"
+	"time"
+	"time-management/internal/shared/metrics"
+)
 
 type apiFunc func(http.ResponseWriter, *http.Request) error
 
-type ApiError struct {
-	Error string `json:"error"`
-}
-
+// HttpHandler adapts f to net/http and records the request count and latency under
+// the chi route pattern, so that ids in the path do not create new series. An error
+// returned by f is answered by WriteError unless f already wrote the response, then it
+// is only logged.
 func HttpHandler(f apiFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if err := f(w, r); err != nil {
-			err := WriteJson(w, http.StatusBadRequest, ApiError{err.Error()})
-			if err != nil {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		defer func() {
+			metrics.ObserveRequest(r.Method, routePattern(r), ww.Status(), time.Since(start))
+		}()
+
+		if err := f(ww, r); err != nil {
+			if ww.Status() != 0 {
+				slog.ErrorContext(r.Context(), "writing response", "error", err)
 				return
 			}
+			_ = WriteError(ww, r, err)
 		}
 	}
 }
+
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return ""
+	}
+	return rctx.RoutePattern()
+}
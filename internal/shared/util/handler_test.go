@@ -0,0 +1,121 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time-management/internal/shared/logging"
+	"time-management/internal/shared/metrics"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T) string {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestHttpHandler_RecordsRoutePatternAndStatus(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/things/{id}", HttpHandler(func(w http.ResponseWriter, r *http.Request) error {
+		if chi.URLParam(r, "id") == "missing" {
+			return errors.New("boom")
+		}
+		return WriteJson(w, http.StatusOK, map[string]string{"id": chi.URLParam(r, "id")})
+	}))
+
+	// Execute test
+	for _, path := range []string{"/things/1", "/things/2", "/things/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	body := scrape(t)
+
+	// Assertions
+	assert.Contains(t, body, `time_management_http_requests_total{method="GET",route="/things/{id}",status="200"} 2`)
+	assert.Contains(t, body, `time_management_http_requests_total{method="GET",route="/things/{id}",status="500"} 1`)
+	assert.Contains(t, body, `time_management_http_request_duration_seconds_count{method="GET",route="/things/{id}",status="200"} 2`)
+	assert.NotContains(t, body, `route="/things/1"`)
+}
+
+func TestWriteError_HidesInternalErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+	req = req.WithContext(logging.WithRequestId(req.Context(), "req-42"))
+	rec := httptest.NewRecorder()
+
+	// Execute test
+	err := WriteError(rec, req, errors.New(`pq: relation "reports" does not exist`))
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	var problem Problem
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, CodeInternal, problem.Code)
+	assert.Equal(t, ErrInternalServer.Error(), problem.Detail)
+	assert.Equal(t, "req-42", problem.CorrelationId)
+	assert.Equal(t, "/reports", problem.Instance)
+}
+
+func TestWriteError_MapsKinds(t *testing.T) {
+	errReportNotFound := errors.New("report not found")
+	errNameTooShort := errors.New("name is too short")
+	RegisterErrorCodes(map[error]string{errReportNotFound: "report_not_found", errNameTooShort: "name_too_short"})
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"validation", NewValidationError(errors.New("bad input")), http.StatusBadRequest, CodeValidation},
+		{"invalid body", InvalidBody(errors.New("unexpected EOF")), http.StatusBadRequest, CodeInvalidBody},
+		{"not found with code", NewNotFoundError(errReportNotFound), http.StatusNotFound, "report_not_found"},
+		{"wrapped code", NewNotFoundError(fmt.Errorf("%w: 42", errReportNotFound)), http.StatusNotFound, "report_not_found"},
+		{"conflict", NewConflictError(errors.New("taken")), http.StatusConflict, CodeConflict},
+		{"forbidden", NewForbiddenError(errors.New("no")), http.StatusForbidden, CodeForbidden},
+		{"unauthorized", NewUnauthorizedError(errors.New("who")), http.StatusUnauthorized, CodeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			// Execute test
+			err := WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			// Assertions
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, rec.Code)
+			var problem Problem
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, http.StatusText(tt.status), problem.Title)
+			assert.Equal(t, tt.err.Error(), problem.Detail)
+			assert.Empty(t, problem.CorrelationId)
+		})
+	}
+
+	t.Run("field errors", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		// Execute test
+		err := WriteError(rec, httptest.NewRequest(http.MethodPost, "/", nil), NewFieldError("name", errNameTooShort))
+
+		// Assertions
+		assert.NoError(t, err)
+		var problem Problem
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+		assert.Equal(t, "name_too_short", problem.Code)
+		assert.Equal(t, []FieldError{{Field: "name", Code: "name_too_short", Message: "name is too short"}}, problem.Errors)
+	})
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time-management/internal/shared/logging"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response. Code is the extension member clients
+// switch on, Title and Detail are meant for humans and may change.
+type Problem struct {
+	Type          string       `json:"type"`
+	Title         string       `json:"title"`
+	Status        int          `json:"status"`
+	Detail        string       `json:"detail,omitempty"`
+	Instance      string       `json:"instance,omitempty"`
+	Code          string       `json:"code"`
+	CorrelationId string       `json:"correlation_id,omitempty"`
+	Errors        []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem describes a problem without further documentation, so the type is about:blank
+// and the title the status text as recommended by the RFC
+func NewProblem(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// WriteProblem answers with p, pointing its instance at the requested path
+func WriteProblem(w http.ResponseWriter, r *http.Request, p *Problem) error {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// WriteError maps err to its problem response. Errors without a kind are internal: they
+// are logged and the client only gets the request id to correlate its report with the
+// log line, never the error text, which may reveal queries or other internals.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	return WriteProblem(w, r, problemFor(r, err))
+}
+
+// problemFor maps err to the problem describing it
+func problemFor(r *http.Request, err error) *Problem {
+	var validationErr *ValidationError
+	var notFoundErr *NotFoundError
+	var conflictErr *ConflictError
+	var forbiddenErr *ForbiddenError
+	var unauthorizedErr *UnauthorizedError
+
+	switch {
+	case errors.As(err, &conflictErr):
+		return NewProblem(http.StatusConflict, ErrorCode(err, CodeConflict), err.Error())
+	case errors.As(err, &validationErr):
+		p := NewProblem(http.StatusBadRequest, ErrorCode(err, CodeValidation), err.Error())
+		for _, field := range validationErr.Fields {
+			if field.Err != nil {
+				field.Code = ErrorCode(field.Err, CodeValidation)
+				field.Message = field.Err.Error()
+			}
+			p.Errors = append(p.Errors, field)
+		}
+		return p
+	case errors.As(err, &notFoundErr):
+		return NewProblem(http.StatusNotFound, ErrorCode(err, CodeNotFound), err.Error())
+	case errors.As(err, &forbiddenErr):
+		return NewProblem(http.StatusForbidden, ErrorCode(err, CodeForbidden), err.Error())
+	case errors.As(err, &unauthorizedErr):
+		return NewProblem(http.StatusUnauthorized, ErrorCode(err, CodeUnauthorized), err.Error())
+	}
+
+	slog.ErrorContext(r.Context(), "internal error", "error", err)
+	p := NewProblem(http.StatusInternalServerError, CodeInternal, ErrInternalServer.Error())
+	p.CorrelationId = logging.RequestId(r.Context())
+	return p
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+type ClockInCommand struct {
+	UserId     string
+	LocationId string
+}
+
+type ClockInHandler struct {
+	Repo domain.TimeEntryRepository
+	// Forgotten closes entries left open for too long, so they do not block clocking in
+	Forgotten CloseForgottenEntriesHandler
+}
+
+func (h *ClockInHandler) Handle(ctx context.Context, cmd ClockInCommand) (*domain.TimeEntry, error) {
+	if cmd.LocationId == "" || len(cmd.LocationId) >= 50 {
+		return nil, util.NewFieldError("location_id", domain.ErrWrongLocationId)
+	}
+
+	active, err := h.Repo.IsEmployeeActive(ctx, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, util.NewValidationError(domain.ErrEmployeeInactive)
+	}
+
+	if _, err := h.Forgotten.Handle(ctx); err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewTimeEntry(uuid.New().String(), cmd.UserId, cmd.LocationId, uint64(time.Now().Unix()))
+
+	return h.Repo.ClockIn(ctx, entry)
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+type ClockOutCommand struct {
+	UserId string
+}
+
+type ClockOutHandler struct {
+	Repo      domain.TimeEntryRepository
+	Forgotten CloseForgottenEntriesHandler
+	// Compliance checks the working time rules after clocking out, nil skips them
+	Compliance domain.ComplianceChecker
+}
+
+// Handle closes the open entry of the user. An entry that was left open for too long is
+// closed at the limit first, clocking out then fails with ErrNotClockedIn.
+func (h *ClockOutHandler) Handle(ctx context.Context, cmd ClockOutCommand) (*domain.TimeEntry, error) {
+	entry, err := openEntry(ctx, h.Repo, h.Forgotten, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.ClockOut(uint64(time.Now().Unix())); err != nil {
+		return nil, util.NewConflictError(err)
+	}
+
+	closed, err := h.Repo.ClockOut(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// The entry is closed already, failing the check must not fail the clock-out
+	if h.Compliance != nil {
+		warnings, err := h.Compliance.CheckEntry(ctx, closed)
+		if err != nil {
+			slog.ErrorContext(ctx, "checking working time rules", "error", err, "entry_id", closed.Id)
+		}
+		closed.ComplianceWarnings = warnings
+	}
+
+	return closed, nil
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/timeentry/domain"
+)
+
+type CloseForgottenEntriesHandler struct {
+	Repo domain.TimeEntryRepository
+	// After is how long an entry may stay open, zero keeps entries open until clock-out
+	After time.Duration
+}
+
+// Handle closes the entries that stayed open for longer than h.After. They are closed at
+// the limit, not now, and flagged so managers can correct them.
+func (h *CloseForgottenEntriesHandler) Handle(ctx context.Context) (int64, error) {
+	if h.After <= 0 {
+		return 0, nil
+	}
+
+	maxSeconds := uint64(h.After.Seconds())
+	openedBefore := uint64(time.Now().Unix()) - maxSeconds
+
+	return h.Repo.CloseForgotten(ctx, openedBefore, maxSeconds)
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"time"
+	reportCommand "time-management/internal/report/application/command"
+	reportDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+type ConvertEntriesCommand struct {
+	UserId   string
+	WorkDate string
+	// LocationId picks the entries to convert when the user worked at several locations
+	LocationId string
+	// MaintenanceMinutes is the part of the tracked time spent on maintenance
+	MaintenanceMinutes uint64
+}
+
+// ReportCreator submits the report the entries are converted into
+type ReportCreator interface {
+	Handle(ctx context.Context, cmd reportCommand.CreateReportCommand) (*reportDomain.Report, error)
+}
+
+type ConvertEntriesHandler struct {
+	Repo    domain.TimeEntryRepository
+	Reports ReportCreator
+}
+
+// Handle submits the closed entries of a work date as a pending report. The tracked time
+// without breaks is split into working and maintenance minutes, and the first clock-in
+// and last clock-out become the shift when both fall on the work date.
+func (h *ConvertEntriesHandler) Handle(ctx context.Context, cmd ConvertEntriesCommand) (*reportDomain.Report, error) {
+	from, to, err := domain.DayBounds(cmd.WorkDate)
+	if err != nil {
+		return nil, util.NewFieldError("work_date", domain.ErrInvalidWorkDate)
+	}
+
+	entries, err := h.Repo.GetUnconverted(ctx, cmd.UserId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	locationId := cmd.LocationId
+	var selected []domain.TimeEntry
+	for _, entry := range entries {
+		if locationId == "" {
+			locationId = entry.Location.Id
+		}
+		if entry.Location.Id != locationId {
+			if cmd.LocationId == "" {
+				return nil, util.NewFieldError("location_id", domain.ErrAmbiguousLocation)
+			}
+			continue
+		}
+		if entry.IsOpen() {
+			return nil, util.NewConflictError(domain.ErrEntryStillOpen)
+		}
+		selected = append(selected, entry)
+	}
+	if len(selected) == 0 {
+		return nil, util.NewValidationError(domain.ErrNoEntriesToConvert)
+	}
+
+	var workedSeconds uint64
+	ids := make([]string, 0, len(selected))
+	start, end := selected[0].ClockedInAt, *selected[0].ClockedOutAt
+	for _, entry := range selected {
+		workedSeconds += entry.WorkedSeconds(time.Now())
+		ids = append(ids, entry.Id)
+		start = min(start, entry.ClockedInAt)
+		end = max(end, *entry.ClockedOutAt)
+	}
+
+	worked := workedSeconds / 60
+	if cmd.MaintenanceMinutes == 0 || cmd.MaintenanceMinutes > worked {
+		return nil, util.NewFieldError("maintenance_minutes", domain.ErrInvalidMaintenance)
+	}
+
+	reportCmd := reportCommand.CreateReportCommand{
+		EmployeeId:         cmd.UserId,
+		LocationId:         locationId,
+		WorkingMinutes:     worked - cmd.MaintenanceMinutes,
+		MaintenanceMinutes: cmd.MaintenanceMinutes,
+		WorkDate:           cmd.WorkDate,
+		// The entries are claimed with the report, a second conversion of them stores nothing
+		EntryIds: ids,
+	}
+	// A shift past midnight cannot be expressed as clock times of the work date
+	if end < to {
+		reportCmd.StartTime = time.Unix(int64(start), 0).Format(reportDomain.ClockLayout)
+		reportCmd.EndTime = time.Unix(int64(end), 0).Format(reportDomain.ClockLayout)
+	}
+
+	return h.Reports.Handle(ctx, reportCmd)
+}
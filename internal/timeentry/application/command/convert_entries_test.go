@@ -0,0 +1,117 @@
+package command_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	reportCommand "time-management/internal/report/application/command"
+	reportDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/application/command"
+	"time-management/internal/timeentry/domain"
+)
+
+// fakeTimeEntryRepository returns the given entries as unconverted, the methods it does not
+// override are not expected to be called
+type fakeTimeEntryRepository struct {
+	domain.TimeEntryRepository
+	entries []domain.TimeEntry
+}
+
+func (r *fakeTimeEntryRepository) GetUnconverted(
+	ctx context.Context,
+	userId string,
+	from, to uint64,
+) ([]domain.TimeEntry, error) {
+	return r.entries, nil
+}
+
+// fakeReportRepository stores created reports in memory along with the entries they claim
+type fakeReportRepository struct {
+	reportDomain.ReportRepository
+	created  *reportDomain.Report
+	entryIds []string
+}
+
+func (r *fakeReportRepository) IsEmployeeActive(ctx context.Context, userId string) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeReportRepository) CreateFromEntries(
+	ctx context.Context,
+	report *reportDomain.Report,
+	entryIds []string,
+) (*reportDomain.Report, error) {
+	r.created = report
+	r.entryIds = entryIds
+	return report, nil
+}
+
+// fakeAbsences takes the listed dates off
+type fakeAbsences map[string]bool
+
+func (a fakeAbsences) HasFullDayAbsence(
+	ctx context.Context,
+	userId string,
+	workDate reportDomain.Date,
+) (bool, error) {
+	return a[workDate.String()], nil
+}
+
+// closedEntry is an entry at loc1 from hours to hours+duration after the start of the day
+func closedEntry(t *testing.T, id string, date string, hours, duration uint64) domain.TimeEntry {
+	from, _, err := domain.DayBounds(date)
+	assert.NoError(t, err)
+
+	entry := domain.NewTimeEntry(id, "usr1", "loc1", from+hours*3600)
+	clockedOutAt := entry.ClockedInAt + duration*3600
+	entry.ClockedOutAt = &clockedOutAt
+	return *entry
+}
+
+func TestConvertEntriesHandler_Handle(t *testing.T) {
+	reports := &fakeReportRepository{}
+	handler := command.ConvertEntriesHandler{
+		Repo: &fakeTimeEntryRepository{entries: []domain.TimeEntry{
+			closedEntry(t, "te1", "2024-03-05", 7, 4),
+			closedEntry(t, "te2", "2024-03-05", 12, 4),
+		}},
+		Reports: &reportCommand.CreateReportHandler{Repo: reports, Absences: fakeAbsences{}},
+	}
+
+	// Execute test
+	report, err := handler.Handle(context.Background(), command.ConvertEntriesCommand{
+		UserId:             "usr1",
+		WorkDate:           "2024-03-05",
+		MaintenanceMinutes: 30,
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(450), report.WorkingMinutes)
+	assert.Equal(t, []string{"te1", "te2"}, reports.entryIds)
+}
+
+func TestConvertEntriesHandler_Handle_OnLeave(t *testing.T) {
+	reports := &fakeReportRepository{}
+	handler := command.ConvertEntriesHandler{
+		Repo: &fakeTimeEntryRepository{entries: []domain.TimeEntry{
+			closedEntry(t, "te1", "2024-03-05", 7, 8),
+		}},
+		Reports: &reportCommand.CreateReportHandler{Repo: reports, Absences: fakeAbsences{"2024-03-05": true}},
+	}
+
+	// Execute test
+	report, err := handler.Handle(context.Background(), command.ConvertEntriesCommand{
+		UserId:             "usr1",
+		WorkDate:           "2024-03-05",
+		MaintenanceMinutes: 30,
+	})
+
+	// Assertions
+	assert.ErrorIs(t, err, reportDomain.ErrAbsentOnWorkDate)
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Nil(t, report)
+	assert.Nil(t, reports.entryIds)
+}
@@ -0,0 +1,35 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+type EndBreakCommand struct {
+	UserId string
+}
+
+type EndBreakHandler struct {
+	Repo      domain.TimeEntryRepository
+	Forgotten CloseForgottenEntriesHandler
+}
+
+func (h *EndBreakHandler) Handle(ctx context.Context, cmd EndBreakCommand) (*domain.TimeEntry, error) {
+	entry, err := openEntry(ctx, h.Repo, h.Forgotten, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := entry.EndBreak(uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, util.NewConflictError(err)
+	}
+
+	if err := h.Repo.EndBreak(ctx, entry.Id, b); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+// openEntry closes forgotten entries and returns the open entry of the user. Not being
+// clocked in conflicts with the commands that need an open entry.
+func openEntry(
+	ctx context.Context,
+	repo domain.TimeEntryRepository,
+	forgotten CloseForgottenEntriesHandler,
+	userId string,
+) (*domain.TimeEntry, error) {
+	if _, err := forgotten.Handle(ctx); err != nil {
+		return nil, err
+	}
+
+	entry, err := repo.GetOpen(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotClockedIn) {
+			return nil, util.NewConflictError(domain.ErrNotClockedIn)
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+type StartBreakCommand struct {
+	UserId string
+}
+
+type StartBreakHandler struct {
+	Repo      domain.TimeEntryRepository
+	Forgotten CloseForgottenEntriesHandler
+}
+
+func (h *StartBreakHandler) Handle(ctx context.Context, cmd StartBreakCommand) (*domain.TimeEntry, error) {
+	entry, err := openEntry(ctx, h.Repo, h.Forgotten, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := entry.StartBreak(uuid.New().String(), uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, util.NewConflictError(err)
+	}
+
+	if err := h.Repo.StartBreak(ctx, entry.Id, b); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,30 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/timeentry/domain"
+)
+
+type GetEntriesQuery struct {
+	Filter domain.TimeEntryFilter
+	Page   pagination.Request
+}
+
+type GetEntriesHandler struct {
+	Repo domain.TimeEntryRepository
+}
+
+func (h *GetEntriesHandler) Handle(
+	ctx context.Context,
+	query GetEntriesQuery,
+) (*pagination.Page[domain.TimeEntry], error) {
+	entries, total, err := h.Repo.GetAll(ctx, query.Filter, query.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.NewPage(entries, total, query.Page)
+
+	return &page, nil
+}
@@ -0,0 +1,20 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/timeentry/domain"
+)
+
+// GetEntryQuery looks up an entry, restricted to the entries of UserId unless it is empty
+type GetEntryQuery struct {
+	Id     string
+	UserId string
+}
+
+type GetEntryHandler struct {
+	Repo domain.TimeEntryRepository
+}
+
+func (h *GetEntryHandler) Handle(ctx context.Context, query GetEntryQuery) (*domain.TimeEntry, error) {
+	return h.Repo.GetById(ctx, query.Id, query.UserId)
+}
@@ -0,0 +1,28 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+type GetOpenEntryQuery struct {
+	UserId string
+}
+
+type GetOpenEntryHandler struct {
+	Repo domain.TimeEntryRepository
+}
+
+func (h *GetOpenEntryHandler) Handle(ctx context.Context, query GetOpenEntryQuery) (*domain.TimeEntry, error) {
+	entry, err := h.Repo.GetOpen(ctx, query.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotClockedIn) {
+			return nil, util.NewNotFoundError(domain.ErrNotClockedIn)
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// ComplianceWarning is a working time rule broken by the time a user clocked
+type ComplianceWarning struct {
+	Rule    string `json:"rule"`
+	Period  string `json:"period"`
+	Message string `json:"message"`
+}
+
+// ComplianceChecker evaluates the working time rules of the organisation. A clock-out
+// cannot be undone, so every violation is returned as a warning.
+type ComplianceChecker interface {
+	CheckEntry(ctx context.Context, entry *TimeEntry) ([]ComplianceWarning, error)
+}
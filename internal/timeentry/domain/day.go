@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// DateLayout is the format of work dates in requests
+const DateLayout = "2006-01-02"
+
+// DayBounds returns the unix times at which the given date and the following one start.
+// Days follow the time zone of the server, as report work dates do.
+func DayBounds(date string) (uint64, uint64, error) {
+	day, err := time.ParseInLocation(DateLayout, date, time.Local)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint64(day.Unix()), uint64(day.AddDate(0, 0, 1).Unix()), nil
+}
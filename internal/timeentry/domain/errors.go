@@ -0,0 +1,38 @@
+package domain
+
+import "errors"
+
+var (
+	ErrTimeEntryNotFound  = errors.New("time entry not found")
+	ErrWrongEmployeeId    = errors.New("wrong employee id: employee does not exist")
+	ErrEmployeeInactive   = errors.New("employee is deactivated and cannot clock in")
+	ErrWrongLocationId    = errors.New("wrong location id: location does not exist")
+	ErrAlreadyClockedIn   = errors.New("already clocked in: clock out of the open entry first")
+	ErrNotClockedIn       = errors.New("not clocked in")
+	ErrAlreadyOnBreak     = errors.New("already on a break")
+	ErrNotOnBreak         = errors.New("not on a break")
+	ErrInvalidWorkDate    = errors.New("invalid work date: expected format YYYY-MM-DD")
+	ErrInvalidDateRange   = errors.New("invalid date range: from must not be after to")
+	ErrNoEntriesToConvert = errors.New("no closed and unconverted time entries on the work date")
+	ErrEntryStillOpen     = errors.New("an entry on the work date is still open: clock out first")
+	ErrAmbiguousLocation  = errors.New("entries at several locations on the work date: location id required")
+	ErrInvalidMaintenance = errors.New("invalid maintenance: must be positive and within the tracked time")
+)
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrTimeEntryNotFound:  "time_entry_not_found",
+	ErrWrongEmployeeId:    "employee_id_invalid",
+	ErrEmployeeInactive:   "employee_inactive",
+	ErrWrongLocationId:    "location_id_invalid",
+	ErrAlreadyClockedIn:   "already_clocked_in",
+	ErrNotClockedIn:       "not_clocked_in",
+	ErrAlreadyOnBreak:     "already_on_break",
+	ErrNotOnBreak:         "not_on_break",
+	ErrInvalidWorkDate:    "work_date_invalid",
+	ErrInvalidDateRange:   "date_range_invalid",
+	ErrNoEntriesToConvert: "no_time_entries",
+	ErrEntryStillOpen:     "time_entry_open",
+	ErrAmbiguousLocation:  "location_ambiguous",
+	ErrInvalidMaintenance: "maintenance_hours_invalid",
+}
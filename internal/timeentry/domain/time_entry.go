@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TimeEntry is the time a user spent at a location between clocking in and out. Open
+// entries have no clock-out time yet, a user has at most one of them.
+type TimeEntry struct {
+	Id           string   `json:"id"`
+	User         User     `json:"user"`
+	Location     Location `json:"location"`
+	ClockedInAt  uint64   `json:"clocked_in_at"`
+	ClockedOutAt *uint64  `json:"clocked_out_at,omitempty"`
+	Breaks       []Break  `json:"breaks"`
+	// AutoClosed is set when the entry was closed because the user forgot to clock out
+	AutoClosed bool `json:"auto_closed"`
+	// ReportId is the report the entry was converted into
+	ReportId *string `json:"report_id,omitempty"`
+	// ComplianceWarnings are filled when the user clocks out, they are not stored
+	ComplianceWarnings []ComplianceWarning `json:"compliance_warnings,omitempty"`
+}
+
+// Break pauses the timer of an entry, open breaks have no end time yet
+type Break struct {
+	Id        string  `json:"id"`
+	StartedAt uint64  `json:"started_at"`
+	EndedAt   *uint64 `json:"ended_at,omitempty"`
+}
+
+type User struct {
+	Id        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+type Location struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// NewTimeEntry Factory method to create an open TimeEntry
+func NewTimeEntry(id, userId, locationId string, clockedInAt uint64) *TimeEntry {
+	return &TimeEntry{
+		Id:          id,
+		User:        User{Id: userId},
+		Location:    Location{Id: locationId},
+		ClockedInAt: clockedInAt,
+		Breaks:      []Break{},
+	}
+}
+
+func (e *TimeEntry) IsOpen() bool {
+	return e.ClockedOutAt == nil
+}
+
+// OpenBreak returns the break the user is currently on, if any
+func (e *TimeEntry) OpenBreak() *Break {
+	for i := range e.Breaks {
+		if e.Breaks[i].EndedAt == nil {
+			return &e.Breaks[i]
+		}
+	}
+
+	return nil
+}
+
+// ClockOut closes the entry at the given time, ending a break that is still running
+func (e *TimeEntry) ClockOut(at uint64) error {
+	if !e.IsOpen() {
+		return ErrNotClockedIn
+	}
+	if at < e.ClockedInAt {
+		at = e.ClockedInAt
+	}
+
+	if open := e.OpenBreak(); open != nil {
+		end := max(at, open.StartedAt)
+		open.EndedAt = &end
+	}
+	e.ClockedOutAt = &at
+
+	return nil
+}
+
+// StartBreak pauses the open entry
+func (e *TimeEntry) StartBreak(id string, at uint64) (*Break, error) {
+	if !e.IsOpen() {
+		return nil, ErrNotClockedIn
+	}
+	if e.OpenBreak() != nil {
+		return nil, ErrAlreadyOnBreak
+	}
+
+	e.Breaks = append(e.Breaks, Break{Id: id, StartedAt: max(at, e.ClockedInAt)})
+
+	return &e.Breaks[len(e.Breaks)-1], nil
+}
+
+// EndBreak resumes the open entry
+func (e *TimeEntry) EndBreak(at uint64) (*Break, error) {
+	if !e.IsOpen() {
+		return nil, ErrNotClockedIn
+	}
+	open := e.OpenBreak()
+	if open == nil {
+		return nil, ErrNotOnBreak
+	}
+
+	end := max(at, open.StartedAt)
+	open.EndedAt = &end
+
+	return open, nil
+}
+
+// end is the clock-out time, or now while the entry is open
+func (e *TimeEntry) end(now time.Time) uint64 {
+	if e.ClockedOutAt != nil {
+		return *e.ClockedOutAt
+	}
+
+	return max(uint64(now.Unix()), e.ClockedInAt)
+}
+
+// BreakSeconds is the time spent on breaks within the entry. Open breaks count until now.
+func (e *TimeEntry) BreakSeconds(now time.Time) uint64 {
+	end := e.end(now)
+
+	var seconds uint64
+	for _, b := range e.Breaks {
+		start := max(b.StartedAt, e.ClockedInAt)
+		stop := end
+		if b.EndedAt != nil {
+			stop = min(*b.EndedAt, end)
+		}
+		if stop > start {
+			seconds += stop - start
+		}
+	}
+
+	return seconds
+}
+
+// WorkedSeconds is the time between clocking in and out without breaks. Open entries
+// count until now, which makes it the live timer.
+func (e *TimeEntry) WorkedSeconds(now time.Time) uint64 {
+	elapsed := e.end(now) - e.ClockedInAt
+	breaks := e.BreakSeconds(now)
+	if breaks > elapsed {
+		return 0
+	}
+
+	return elapsed - breaks
+}
+
+// MarshalJSON adds the timers, open entries are measured until the time of the response
+func (e TimeEntry) MarshalJSON() ([]byte, error) {
+	type timeEntry TimeEntry
+	now := time.Now()
+	return json.Marshal(struct {
+		timeEntry
+		Open          bool   `json:"open"`
+		OnBreak       bool   `json:"on_break"`
+		WorkedMinutes uint64 `json:"worked_minutes"`
+		BreakMinutes  uint64 `json:"break_minutes"`
+	}{
+		timeEntry:     timeEntry(e),
+		Open:          e.IsOpen(),
+		OnBreak:       e.IsOpen() && e.OpenBreak() != nil,
+		WorkedMinutes: e.WorkedSeconds(now) / 60,
+		BreakMinutes:  e.BreakSeconds(now) / 60,
+	})
+}
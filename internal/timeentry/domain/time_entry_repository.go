@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+)
+
+// TimeEntryFilter narrows entry lists; zero values are ignored. From and To bound the
+// clock-in time in unix seconds, To is exclusive.
+type TimeEntryFilter struct {
+	UserId     string
+	LocationId string
+	OpenOnly   bool
+	From       *uint64
+	To         *uint64
+}
+
+type TimeEntryRepository interface {
+	// ClockIn stores a new open entry, failing with ErrAlreadyClockedIn when the user has one
+	ClockIn(ctx context.Context, entry *TimeEntry) (*TimeEntry, error)
+	// GetOpen returns the open entry of the user or ErrNotClockedIn
+	GetOpen(ctx context.Context, userId string) (*TimeEntry, error)
+	GetAll(ctx context.Context, filter TimeEntryFilter, page pagination.Request) ([]TimeEntry, int, error)
+	GetById(ctx context.Context, id, userId string) (*TimeEntry, error)
+	// GetUnconverted returns the entries of the user clocked in within [from, to) that were
+	// not converted into a report yet, open ones included
+	GetUnconverted(ctx context.Context, userId string, from, to uint64) ([]TimeEntry, error)
+	IsEmployeeActive(ctx context.Context, userId string) (bool, error)
+	// ClockOut closes the open entry and its open break
+	ClockOut(ctx context.Context, entry *TimeEntry) (*TimeEntry, error)
+	StartBreak(ctx context.Context, entryId string, b *Break) error
+	EndBreak(ctx context.Context, entryId string, b *Break) error
+	// CloseForgotten closes the entries opened at or before openedBefore, maxSeconds after
+	// they were opened, and returns how many were closed
+	CloseForgotten(ctx context.Context, openedBefore, maxSeconds uint64) (int64, error)
+}
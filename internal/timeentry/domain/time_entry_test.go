@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntry_WorkedSeconds(t *testing.T) {
+	entry := NewTimeEntry("te1", "usr1", "loc1", 1000)
+
+	// Execute test
+	_, err := entry.StartBreak("br1", 4600)
+	assert.NoError(t, err)
+	_, err = entry.EndBreak(6400)
+	assert.NoError(t, err)
+	err = entry.ClockOut(10000)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, entry.IsOpen())
+	assert.Equal(t, uint64(1800), entry.BreakSeconds(time.Unix(99999, 0)))
+	assert.Equal(t, uint64(7200), entry.WorkedSeconds(time.Unix(99999, 0)))
+}
+
+func TestTimeEntry_OpenEntryCountsUntilNow(t *testing.T) {
+	entry := NewTimeEntry("te1", "usr1", "loc1", 1000)
+	_, err := entry.StartBreak("br1", 2000)
+	assert.NoError(t, err)
+
+	// Execute test
+	now := time.Unix(2600, 0)
+
+	// Assertions
+	assert.Equal(t, uint64(600), entry.BreakSeconds(now))
+	assert.Equal(t, uint64(1000), entry.WorkedSeconds(now))
+}
+
+func TestTimeEntry_ClockOutEndsOpenBreak(t *testing.T) {
+	entry := NewTimeEntry("te1", "usr1", "loc1", 1000)
+	_, err := entry.StartBreak("br1", 2000)
+	assert.NoError(t, err)
+
+	// Execute test
+	err = entry.ClockOut(2500)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Nil(t, entry.OpenBreak())
+	assert.Equal(t, uint64(2500), *entry.Breaks[0].EndedAt)
+	assert.Equal(t, uint64(1000), entry.WorkedSeconds(time.Unix(99999, 0)))
+}
+
+func TestTimeEntry_BreakRules(t *testing.T) {
+	entry := NewTimeEntry("te1", "usr1", "loc1", 1000)
+
+	// Execute test and assertions
+	_, err := entry.EndBreak(1500)
+	assert.ErrorIs(t, err, ErrNotOnBreak)
+
+	_, err = entry.StartBreak("br1", 1500)
+	assert.NoError(t, err)
+	_, err = entry.StartBreak("br2", 1600)
+	assert.ErrorIs(t, err, ErrAlreadyOnBreak)
+
+	assert.NoError(t, entry.ClockOut(2000))
+	_, err = entry.StartBreak("br3", 2100)
+	assert.ErrorIs(t, err, ErrNotClockedIn)
+	assert.ErrorIs(t, entry.ClockOut(2200), ErrNotClockedIn)
+}
+
+func TestDayBounds(t *testing.T) {
+	// Execute test
+	from, to, err := DayBounds("2024-03-05")
+
+	// Assertions
+	assert.NoError(t, err)
+	start := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, uint64(start.Unix()), from)
+	assert.Equal(t, uint64(start.AddDate(0, 0, 1).Unix()), to)
+
+	_, _, err = DayBounds("05.03.2024")
+	assert.Error(t, err)
+}
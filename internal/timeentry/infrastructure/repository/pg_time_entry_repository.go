@@ -0,0 +1,343 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	locationPg "time-management/internal/location/infrastructure/repository"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+	userPg "time-management/internal/user/infrastructure/repository"
+)
+
+const (
+	TableName      = "time_entries"
+	BreakTableName = "time_entry_breaks"
+)
+
+// sortColumns maps the sortable entry fields to their columns
+var sortColumns = map[string]string{
+	"clocked_in_at":  "e.clocked_in_at",
+	"clocked_out_at": "e.clocked_out_at",
+}
+
+// selectEntries reads entries with their user, location and breaks, the breaks are
+// aggregated to JSON so a page of entries takes a single query
+var selectEntries = fmt.Sprintf(`
+	SELECT
+		e.id, e.clocked_in_at, e.clocked_out_at, e.auto_closed, e.report_id,
+		u.id, u.first_name, u.last_name, u.email,
+		l.id, l.name,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', b.id, 'started_at', b.started_at, 'ended_at', b.ended_at
+			) ORDER BY b.started_at, b.id)
+			FROM %s b WHERE b.entry_id = e.id
+		), '[]')
+	FROM %s e
+	JOIN %s u ON e.user_id = u.id
+	JOIN %s l ON e.location_id = l.id
+`, BreakTableName, TableName, userPg.TableName, locationPg.TableName)
+
+type PgTimeEntryRepository struct {
+	DB *sql.DB
+}
+
+func NewPgTimeEntryRepository(db *sql.DB) *PgTimeEntryRepository {
+	return &PgTimeEntryRepository{DB: db}
+}
+
+func (r *PgTimeEntryRepository) ClockIn(ctx context.Context, entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	locationExist, err := r.checkIfRecordExists(ctx, entry.Location.Id, locationPg.TableName)
+	if err != nil {
+		return nil, err
+	}
+	if !locationExist {
+		return nil, util.NewValidationError(domain.ErrWrongLocationId)
+	}
+
+	// The partial unique index allows a single open entry per user, even when two
+	// requests race to clock in
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, user_id, location_id, clocked_in_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) WHERE clocked_out_at IS NULL DO NOTHING
+		RETURNING id
+	`, TableName)
+
+	var savedId string
+	err = r.DB.QueryRowContext(ctx, query, entry.Id, entry.User.Id, entry.Location.Id, entry.ClockedInAt).
+		Scan(&savedId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewConflictError(domain.ErrAlreadyClockedIn)
+		}
+		return nil, err
+	}
+
+	return r.getEntry(ctx, "e.id = $1", savedId)
+}
+
+func (r *PgTimeEntryRepository) GetOpen(ctx context.Context, userId string) (*domain.TimeEntry, error) {
+	entry, err := r.getEntry(ctx, "e.user_id = $1 AND e.clocked_out_at IS NULL", userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrTimeEntryNotFound) {
+			return nil, domain.ErrNotClockedIn
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *PgTimeEntryRepository) GetAll(
+	ctx context.Context,
+	filter domain.TimeEntryFilter,
+	page pagination.Request,
+) ([]domain.TimeEntry, int, error) {
+	where, args := buildEntryFilter(filter)
+
+	orderBy, err := page.OrderBy(sortColumns, "e.clocked_in_at DESC", "e.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s e %s`, TableName, where)
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`%s %s %s LIMIT $%d OFFSET $%d`, selectEntries, where, orderBy, len(args)+1, len(args)+2)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries, err := ScanTimeEntryRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *PgTimeEntryRepository) GetById(ctx context.Context, id, userId string) (*domain.TimeEntry, error) {
+	if userId == "" {
+		return r.getEntry(ctx, "e.id = $1", id)
+	}
+
+	return r.getEntry(ctx, "e.id = $1 AND e.user_id = $2", id, userId)
+}
+
+func (r *PgTimeEntryRepository) GetUnconverted(
+	ctx context.Context,
+	userId string,
+	from, to uint64,
+) ([]domain.TimeEntry, error) {
+	query := selectEntries + `
+		WHERE e.user_id = $1 AND e.clocked_in_at >= $2 AND e.clocked_in_at < $3 AND e.report_id IS NULL
+		ORDER BY e.clocked_in_at, e.id
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return ScanTimeEntryRows(rows)
+}
+
+func (r *PgTimeEntryRepository) IsEmployeeActive(ctx context.Context, userId string) (bool, error) {
+	query := fmt.Sprintf(`SELECT active FROM %s WHERE id = $1`, userPg.TableName)
+
+	var active sql.NullBool
+	err := r.DB.QueryRowContext(ctx, query, userId).Scan(&active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, util.NewValidationError(domain.ErrWrongEmployeeId)
+		}
+		return false, err
+	}
+
+	return active.Valid && active.Bool, nil
+}
+
+func (r *PgTimeEntryRepository) ClockOut(ctx context.Context, entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET clocked_out_at = $1 WHERE id = $2 AND clocked_out_at IS NULL`, TableName)
+	result, err := tx.ExecContext(ctx, query, entry.ClockedOutAt, entry.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// Another request clocked out first
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return nil, util.NewConflictError(domain.ErrNotClockedIn)
+	}
+
+	breakQuery := fmt.Sprintf(`
+		UPDATE %s SET ended_at = GREATEST(started_at, $1) WHERE entry_id = $2 AND ended_at IS NULL
+	`, BreakTableName)
+	if _, err := tx.ExecContext(ctx, breakQuery, entry.ClockedOutAt, entry.Id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.getEntry(ctx, "e.id = $1", entry.Id)
+}
+
+func (r *PgTimeEntryRepository) StartBreak(ctx context.Context, entryId string, b *domain.Break) error {
+	// The partial unique index rejects a second open break of the entry
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, entry_id, started_at)
+		SELECT $1, e.id, $2 FROM %s e WHERE e.id = $3 AND e.clocked_out_at IS NULL
+		ON CONFLICT (entry_id) WHERE ended_at IS NULL DO NOTHING
+	`, BreakTableName, TableName)
+
+	result, err := r.DB.ExecContext(ctx, query, b.Id, b.StartedAt, entryId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return util.NewConflictError(domain.ErrAlreadyOnBreak)
+	}
+
+	return nil
+}
+
+func (r *PgTimeEntryRepository) EndBreak(ctx context.Context, entryId string, b *domain.Break) error {
+	query := fmt.Sprintf(`UPDATE %s SET ended_at = $1 WHERE id = $2 AND entry_id = $3 AND ended_at IS NULL`, BreakTableName)
+
+	result, err := r.DB.ExecContext(ctx, query, b.EndedAt, b.Id, entryId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return util.NewConflictError(domain.ErrNotOnBreak)
+	}
+
+	return nil
+}
+
+func (r *PgTimeEntryRepository) CloseForgotten(ctx context.Context, openedBefore, maxSeconds uint64) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Breaks first, while their entries can still be told apart by being open
+	breakQuery := fmt.Sprintf(`
+		UPDATE %s b SET ended_at = GREATEST(b.started_at, e.clocked_in_at + $1)
+		FROM %s e
+		WHERE b.entry_id = e.id AND b.ended_at IS NULL
+			AND e.clocked_out_at IS NULL AND e.clocked_in_at <= $2
+	`, BreakTableName, TableName)
+	if _, err := tx.ExecContext(ctx, breakQuery, maxSeconds, openedBefore); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s SET clocked_out_at = clocked_in_at + $1, auto_closed = TRUE
+		WHERE clocked_out_at IS NULL AND clocked_in_at <= $2
+	`, TableName)
+	result, err := tx.ExecContext(ctx, query, maxSeconds, openedBefore)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	closed, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return closed, nil
+}
+
+func (r *PgTimeEntryRepository) getEntry(ctx context.Context, condition string, args ...any) (*domain.TimeEntry, error) {
+	query := fmt.Sprintf(`%s WHERE %s`, selectEntries, condition)
+
+	return ScanTimeEntryRow(r.DB.QueryRowContext(ctx, query, args...))
+}
+
+func (r *PgTimeEntryRepository) checkIfRecordExists(ctx context.Context, id, table string) (bool, error) {
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)`, table)
+
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// buildEntryFilter returns the WHERE clause and its arguments for listing entries
+func buildEntryFilter(filter domain.TimeEntryFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("e.user_id = $%d", len(args)))
+	}
+	if filter.LocationId != "" {
+		args = append(args, filter.LocationId)
+		conditions = append(conditions, fmt.Sprintf("e.location_id = $%d", len(args)))
+	}
+	if filter.OpenOnly {
+		conditions = append(conditions, "e.clocked_out_at IS NULL")
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("e.clocked_in_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("e.clocked_in_at < $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
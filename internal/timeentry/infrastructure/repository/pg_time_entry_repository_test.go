@@ -0,0 +1,225 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	locationPg "time-management/internal/location/infrastructure/repository"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+	"time-management/internal/timeentry/infrastructure/repository"
+)
+
+func TestPgTimeEntryRepository_ClockIn(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mockCheckRecordExists(mock, locationPg.TableName, entry1.Location.Id, true)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO time_entries`)).
+		WithArgs(entry1.Id, entry1.User.Id, entry1.Location.Id, entry1.ClockedInAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(entry1.Id))
+	mockEntryQuery(mock, `WHERE e.id = $1`, entry1, entry1.Id)
+
+	// Execute test
+	created, err := repo.ClockIn(context.Background(), domain.NewTimeEntry(
+		entry1.Id, entry1.User.Id, entry1.Location.Id, entry1.ClockedInAt,
+	))
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, entry1.Id, created.Id)
+	assert.Equal(t, "Jane", created.User.FirstName)
+	assert.Len(t, created.Breaks, 1)
+	assert.Nil(t, created.Breaks[0].EndedAt)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_ClockIn_AlreadyOpen(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mockCheckRecordExists(mock, locationPg.TableName, entry1.Location.Id, true)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO time_entries`)).
+		WithArgs(entry1.Id, entry1.User.Id, entry1.Location.Id, entry1.ClockedInAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Execute test
+	_, err := repo.ClockIn(context.Background(), &entry1)
+
+	// Assertions
+	var conflictErr *util.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.ErrorIs(t, err, domain.ErrAlreadyClockedIn)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_GetOpen_NotClockedIn(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE e.user_id = $1 AND e.clocked_out_at IS NULL`)).
+		WithArgs("usr1").
+		WillReturnRows(sqlmock.NewRows(entryColumns))
+
+	// Execute test
+	_, err := repo.GetOpen(context.Background(), "usr1")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrNotClockedIn)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_GetAll_OpenOnly(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	where := `WHERE e.location_id = $1 AND e.clocked_out_at IS NULL`
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM time_entries e ` + where)).
+		WithArgs("loc1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockEntryQuery(mock, where+` ORDER BY e.clocked_in_at DESC, e.id LIMIT $2 OFFSET $3`, entry1, "loc1", 50, 0)
+
+	// Execute test
+	filter := domain.TimeEntryFilter{LocationId: "loc1", OpenOnly: true}
+	entries, total, err := repo.GetAll(context.Background(), filter, pagination.Request{Limit: 50})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, entries, 1)
+	assert.True(t, entries[0].IsOpen())
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_ClockOut(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	closed := entry1
+	clockedOutAt := uint64(1700030000)
+	closed.ClockedOutAt = &clockedOutAt
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE time_entries SET clocked_out_at = $1 WHERE id = $2 AND clocked_out_at IS NULL`)).
+		WithArgs(clockedOutAt, entry1.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE time_entry_breaks SET ended_at = GREATEST(started_at, $1)`)).
+		WithArgs(clockedOutAt, entry1.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mockEntryQuery(mock, `WHERE e.id = $1`, closed, entry1.Id)
+
+	// Execute test
+	entry, err := repo.ClockOut(context.Background(), &closed)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, entry.IsOpen())
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_ClockOut_AlreadyClosed(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	closed := entry1
+	clockedOutAt := uint64(1700030000)
+	closed.ClockedOutAt = &clockedOutAt
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE time_entries SET clocked_out_at = $1`)).
+		WithArgs(clockedOutAt, entry1.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	// Execute test
+	_, err := repo.ClockOut(context.Background(), &closed)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrNotClockedIn)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_StartBreak_AlreadyOnBreak(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO time_entry_breaks`)).
+		WithArgs("br2", uint64(1700005000), entry1.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute test
+	err := repo.StartBreak(context.Background(), entry1.Id, &domain.Break{Id: "br2", StartedAt: 1700005000})
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrAlreadyOnBreak)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTimeEntryRepository_CloseForgotten(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE time_entry_breaks b SET ended_at = GREATEST(b.started_at, e.clocked_in_at + $1)`)).
+		WithArgs(uint64(43200), uint64(1700000000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE time_entries SET clocked_out_at = clocked_in_at + $1, auto_closed = TRUE`)).
+		WithArgs(uint64(43200), uint64(1700000000)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	// Execute test
+	closed, err := repo.CloseForgotten(context.Background(), 1700000000, 43200)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), closed)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *repository.PgTimeEntryRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	repo := repository.NewPgTimeEntryRepository(db)
+	return mock, repo
+}
+
+// assertMockExpectations is a helper to ensure all expectations of the mock are met
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// mockCheckRecordExists mocks the query that checks if a record exists in a given table
+func mockCheckRecordExists(mock sqlmock.Sqlmock, tableName, id string, exists bool) {
+	query := regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM ` + tableName + ` WHERE id = $1)`)
+	mock.ExpectQuery(query).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+}
+
+var entryColumns = []string{
+	"id", "clocked_in_at", "clocked_out_at", "auto_closed", "report_id",
+	"id", "first_name", "last_name", "email",
+	"id", "name",
+	"breaks",
+}
+
+// mockEntryQuery mocks a query of entries with their user, location and breaks
+func mockEntryQuery(mock sqlmock.Sqlmock, condition string, entry domain.TimeEntry, args ...driver.Value) {
+	rows := sqlmock.NewRows(entryColumns).AddRow(
+		entry.Id, entry.ClockedInAt, entry.ClockedOutAt, entry.AutoClosed, entry.ReportId,
+		entry.User.Id, entry.User.FirstName, entry.User.LastName, entry.User.Email,
+		entry.Location.Id, entry.Location.Name,
+		[]byte(`[{"id": "br1", "started_at": 1700003600, "ended_at": null}]`),
+	)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM time_entries e`) + `[\s\S]*` + regexp.QuoteMeta(condition)).
+		WithArgs(args...).
+		WillReturnRows(rows)
+}
+
+var entry1 = domain.TimeEntry{
+	Id:          "te1",
+	User:        domain.User{Id: "usr1", FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+	Location:    domain.Location{Id: "loc1", Name: "New York"},
+	ClockedInAt: 1700000000,
+	Breaks:      []domain.Break{},
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/domain"
+)
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTimeEntry(row rowScanner) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	var breaks []byte
+
+	err := row.Scan(
+		&entry.Id, &entry.ClockedInAt, &entry.ClockedOutAt, &entry.AutoClosed, &entry.ReportId,
+		&entry.User.Id, &entry.User.FirstName, &entry.User.LastName, &entry.User.Email,
+		&entry.Location.Id, &entry.Location.Name,
+		&breaks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(breaks, &entry.Breaks); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func ScanTimeEntryRow(row *sql.Row) (*domain.TimeEntry, error) {
+	entry, err := scanTimeEntry(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrTimeEntryNotFound)
+		}
+
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func ScanTimeEntryRows(rows *sql.Rows) ([]domain.TimeEntry, error) {
+	entries := []domain.TimeEntry{}
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+	teDomain "time-management/internal/timeentry/domain"
+)
+
+// parseListRequest reads the filter and pagination query parameters of the entry list endpoints
+func parseListRequest(r *http.Request) (teDomain.TimeEntryFilter, pagination.Request, error) {
+	page, err := pagination.FromRequest(r)
+	if err != nil {
+		return teDomain.TimeEntryFilter{}, pagination.Request{}, err
+	}
+
+	filter, err := parseEntryFilter(r)
+	if err != nil {
+		return teDomain.TimeEntryFilter{}, pagination.Request{}, err
+	}
+
+	return filter, page, nil
+}
+
+// parseEntryFilter reads the user_id, location_id, from and to query parameters. From and
+// to are dates, both included, matched against the clock-in time.
+func parseEntryFilter(r *http.Request) (teDomain.TimeEntryFilter, error) {
+	values := r.URL.Query()
+	filter := teDomain.TimeEntryFilter{
+		UserId:     values.Get("user_id"),
+		LocationId: values.Get("location_id"),
+	}
+
+	if from := values.Get("from"); from != "" {
+		start, _, err := teDomain.DayBounds(from)
+		if err != nil {
+			return teDomain.TimeEntryFilter{}, util.NewFieldError("from", teDomain.ErrInvalidWorkDate)
+		}
+		filter.From = &start
+	}
+	if to := values.Get("to"); to != "" {
+		_, end, err := teDomain.DayBounds(to)
+		if err != nil {
+			return teDomain.TimeEntryFilter{}, util.NewFieldError("to", teDomain.ErrInvalidWorkDate)
+		}
+		filter.To = &end
+	}
+	if filter.From != nil && filter.To != nil && *filter.From >= *filter.To {
+		return teDomain.TimeEntryFilter{}, util.NewValidationError(teDomain.ErrInvalidDateRange)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,257 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time"
+	repDomain "time-management/internal/report/domain"
+	"time-management/internal/shared/util"
+	"time-management/internal/timeentry/application/command"
+	"time-management/internal/timeentry/application/query"
+	teDomain "time-management/internal/timeentry/domain"
+	"time-management/internal/user/domain"
+)
+
+type TimeEntryHandler struct {
+	ClockInHandler               command.ClockInHandler
+	ClockOutHandler              command.ClockOutHandler
+	StartBreakHandler            command.StartBreakHandler
+	EndBreakHandler              command.EndBreakHandler
+	ConvertEntriesHandler        command.ConvertEntriesHandler
+	CloseForgottenEntriesHandler command.CloseForgottenEntriesHandler
+	GetOpenEntryHandler          query.GetOpenEntryHandler
+	GetEntriesHandler            query.GetEntriesHandler
+	GetEntryHandler              query.GetEntryHandler
+}
+
+// NewTimeEntryHandler wires the time entry endpoints. Converted entries are submitted
+// through the report commands, so they get the same rounding and checks as typed reports.
+// A nil compliance checker skips the working time rules on clock-out.
+func NewTimeEntryHandler(
+	repository teDomain.TimeEntryRepository,
+	reports command.ReportCreator,
+	compliance teDomain.ComplianceChecker,
+	autoCloseAfter time.Duration,
+) *TimeEntryHandler {
+	forgotten := command.CloseForgottenEntriesHandler{Repo: repository, After: autoCloseAfter}
+
+	return &TimeEntryHandler{
+		ClockInHandler: command.ClockInHandler{Repo: repository, Forgotten: forgotten},
+		ClockOutHandler: command.ClockOutHandler{
+			Repo:       repository,
+			Forgotten:  forgotten,
+			Compliance: compliance,
+		},
+		StartBreakHandler:            command.StartBreakHandler{Repo: repository, Forgotten: forgotten},
+		EndBreakHandler:              command.EndBreakHandler{Repo: repository, Forgotten: forgotten},
+		ConvertEntriesHandler:        command.ConvertEntriesHandler{Repo: repository, Reports: reports},
+		CloseForgottenEntriesHandler: forgotten,
+		GetOpenEntryHandler:          query.GetOpenEntryHandler{Repo: repository},
+		GetEntriesHandler:            query.GetEntriesHandler{Repo: repository},
+		GetEntryHandler:              query.GetEntryHandler{Repo: repository},
+	}
+}
+
+func (h *TimeEntryHandler) ClockIn(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	var req struct {
+		LocationId string `json:"location_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := command.ClockInCommand{UserId: user.Id, LocationId: req.LocationId}
+	entry, err := h.ClockInHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusCreated, entry)
+}
+
+func (h *TimeEntryHandler) ClockOut(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	entry, err := h.ClockOutHandler.Handle(r.Context(), command.ClockOutCommand{UserId: user.Id})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entry)
+}
+
+func (h *TimeEntryHandler) StartBreak(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	entry, err := h.StartBreakHandler.Handle(r.Context(), command.StartBreakCommand{UserId: user.Id})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entry)
+}
+
+func (h *TimeEntryHandler) EndBreak(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	entry, err := h.EndBreakHandler.Handle(r.Context(), command.EndBreakCommand{UserId: user.Id})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entry)
+}
+
+// GetCurrentEntry returns the open entry of the user with its live timer
+func (h *TimeEntryHandler) GetCurrentEntry(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	entry, err := h.GetOpenEntryHandler.Handle(r.Context(), query.GetOpenEntryQuery{UserId: user.Id})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entry)
+}
+
+func (h *TimeEntryHandler) GetOwnEntries(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	filter, page, err := parseListRequest(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	filter.UserId = user.Id
+
+	entries, err := h.GetEntriesHandler.Handle(r.Context(), query.GetEntriesQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entries)
+}
+
+func (h *TimeEntryHandler) GetOwnEntry(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	entryQuery := query.GetEntryQuery{Id: chi.URLParam(r, "id"), UserId: user.Id}
+	entry, err := h.GetEntryHandler.Handle(r.Context(), entryQuery)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entry)
+}
+
+// GetEntries lists the entries of every user, filtered by user_id, location_id, from and to
+func (h *TimeEntryHandler) GetEntries(w http.ResponseWriter, r *http.Request) error {
+	filter, page, err := parseListRequest(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	entries, err := h.GetEntriesHandler.Handle(r.Context(), query.GetEntriesQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entries)
+}
+
+// GetOpenEntries lists who is clocked in right now. Forgotten entries are closed first so
+// they do not show up as people still at work.
+func (h *TimeEntryHandler) GetOpenEntries(w http.ResponseWriter, r *http.Request) error {
+	filter, page, err := parseListRequest(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	filter.OpenOnly = true
+
+	if _, err := h.CloseForgottenEntriesHandler.Handle(r.Context()); err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	entries, err := h.GetEntriesHandler.Handle(r.Context(), query.GetEntriesQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entries)
+}
+
+func (h *TimeEntryHandler) GetEntry(w http.ResponseWriter, r *http.Request) error {
+	entry, err := h.GetEntryHandler.Handle(r.Context(), query.GetEntryQuery{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, entry)
+}
+
+// ConvertEntries submits the entries of a work date as a pending report. Managers convert
+// the entries of an employee by passing the employee id in the path.
+func (h *TimeEntryHandler) ConvertEntries(w http.ResponseWriter, r *http.Request) error {
+	employeeId := chi.URLParam(r, "employee_id")
+	if employeeId == "" {
+		user, ok := r.Context().Value("user").(*domain.User)
+		if !ok || user == nil {
+			return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+		}
+		employeeId = user.Id
+	}
+
+	var req struct {
+		WorkDate           string   `json:"work_date"`
+		LocationId         string   `json:"location_id"`
+		MaintenanceMinutes *int64   `json:"maintenance_minutes"`
+		MaintenanceHours   *float64 `json:"maintenance_hours"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	var maintenance uint64
+	switch {
+	case req.MaintenanceMinutes != nil && *req.MaintenanceMinutes > 0:
+		maintenance = uint64(*req.MaintenanceMinutes)
+	case req.MaintenanceMinutes == nil && req.MaintenanceHours != nil && *req.MaintenanceHours > 0:
+		maintenance = repDomain.HoursToMinutes(*req.MaintenanceHours)
+	}
+
+	cmd := command.ConvertEntriesCommand{
+		UserId:             employeeId,
+		WorkDate:           req.WorkDate,
+		LocationId:         req.LocationId,
+		MaintenanceMinutes: maintenance,
+	}
+	report, err := h.ConvertEntriesHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusCreated, report)
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/shared/totp"
+	sharedUtil "time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+// ConfirmTwoFactorCommand enables two-factor authentication with a code from the
+// authenticator app. With a pre-auth token it also completes the login.
+type ConfirmTwoFactorCommand struct {
+	UserId       string
+	PreAuthToken string
+	Code         string
+}
+
+type ConfirmTwoFactorHandler struct {
+	Repo      domain.UserRepository
+	Sessions  domain.SessionRepository
+	TwoFactor domain.TwoFactorRepository
+	Throttle  domain.LoginThrottle
+	JwtSecret []byte
+}
+
+func (h *ConfirmTwoFactorHandler) Handle(ctx context.Context, cmd ConfirmTwoFactorCommand) (*domain.TwoFactorConfirmation, error) {
+	userId, err := resolveUserId(h.JwtSecret, cmd.UserId, cmd.PreAuthToken, PurposeTwoFactorEnrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	throttleKey := twoFactorThrottleKey(userId)
+	if h.Throttle != nil {
+		if wait := h.Throttle.RetryAfter(throttleKey, now); wait > 0 {
+			return nil, &domain.TooManyAttemptsError{RetryAfter: wait}
+		}
+	}
+
+	var user *domain.User
+	if cmd.UserId == "" {
+		if user, err = loginUser(ctx, h.Repo, userId); err != nil {
+			return nil, err
+		}
+	}
+
+	twoFactor, err := h.TwoFactor.GetByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		return nil, sharedUtil.NewConflictError(domain.ErrTwoFactorEnabled)
+	}
+
+	step, ok := totp.Validate(twoFactor.Secret, cmd.Code, now)
+	if !ok {
+		if h.Throttle != nil {
+			h.Throttle.Fail(throttleKey, now)
+		}
+		return nil, sharedUtil.NewValidationError(domain.ErrInvalidTwoFactorCode)
+	}
+
+	if h.Throttle != nil {
+		h.Throttle.Reset(throttleKey)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.TwoFactor.Confirm(ctx, userId, step, hashes, uint64(now.Unix())); err != nil {
+		return nil, err
+	}
+
+	confirmation := &domain.TwoFactorConfirmation{RecoveryCodes: codes}
+	if user != nil {
+		if confirmation.Tokens, err = startSession(ctx, h.Sessions, h.JwtSecret, user, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return confirmation, nil
+}
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/shared/totp"
+	"time-management/internal/user/domain"
+)
+
+// EnrollTwoFactorCommand starts the setup for the logged in user or, when their role
+// requires two-factor authentication, for the user of an enrollment pre-auth token
+type EnrollTwoFactorCommand struct {
+	UserId       string
+	PreAuthToken string
+}
+
+type EnrollTwoFactorHandler struct {
+	Repo      domain.UserRepository
+	TwoFactor domain.TwoFactorRepository
+	JwtSecret []byte
+}
+
+func (h *EnrollTwoFactorHandler) Handle(ctx context.Context, cmd EnrollTwoFactorCommand) (*domain.TwoFactorEnrollment, error) {
+	userId, err := resolveUserId(h.JwtSecret, cmd.UserId, cmd.PreAuthToken, PurposeTwoFactorEnrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.Repo.GetById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor := domain.NewTwoFactor(user.Id, secret, uint64(time.Now().Unix()))
+	if err := h.TwoFactor.SavePending(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"time"
+	appMail "time-management/internal/shared/mail"
+	sharedUtil "time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+const PasswordResetExpirationTime = time.Hour
+
+type ForgotPasswordCommand struct {
+	Email string
+}
+
+// ForgotPasswordHandler mails a reset link to the user. ResetUrl is the page of the
+// client that accepts the token; without it the bare token is sent.
+type ForgotPasswordHandler struct {
+	Repo     domain.UserRepository
+	Resets   domain.PasswordResetRepository
+	Mailer   appMail.Mailer
+	ResetUrl string
+}
+
+// Handle succeeds without sending anything for unknown or inactive accounts. Known ones get
+// their link in the background, so that neither the response nor its timing reveals which
+// emails are registered.
+func (h *ForgotPasswordHandler) Handle(ctx context.Context, cmd ForgotPasswordCommand) error {
+	if _, err := mail.ParseAddress(cmd.Email); err != nil {
+		return sharedUtil.NewFieldError("email", domain.ErrEmailWrongFormat)
+	}
+
+	user, err := h.Repo.GetByEmail(ctx, cmd.Email)
+	if err != nil || !user.Active {
+		return nil
+	}
+
+	// The request may be done before the mail is, its values are kept for the logs
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := h.sendReset(ctx, user); err != nil {
+			slog.ErrorContext(ctx, "send password reset", "user", user.Id, "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// sendReset stores a new reset token for the user and mails the link to it
+func (h *ForgotPasswordHandler) sendReset(ctx context.Context, user *domain.User) error {
+	token, tokenHash, err := newSecret()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reset := domain.NewPasswordReset(
+		uuid.New().String(),
+		user.Id,
+		tokenHash,
+		uint64(now.Unix()),
+		uint64(now.Add(PasswordResetExpirationTime).Unix()),
+	)
+	if err := h.Resets.Create(ctx, reset); err != nil {
+		return err
+	}
+
+	return h.Mailer.Send(ctx, appMail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\n"+
+				"We received a request to reset your password. "+
+				"Use the following within %d minutes to choose a new one:\n\n%s\n\n"+
+				"If you did not ask for a new password, you can ignore this email.",
+			user.FirstName,
+			int(PasswordResetExpirationTime.Minutes()),
+			h.resetLink(token),
+		),
+	})
+}
+
+func (h *ForgotPasswordHandler) resetLink(token string) string {
+	link, err := url.Parse(h.ResetUrl)
+	if h.ResetUrl == "" || err != nil {
+		return token
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
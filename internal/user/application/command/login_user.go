@@ -2,54 +2,159 @@ package command
 
 import (
 	"context"
-	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"errors"
+	"log/slog"
 	"net/mail"
-	"os"
+	"strings"
+	"sync"
 	"time"
+	"time-management/internal/shared/metrics"
 	sharedUtil "time-management/internal/shared/util"
 	"time-management/internal/user/domain"
 )
 
-const JwtId = "id"
-const JwtRole = "role"
-const JwtExp = "exp"
-const JwtExpirationTime = time.Hour * 24
-
 type LoginUserCommand struct {
 	Email    string
 	Password string
+	IP       string
 }
 
 type LoginUserHandler struct {
-	Repo domain.UserRepository
+	Repo     domain.UserRepository
+	Sessions domain.SessionRepository
+	Throttle domain.LoginThrottle
+	// TwoFactor adds the second login step for users who enabled it or whose role requires it
+	TwoFactor domain.TwoFactorRepository
+	Hasher    domain.PasswordHasher
+	JwtSecret []byte
+
+	dummyHash     string
+	dummyHashOnce sync.Once
+}
+
+// compareDummy spends about as long as checking a real password so that unknown and
+// locked accounts cannot be told apart by response time
+func (h *LoginUserHandler) compareDummy(password string) {
+	h.dummyHashOnce.Do(func() {
+		h.dummyHash, _ = h.Hasher.Hash("dummy-password")
+	})
+	_, _ = h.Hasher.Verify(h.dummyHash, password)
+}
+
+func emailThrottleKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
 }
 
-func (h *LoginUserHandler) Handle(ctx context.Context, cmd LoginUserCommand) (*string, error) {
+func (h *LoginUserHandler) Handle(ctx context.Context, cmd LoginUserCommand) (*domain.LoginResult, error) {
 	if _, err := mail.ParseAddress(cmd.Email); err != nil {
-		return nil, sharedUtil.NewValidationError(domain.ErrEmailWrongFormat)
+		return nil, sharedUtil.NewFieldError("email", domain.ErrEmailWrongFormat)
+	}
+
+	now := time.Now()
+	emailKey := emailThrottleKey(cmd.Email)
+	ipKey := ipThrottleKey(cmd.IP)
+
+	if h.Throttle != nil {
+		wait := max(h.Throttle.RetryAfter(emailKey, now), h.Throttle.RetryAfter(ipKey, now))
+		if wait > 0 {
+			metrics.FailedLogins.WithLabelValues(metrics.LoginThrottled).Inc()
+			return nil, &domain.TooManyAttemptsError{RetryAfter: wait}
+		}
 	}
 
 	user, err := h.Repo.GetByEmail(ctx, cmd.Email)
 	if err != nil {
+		h.compareDummy(cmd.Password)
+		h.fail(emailKey, ipKey, now, metrics.LoginInvalidCredentials)
 		return nil, domain.ErrInvalidEmailOrPassword
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(cmd.Password))
+	// A locked account answers like an unknown email, the lock only shows in the metrics and
+	// to admins, otherwise the lockout would reveal which emails are registered
+	if user.LockedUntil != nil && *user.LockedUntil > uint64(now.Unix()) {
+		h.compareDummy(cmd.Password)
+		h.fail(emailKey, ipKey, now, metrics.LoginLocked)
+		return nil, domain.ErrInvalidEmailOrPassword
+	}
+
+	valid, err := h.Hasher.Verify(user.PasswordHash, cmd.Password)
 	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		h.fail(emailKey, ipKey, now, metrics.LoginInvalidCredentials)
+		_, err := h.Repo.RecordFailedLogin(ctx, user.Id, uint64(now.Add(domain.LockoutDuration).Unix()))
+		if err != nil {
+			return nil, err
+		}
 		return nil, domain.ErrInvalidEmailOrPassword
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		JwtId:   user.Id,
-		JwtRole: user.Role,
-		JwtExp:  time.Now().Add(JwtExpirationTime).Unix(),
-	})
+	if h.Throttle != nil {
+		h.Throttle.Reset(emailKey)
+	}
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		if err := h.Repo.Unlock(ctx, user.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	// Upgrade hashes made with an older algorithm or weaker parameters while the plain password is at hand
+	if h.Hasher.NeedsRehash(user.PasswordHash) {
+		if hash, err := h.Hasher.Hash(cmd.Password); err != nil {
+			slog.ErrorContext(ctx, "rehash password", "user", user.Id, "error", err)
+		} else if err := h.Repo.ChangePassword(ctx, user.Id, hash); err != nil {
+			slog.ErrorContext(ctx, "store rehashed password", "user", user.Id, "error", err)
+		}
+	}
+
+	// Checked after the password so that the status of an account is not revealed to guessers
+	if !user.Active {
+		return nil, domain.ErrUserInactive
+	}
+
+	twoFactor, err := h.TwoFactor.GetByUserId(ctx, user.Id)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		preAuthToken, err := newPreAuthToken(h.JwtSecret, user.Id, PurposeTwoFactor, now)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{PreAuthToken: preAuthToken, TwoFactorRequired: true}, nil
+	}
 
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	required, err := h.TwoFactor.IsRequired(ctx, user.Role)
 	if err != nil {
 		return nil, err
 	}
+	if required {
+		preAuthToken, err := newPreAuthToken(h.JwtSecret, user.Id, PurposeTwoFactorEnrollment, now)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{PreAuthToken: preAuthToken, TwoFactorEnrollmentRequired: true}, nil
+	}
 
-	return &tokenString, nil
+	tokens, err := startSession(ctx, h.Sessions, h.JwtSecret, user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResult{Tokens: tokens}, nil
+}
+
+// fail counts a rejected attempt against both throttle keys
+func (h *LoginUserHandler) fail(emailKey, ipKey string, now time.Time, reason string) {
+	metrics.FailedLogins.WithLabelValues(reason).Inc()
+	if h.Throttle == nil {
+		return
+	}
+	h.Throttle.Fail(emailKey, now)
+	h.Throttle.Fail(ipKey, now)
 }
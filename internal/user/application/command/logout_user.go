@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+	"time-management/internal/user/domain"
+)
+
+type LogoutUserCommand struct {
+	RefreshToken string
+}
+
+type LogoutUserHandler struct {
+	Sessions domain.SessionRepository
+}
+
+// Handle revokes the session the refresh token belongs to. Logging out without a
+// refresh token only clears the cookies, a token whose secret does not match its
+// session is rejected like on refresh.
+func (h *LogoutUserHandler) Handle(ctx context.Context, cmd LogoutUserCommand) error {
+	if cmd.RefreshToken == "" {
+		return nil
+	}
+
+	sessionId, hash, err := parseRefreshToken(cmd.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	session, err := h.Sessions.GetById(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(hash)) != 1 {
+		return domain.ErrInvalidRefreshToken
+	}
+
+	return h.Sessions.Revoke(ctx, sessionId, uint64(time.Now().Unix()))
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/user/domain"
+)
+
+type RefreshTokenCommand struct {
+	RefreshToken string
+}
+
+type RefreshTokenHandler struct {
+	Repo      domain.UserRepository
+	Sessions  domain.SessionRepository
+	JwtSecret []byte
+}
+
+// Handle rotates the refresh token of the session and issues a new access token.
+// Presenting a refresh token that was already rotated means it was copied, so the
+// whole session is revoked.
+func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenCommand) (*domain.Tokens, error) {
+	sessionId, oldHash, err := parseRefreshToken(cmd.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	refreshToken, newHash, err := newRefreshToken(sessionId)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := uint64(now.Add(RefreshTokenExpirationTime).Unix())
+
+	rotated, err := h.Sessions.Rotate(ctx, sessionId, oldHash, newHash, expiresAt, uint64(now.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		if err := h.Sessions.Revoke(ctx, sessionId, uint64(now.Unix())); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	session, err := h.Sessions.GetById(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the user again so that role changes apply from the next access token
+	user, err := h.Repo.GetById(ctx, session.UserId)
+	if err != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if !user.Active {
+		if err := h.Sessions.Revoke(ctx, sessionId, uint64(now.Unix())); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrUserInactive
+	}
+
+	return issueTokens(h.JwtSecret, user, session, refreshToken, now)
+}
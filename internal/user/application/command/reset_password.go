@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"time"
+	sharedUtil "time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+type ResetPasswordCommand struct {
+	Token    string
+	Password string
+}
+
+type ResetPasswordHandler struct {
+	Repo     domain.UserRepository
+	Resets   domain.PasswordResetRepository
+	Sessions domain.SessionRepository
+	Policy   domain.PasswordPolicy
+	Hasher   domain.PasswordHasher
+}
+
+// Handle sets the new password and logs the user out everywhere
+func (h *ResetPasswordHandler) Handle(ctx context.Context, cmd ResetPasswordCommand) error {
+	if cmd.Token == "" {
+		return sharedUtil.NewValidationError(domain.ErrInvalidResetToken)
+	}
+
+	now := uint64(time.Now().Unix())
+	tokenHash := hashToken(cmd.Token)
+
+	// The token is only used up once the new password passed the policy, so that the
+	// user can correct a rejected password with the same link
+	userId, err := h.Resets.GetUserId(ctx, tokenHash, now)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.Repo.GetById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Policy.Validate(cmd.Password, user.Email); err != nil {
+		return sharedUtil.NewFieldError("password", err)
+	}
+
+	hash, err := h.Hasher.Hash(cmd.Password)
+	if err != nil {
+		return domain.ErrFailedToHashPassword
+	}
+
+	if _, err := h.Resets.Consume(ctx, tokenHash, now); err != nil {
+		return err
+	}
+
+	if err := h.Repo.ChangePassword(ctx, userId, hash); err != nil {
+		return err
+	}
+
+	_, err = h.Sessions.RevokeAllForUser(ctx, userId, now)
+	return err
+}
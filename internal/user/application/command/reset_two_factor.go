@@ -0,0 +1,25 @@
+package command
+
+import (
+	"context"
+	"time-management/internal/user/domain"
+)
+
+type ResetTwoFactorCommand struct {
+	UserId string
+}
+
+type ResetTwoFactorHandler struct {
+	Repo      domain.UserRepository
+	TwoFactor domain.TwoFactorRepository
+}
+
+// Handle removes the secret and recovery codes of a user who lost their device.
+// If their role requires two-factor authentication they set it up again on the next login.
+func (h *ResetTwoFactorHandler) Handle(ctx context.Context, cmd ResetTwoFactorCommand) error {
+	if _, err := h.Repo.GetById(ctx, cmd.UserId); err != nil {
+		return err
+	}
+
+	return h.TwoFactor.Delete(ctx, cmd.UserId)
+}
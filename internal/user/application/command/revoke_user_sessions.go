@@ -0,0 +1,25 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/user/domain"
+)
+
+type RevokeUserSessionsCommand struct {
+	UserId string
+}
+
+type RevokeUserSessionsHandler struct {
+	Repo     domain.UserRepository
+	Sessions domain.SessionRepository
+}
+
+// Handle logs the user out on every device and returns the number of revoked sessions
+func (h *RevokeUserSessionsHandler) Handle(ctx context.Context, cmd RevokeUserSessionsCommand) (int64, error) {
+	if _, err := h.Repo.GetById(ctx, cmd.UserId); err != nil {
+		return 0, err
+	}
+
+	return h.Sessions.RevokeAllForUser(ctx, cmd.UserId, uint64(time.Now().Unix()))
+}
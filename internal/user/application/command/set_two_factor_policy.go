@@ -0,0 +1,27 @@
+package command
+
+import (
+	"context"
+	sharedUtil "time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+	"time-management/internal/user/role"
+)
+
+type SetTwoFactorPolicyCommand struct {
+	Role     string
+	Required bool
+}
+
+type SetTwoFactorPolicyHandler struct {
+	TwoFactor domain.TwoFactorRepository
+}
+
+// Handle sets whether users of the role have to use two-factor authentication.
+// It applies from their next login.
+func (h *SetTwoFactorPolicyHandler) Handle(ctx context.Context, cmd SetTwoFactorPolicyCommand) error {
+	if !role.Role(cmd.Role).IsValid() {
+		return sharedUtil.NewValidationError(domain.ErrInvalidRole)
+	}
+
+	return h.TwoFactor.SetRequired(ctx, cmd.Role, cmd.Required)
+}
@@ -0,0 +1,162 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"time-management/internal/user/domain"
+)
+
+const JwtId = "id"
+const JwtRole = "role"
+const JwtSessionId = "sid"
+const JwtExp = "exp"
+const JwtPurpose = "purpose"
+
+// Purposes of pre-auth tokens, which stand in for a session between the password and
+// the two-factor step of a login
+const (
+	PurposeTwoFactor           = "2fa"
+	PurposeTwoFactorEnrollment = "2fa_enrollment"
+)
+
+// AccessTokenExpirationTime is kept short since access tokens are checked against the
+// session on every request but cannot be revoked on their own
+const AccessTokenExpirationTime = time.Minute * 15
+const RefreshTokenExpirationTime = time.Hour * 24 * 7
+const PreAuthTokenExpirationTime = time.Minute * 5
+
+// startSession creates a session for the user and issues its first tokens
+func startSession(
+	ctx context.Context,
+	sessions domain.SessionRepository,
+	secret []byte,
+	user *domain.User,
+	now time.Time,
+) (*domain.Tokens, error) {
+	sessionId := uuid.New().String()
+	refreshToken, refreshTokenHash, err := newRefreshToken(sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	session := domain.NewSession(
+		sessionId,
+		user.Id,
+		refreshTokenHash,
+		uint64(now.Unix()),
+		uint64(now.Add(RefreshTokenExpirationTime).Unix()),
+	)
+	if err := sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return issueTokens(secret, user, session, refreshToken, now)
+}
+
+// issueTokens signs a new access token and pairs it with the refresh token of the session
+func issueTokens(secret []byte, user *domain.User, session *domain.Session, refreshToken string, now time.Time) (*domain.Tokens, error) {
+	accessExpiresAt := now.Add(AccessTokenExpirationTime)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		JwtId:        user.Id,
+		JwtRole:      user.Role,
+		JwtSessionId: session.Id,
+		JwtExp:       accessExpiresAt.Unix(),
+	})
+
+	accessToken, err := token.SignedString(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Tokens{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  uint64(accessExpiresAt.Unix()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// newPreAuthToken signs a short-lived token for the user that is only accepted by the
+// login step of the purpose. It has no session id, so the auth middleware rejects it.
+func newPreAuthToken(secret []byte, userId, purpose string, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		JwtId:      userId,
+		JwtPurpose: purpose,
+		JwtExp:     now.Add(PreAuthTokenExpirationTime).Unix(),
+	})
+
+	return token.SignedString(secret)
+}
+
+// parsePreAuthToken returns the user id of a valid pre-auth token issued for the purpose
+func parsePreAuthToken(secret []byte, tokenString, purpose string) (string, error) {
+	token, err := jwt.Parse(
+		tokenString,
+		func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid {
+		return "", domain.ErrInvalidPreAuthToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", domain.ErrInvalidPreAuthToken
+	}
+
+	userId, okId := claims[JwtId].(string)
+	tokenPurpose, okPurpose := claims[JwtPurpose].(string)
+	if !okId || !okPurpose || tokenPurpose != purpose {
+		return "", domain.ErrInvalidPreAuthToken
+	}
+
+	return userId, nil
+}
+
+// newRefreshToken returns a refresh token for the session and the hash to store.
+// The token is the session id followed by a random secret.
+func newRefreshToken(sessionId string) (string, string, error) {
+	secret, hash, err := newSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	return sessionId + "." + secret, hash, nil
+}
+
+// newSecret returns a random URL safe secret and its hash
+func newSecret() (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	return encoded, hashToken(encoded), nil
+}
+
+// parseRefreshToken splits a refresh token into its session id and the hash of its secret
+func parseRefreshToken(token string) (string, string, error) {
+	sessionId, secret, ok := strings.Cut(token, ".")
+	if !ok || sessionId == "" || secret == "" {
+		return "", "", domain.ErrInvalidRefreshToken
+	}
+
+	return sessionId, hashToken(secret), nil
+}
+
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time-management/internal/user/domain"
+)
+
+// TwoFactorIssuer is the account issuer shown in authenticator apps
+const TwoFactorIssuer = "Time Management"
+
+// resolveUserId returns the id of the logged in user or, while logging in, the user of
+// the pre-auth token
+func resolveUserId(secret []byte, userId, preAuthToken, purpose string) (string, error) {
+	if userId != "" {
+		return userId, nil
+	}
+
+	return parsePreAuthToken(secret, preAuthToken, purpose)
+}
+
+// loginUser loads the user of a pre-auth token and makes sure they may still log in
+func loginUser(ctx context.Context, repo domain.UserRepository, userId string) (*domain.User, error) {
+	user, err := repo.GetById(ctx, userId)
+	if err != nil {
+		return nil, domain.ErrInvalidPreAuthToken
+	}
+	if !user.Active {
+		return nil, domain.ErrUserInactive
+	}
+
+	return user, nil
+}
+
+// newRecoveryCodes returns recovery codes formatted for display and the hashes to store
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, domain.RecoveryCodeCount)
+	hashes := make([]string, domain.RecoveryCodeCount)
+
+	for i := range codes {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(raw))
+		codes[i] = code[:4] + "-" + code[4:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so that codes can be typed as shown or not
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	return hashToken(normalized)
+}
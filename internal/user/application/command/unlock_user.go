@@ -0,0 +1,33 @@
+package command
+
+import (
+	"context"
+	"time-management/internal/user/domain"
+)
+
+type UnlockUserCommand struct {
+	UserId string
+}
+
+type UnlockUserHandler struct {
+	Repo     domain.UserRepository
+	Throttle domain.LoginThrottle
+}
+
+// Handle lifts a lockout after too many failed logins together with the login delay for the email
+func (h *UnlockUserHandler) Handle(ctx context.Context, cmd UnlockUserCommand) error {
+	user, err := h.Repo.GetById(ctx, cmd.UserId)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Repo.Unlock(ctx, user.Id); err != nil {
+		return err
+	}
+
+	if h.Throttle != nil {
+		h.Throttle.Reset(emailThrottleKey(user.Email))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"time"
+	"time-management/internal/shared/metrics"
+	"time-management/internal/shared/totp"
+	"time-management/internal/user/domain"
+)
+
+// VerifyTwoFactorCommand is the second login step. Either a code from the
+// authenticator app or one of the recovery codes is required.
+type VerifyTwoFactorCommand struct {
+	PreAuthToken string
+	Code         string
+	RecoveryCode string
+}
+
+type VerifyTwoFactorHandler struct {
+	Repo      domain.UserRepository
+	Sessions  domain.SessionRepository
+	TwoFactor domain.TwoFactorRepository
+	Throttle  domain.LoginThrottle
+	JwtSecret []byte
+}
+
+func (h *VerifyTwoFactorHandler) Handle(ctx context.Context, cmd VerifyTwoFactorCommand) (*domain.Tokens, error) {
+	userId, err := parsePreAuthToken(h.JwtSecret, cmd.PreAuthToken, PurposeTwoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	throttleKey := twoFactorThrottleKey(userId)
+	if h.Throttle != nil {
+		if wait := h.Throttle.RetryAfter(throttleKey, now); wait > 0 {
+			metrics.FailedLogins.WithLabelValues(metrics.LoginThrottled).Inc()
+			return nil, &domain.TooManyAttemptsError{RetryAfter: wait}
+		}
+	}
+
+	user, err := loginUser(ctx, h.Repo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := h.TwoFactor.GetByUserId(ctx, userId)
+	if err != nil || !twoFactor.Enabled() {
+		// Two-factor authentication was reset since the password step
+		return nil, domain.ErrInvalidPreAuthToken
+	}
+
+	valid, err := h.verify(ctx, twoFactor, cmd, now)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginInvalidTwoFactor).Inc()
+		if h.Throttle != nil {
+			h.Throttle.Fail(throttleKey, now)
+		}
+		return nil, domain.ErrInvalidTwoFactorCode
+	}
+
+	if h.Throttle != nil {
+		h.Throttle.Reset(throttleKey)
+	}
+
+	return startSession(ctx, h.Sessions, h.JwtSecret, user, now)
+}
+
+// twoFactorThrottleKey is shared by verifying and confirming codes, so that guesses on either
+// count against the same limit
+func twoFactorThrottleKey(userId string) string {
+	return "2fa:" + userId
+}
+
+// verify checks the code or the recovery code and uses it up so that it cannot be replayed
+func (h *VerifyTwoFactorHandler) verify(
+	ctx context.Context,
+	twoFactor *domain.TwoFactor,
+	cmd VerifyTwoFactorCommand,
+	now time.Time,
+) (bool, error) {
+	if cmd.RecoveryCode != "" {
+		return h.TwoFactor.UseRecoveryCode(ctx, twoFactor.UserId, hashRecoveryCode(cmd.RecoveryCode), uint64(now.Unix()))
+	}
+
+	step, ok := totp.Validate(twoFactor.Secret, cmd.Code, now)
+	if !ok {
+		return false, nil
+	}
+
+	return h.TwoFactor.UseStep(ctx, twoFactor.UserId, step)
+}
@@ -0,0 +1,31 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/user/domain"
+	"time-management/internal/user/role"
+)
+
+type GetTwoFactorPoliciesHandler struct {
+	TwoFactor domain.TwoFactorRepository
+}
+
+// Handle returns the policy of every role, roles without a stored policy do not require two-factor authentication
+func (h *GetTwoFactorPoliciesHandler) Handle(ctx context.Context) ([]domain.TwoFactorPolicy, error) {
+	stored, err := h.TwoFactor.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]bool, len(stored))
+	for _, policy := range stored {
+		required[policy.Role] = policy.Required
+	}
+
+	policies := make([]domain.TwoFactorPolicy, 0, len(role.All()))
+	for _, r := range role.All() {
+		policies = append(policies, domain.TwoFactorPolicy{Role: r.String(), Required: required[r.String()]})
+	}
+
+	return policies, nil
+}
@@ -8,8 +8,55 @@ var (
 	ErrEmailTaken             = errors.New("email already taken")
 	ErrEmailWrongFormat       = errors.New("email has wrong format")
 	ErrPasswordTooShort       = errors.New("password is too short")
+	ErrPasswordTooLong        = errors.New("password is too long")
+	ErrPasswordNeedsUpper     = errors.New("password must contain an upper case letter")
+	ErrPasswordNeedsLower     = errors.New("password must contain a lower case letter")
+	ErrPasswordNeedsDigit     = errors.New("password must contain a digit")
+	ErrPasswordNeedsSymbol    = errors.New("password must contain a symbol")
+	ErrPasswordEqualsEmail    = errors.New("password must not be the email")
+	ErrPasswordTooCommon      = errors.New("password is too common")
 	ErrUserNotFound           = errors.New("user not found")
 	ErrInternalServer         = errors.New("there is an error, try again later")
 	ErrInvalidEmailOrPassword = errors.New("invalid email or password")
+	ErrUserInactive           = errors.New("user account is deactivated")
+	ErrTooManyAttempts        = errors.New("too many failed login attempts")
 	ErrFailedToHashPassword   = errors.New("failed to hash password")
+	ErrSessionNotFound        = errors.New("session not found")
+	ErrInvalidRefreshToken    = errors.New("invalid or expired refresh token")
+	ErrInvalidResetToken      = errors.New("invalid or expired password reset token")
+	ErrTwoFactorNotFound      = errors.New("two-factor authentication is not set up")
+	ErrTwoFactorEnabled       = errors.New("two-factor authentication is already enabled")
+	ErrInvalidTwoFactorCode   = errors.New("invalid two-factor code")
+	ErrInvalidPreAuthToken    = errors.New("invalid or expired pre-auth token")
+	ErrInvalidRole            = errors.New("invalid role")
 )
+
+// ErrorCodes are the stable codes of the errors above, clients switch on them
+var ErrorCodes = map[error]string{
+	ErrFirstNameTooShort:      "first_name_too_short",
+	ErrLastNameTooShort:       "last_name_too_short",
+	ErrEmailTaken:             "email_taken",
+	ErrEmailWrongFormat:       "email_invalid",
+	ErrPasswordTooShort:       "password_too_short",
+	ErrPasswordTooLong:        "password_too_long",
+	ErrPasswordNeedsUpper:     "password_needs_upper",
+	ErrPasswordNeedsLower:     "password_needs_lower",
+	ErrPasswordNeedsDigit:     "password_needs_digit",
+	ErrPasswordNeedsSymbol:    "password_needs_symbol",
+	ErrPasswordEqualsEmail:    "password_equals_email",
+	ErrPasswordTooCommon:      "password_too_common",
+	ErrUserNotFound:           "user_not_found",
+	ErrInternalServer:         "internal_error",
+	ErrInvalidEmailOrPassword: "invalid_credentials",
+	ErrUserInactive:           "user_inactive",
+	ErrTooManyAttempts:        "too_many_attempts",
+	ErrFailedToHashPassword:   "internal_error",
+	ErrSessionNotFound:        "session_not_found",
+	ErrInvalidRefreshToken:    "refresh_token_invalid",
+	ErrInvalidResetToken:      "reset_token_invalid",
+	ErrTwoFactorNotFound:      "two_factor_not_set_up",
+	ErrTwoFactorEnabled:       "two_factor_already_enabled",
+	ErrInvalidTwoFactorCode:   "two_factor_code_invalid",
+	ErrInvalidPreAuthToken:    "pre_auth_token_invalid",
+	ErrInvalidRole:            "role_invalid",
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxFailedLogins is the number of consecutive wrong passwords after which an account is locked
+const MaxFailedLogins = 5
+
+// LockoutDuration is how long an account stays locked after too many failed logins
+const LockoutDuration = 15 * time.Minute
+
+// LoginThrottle slows down repeated failed logins per key, such as an email or an IP address
+type LoginThrottle interface {
+	// RetryAfter returns how long the key has to wait before the next attempt, zero if none
+	RetryAfter(key string, now time.Time) time.Duration
+	Fail(key string, now time.Time)
+	Reset(key string)
+}
+
+// TooManyAttemptsError is returned while a login is throttled. Locked accounts answer like
+// wrong credentials so that they do not reveal which emails are registered.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("%s: retry in %d seconds", ErrTooManyAttempts, int(e.RetryAfter.Seconds()+0.5))
+}
+
+func (e *TooManyAttemptsError) Unwrap() error {
+	return ErrTooManyAttempts
+}
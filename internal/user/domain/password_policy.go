@@ -0,0 +1,97 @@
+package domain
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords holds the bundled list of frequently used and breached passwords in lower case
+var commonPasswords = func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}()
+
+// PasswordPolicy describes the passwords users may choose
+type PasswordPolicy struct {
+	MinLength int
+	// MaxLength is capped at 72 bytes since bcrypt ignores everything after that
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    10,
+		MaxLength:    72,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}
+}
+
+// Validate checks the password of the user with the given email against the policy
+func (p PasswordPolicy) Validate(password, email string) error {
+	if len([]rune(password)) < p.MinLength {
+		return ErrPasswordTooShort
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return ErrPasswordTooLong
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		return ErrPasswordNeedsUpper
+	}
+	if p.RequireLower && !lower {
+		return ErrPasswordNeedsLower
+	}
+	if p.RequireDigit && !digit {
+		return ErrPasswordNeedsDigit
+	}
+	if p.RequireSymbol && !symbol {
+		return ErrPasswordNeedsSymbol
+	}
+
+	if email != "" && strings.EqualFold(strings.TrimSpace(password), strings.TrimSpace(email)) {
+		return ErrPasswordEqualsEmail
+	}
+	if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+		return ErrPasswordTooCommon
+	}
+
+	return nil
+}
+
+// PasswordHasher hashes new passwords and verifies stored hashes of every supported algorithm
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) (bool, error)
+	// NeedsRehash reports whether the hash uses another algorithm or weaker parameters than configured
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	tests := []struct {
+		name     string
+		password string
+		email    string
+		err      error
+	}{
+		{"valid", "Correct7Horse", "john@example.com", nil},
+		{"too short", "Ab1defg", "", ErrPasswordTooShort},
+		{"too long", "Ab1" + string(make([]byte, 70)), "", ErrPasswordTooLong},
+		{"no upper", "correct7horse", "", ErrPasswordNeedsUpper},
+		{"no lower", "CORRECT7HORSE", "", ErrPasswordNeedsLower},
+		{"no digit", "CorrectHorse", "", ErrPasswordNeedsDigit},
+		{"common", "Password123", "", ErrPasswordTooCommon},
+		{"common with year", "Summer2024!", "", ErrPasswordTooCommon},
+		{"equals email", "John1@Example.com", "john1@example.com", ErrPasswordEqualsEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assertions
+			assert.Equal(t, tt.err, policy.Validate(tt.password, tt.email))
+		})
+	}
+}
+
+func TestPasswordPolicy_Validate_RequireSymbol(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.RequireSymbol = true
+
+	// Assertions
+	assert.Equal(t, ErrPasswordNeedsSymbol, policy.Validate("Correct7Horse", ""))
+	assert.NoError(t, policy.Validate("Correct7Horse!", ""))
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+// PasswordReset is a single-use token that lets a user choose a new password.
+// Only the hash of the token is stored.
+type PasswordReset struct {
+	Id        string
+	UserId    string
+	TokenHash string
+	CreatedAt uint64
+	ExpiresAt uint64
+	UsedAt    *uint64
+}
+
+func NewPasswordReset(id, userId, tokenHash string, createdAt, expiresAt uint64) *PasswordReset {
+	return &PasswordReset{
+		Id:        id,
+		UserId:    userId,
+		TokenHash: tokenHash,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
+type PasswordResetRepository interface {
+	// Create stores the reset and invalidates earlier unused resets of the same user
+	Create(ctx context.Context, reset *PasswordReset) error
+	// GetUserId returns the user id of an unused, unexpired reset without using it up
+	GetUserId(ctx context.Context, tokenHash string, now uint64) (string, error)
+	// Consume marks the unused, unexpired reset with the token hash as used and returns its user id
+	Consume(ctx context.Context, tokenHash string, now uint64) (string, error)
+}
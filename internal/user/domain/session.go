@@ -0,0 +1,39 @@
+package domain
+
+// Session is a login of a user on one device. It holds the hash of the current
+// refresh token, which is replaced every time the token is refreshed.
+type Session struct {
+	Id               string  `json:"id"`
+	UserId           string  `json:"user_id"`
+	RefreshTokenHash string  `json:"-"`
+	CreatedAt        uint64  `json:"created_at"`
+	ExpiresAt        uint64  `json:"expires_at"`
+	RevokedAt        *uint64 `json:"revoked_at,omitempty"`
+}
+
+func NewSession(id, userId, refreshTokenHash string, createdAt, expiresAt uint64) *Session {
+	return &Session{
+		Id:               id,
+		UserId:           userId,
+		RefreshTokenHash: refreshTokenHash,
+		CreatedAt:        createdAt,
+		ExpiresAt:        expiresAt,
+	}
+}
+
+// Tokens are issued on login and on every refresh
+type Tokens struct {
+	AccessToken      string
+	AccessExpiresAt  uint64
+	RefreshToken     string
+	RefreshExpiresAt uint64
+}
+
+// LoginResult holds the tokens of the new session, or a pre-auth token when the user
+// still has to pass or set up two-factor authentication
+type LoginResult struct {
+	Tokens                      *Tokens
+	PreAuthToken                string
+	TwoFactorRequired           bool
+	TwoFactorEnrollmentRequired bool
+}
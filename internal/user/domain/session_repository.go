@@ -0,0 +1,14 @@
+package domain
+
+import "context"
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetById(ctx context.Context, id string) (*Session, error)
+	// Rotate replaces the refresh token hash of an active session when oldHash is still
+	// the current one and reports whether the session was updated
+	Rotate(ctx context.Context, id, oldHash, newHash string, expiresAt, now uint64) (bool, error)
+	IsActive(ctx context.Context, id string, now uint64) (bool, error)
+	Revoke(ctx context.Context, id string, now uint64) error
+	RevokeAllForUser(ctx context.Context, userId string, now uint64) (int64, error)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "context"
+
+// RecoveryCodeCount is the number of recovery codes issued when two-factor authentication is enabled
+const RecoveryCodeCount = 10
+
+// TwoFactor holds the TOTP secret of a user. It only protects logins once the user
+// confirmed the enrollment with a valid code.
+type TwoFactor struct {
+	UserId      string
+	Secret      string
+	CreatedAt   uint64
+	ConfirmedAt *uint64
+	// LastUsedStep is the last accepted TOTP time step, codes of this or earlier steps are rejected
+	LastUsedStep int64
+}
+
+func NewTwoFactor(userId, secret string, createdAt uint64) *TwoFactor {
+	return &TwoFactor{
+		UserId:    userId,
+		Secret:    secret,
+		CreatedAt: createdAt,
+	}
+}
+
+func (t *TwoFactor) Enabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+// TwoFactorPolicy tells whether users of a role must use two-factor authentication
+type TwoFactorPolicy struct {
+	Role     string `json:"role"`
+	Required bool   `json:"required"`
+}
+
+type TwoFactorRepository interface {
+	GetByUserId(ctx context.Context, userId string) (*TwoFactor, error)
+	// SavePending stores a new unconfirmed secret, replacing an earlier unconfirmed one.
+	// It fails when the user already has two-factor authentication enabled.
+	SavePending(ctx context.Context, twoFactor *TwoFactor) error
+	// Confirm enables two-factor authentication and replaces the recovery codes
+	Confirm(ctx context.Context, userId string, step int64, recoveryCodeHashes []string, now uint64) error
+	// UseStep records the accepted time step and reports false when it was not newer than the last one
+	UseStep(ctx context.Context, userId string, step int64) (bool, error)
+	// UseRecoveryCode marks an unused recovery code as used and reports whether one matched
+	UseRecoveryCode(ctx context.Context, userId, codeHash string, now uint64) (bool, error)
+	// Delete removes the secret and the recovery codes of the user
+	Delete(ctx context.Context, userId string) error
+	GetPolicies(ctx context.Context) ([]TwoFactorPolicy, error)
+	IsRequired(ctx context.Context, role string) (bool, error)
+	SetRequired(ctx context.Context, role string, required bool) error
+}
+
+// TwoFactorEnrollment is returned when a user starts setting up two-factor authentication
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorConfirmation is returned once, when two-factor authentication is enabled.
+// Tokens is only set when the enrollment completed a login.
+type TwoFactorConfirmation struct {
+	RecoveryCodes []string
+	Tokens        *Tokens
+}
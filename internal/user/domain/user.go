@@ -11,6 +11,9 @@ type User struct {
 	PasswordHash string `json:"password_hash"`
 	CreatedAt    uint64 `json:"created_at"`
 	Active       bool   `json:"active"`
+
+	FailedLoginAttempts int     `json:"-"`
+	LockedUntil         *uint64 `json:"locked_until,omitempty"`
 }
 
 // NewAdmin Factory method to create an Admin
@@ -27,7 +30,7 @@ func NewAdmin(id, firstName, lastName, email, password string, createdAt uint64,
 	}
 }
 
-// NewManager Factory method to create a Moderator
+// NewManager Factory method to create a Manager
 func NewManager(id, firstName, lastName, email, password string, createdAt uint64, active bool) *User {
 	return &User{
 		Id:           id,
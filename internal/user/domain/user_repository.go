@@ -1,15 +1,30 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+)
+
+type UserFilter struct {
+	Active *bool
+	Search string
+}
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) (*User, error)
-	GetAllWithRole(ctx context.Context, role string) ([]User, error)
+	GetAllWithRole(ctx context.Context, role string, filter UserFilter, page pagination.Request) ([]User, int, error)
+	GetById(ctx context.Context, id string) (*User, error)
 	GetByIdWithRole(ctx context.Context, id, role string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, id, firstName, lastName string) (*User, error)
 	ChangePassword(ctx context.Context, id, password string) error
 	ChangeEmail(ctx context.Context, id, email string) error
+	// ToggleStatus activates or deactivates a user. Deactivating also revokes all of their sessions.
 	ToggleStatus(ctx context.Context, id string, status bool) (bool, error)
+	// RecordFailedLogin counts a wrong password and locks the account until lockUntil once
+	// MaxFailedLogins is reached. It returns the end of the lock when one was set.
+	RecordFailedLogin(ctx context.Context, id string, lockUntil uint64) (*uint64, error)
+	// Unlock clears the failed login count and any lock of the account
+	Unlock(ctx context.Context, id string) error
 	Delete(ctx context.Context, id string) error
 }
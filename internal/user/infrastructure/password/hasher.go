@@ -0,0 +1,89 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"time-management/internal/shared/config"
+	"time-management/internal/user/domain"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown password hashing algorithm")
+	ErrUnsupportedHash  = errors.New("unsupported password hash")
+)
+
+// Hasher hashes new passwords with the configured algorithm and verifies hashes of
+// both algorithms, so that switching the algorithm keeps existing passwords working
+// until they are rehashed on the next login
+type Hasher struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+func NewHasher(algorithm string, bcryptCost int, argon2 Argon2Params) *Hasher {
+	return &Hasher{Algorithm: algorithm, BcryptCost: bcryptCost, Argon2: argon2}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.Algorithm == AlgorithmArgon2id {
+		return hashArgon2id(password, h.Argon2)
+	}
+	return hashBcrypt(password, h.BcryptCost)
+}
+
+func (h *Hasher) Verify(hash, password string) (bool, error) {
+	switch {
+	case isArgon2id(hash):
+		return verifyArgon2id(hash, password)
+	case isBcrypt(hash):
+		return verifyBcrypt(hash, password)
+	default:
+		return false, ErrUnsupportedHash
+	}
+}
+
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if h.Algorithm == AlgorithmArgon2id {
+		if !isArgon2id(hash) {
+			return true
+		}
+		params, err := argon2idParams(hash)
+		return err != nil || params.weakerThan(h.Argon2)
+	}
+
+	if !isBcrypt(hash) {
+		return true
+	}
+	cost, err := bcryptCost(hash)
+	return err != nil || cost < h.BcryptCost
+}
+
+// NewHasherFromConfig builds the hasher selected by PASSWORD_HASHER with the configured costs
+func NewHasherFromConfig(cfg config.Password) (*Hasher, error) {
+	if cfg.Hasher != AlgorithmBcrypt && cfg.Hasher != AlgorithmArgon2id {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, cfg.Hasher)
+	}
+
+	params := Argon2Params{
+		Memory:  uint32(cfg.Argon2Memory),
+		Time:    uint32(cfg.Argon2Time),
+		Threads: uint8(cfg.Argon2Threads),
+	}
+
+	return NewHasher(cfg.Hasher, cfg.BcryptCost, params), nil
+}
+
+// PolicyFromConfig returns the default password policy with the configured minimum length and symbol rule
+func PolicyFromConfig(cfg config.Password) domain.PasswordPolicy {
+	policy := domain.DefaultPasswordPolicy()
+	policy.MinLength = cfg.MinLength
+	policy.RequireSymbol = cfg.RequireSymbol
+
+	return policy
+}
@@ -0,0 +1,77 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Low parameters keep the tests fast, production values come from the environment
+var testArgon2 = Argon2Params{Memory: 1024, Time: 1, Threads: 1}
+
+func TestHasher_Bcrypt(t *testing.T) {
+	hasher := NewHasher(AlgorithmBcrypt, bcrypt.MinCost, testArgon2)
+
+	// Execute test
+	hash, err := hasher.Hash("Correct7Horse")
+
+	// Assertions
+	assert.NoError(t, err)
+	ok, err := hasher.Verify(hash, "Correct7Horse")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = hasher.Verify(hash, "Wrong7Horse")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, hasher.NeedsRehash(hash))
+}
+
+func TestHasher_Argon2id(t *testing.T) {
+	hasher := NewHasher(AlgorithmArgon2id, bcrypt.MinCost, testArgon2)
+
+	// Execute test
+	hash, err := hasher.Hash("Correct7Horse")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+	ok, err := hasher.Verify(hash, "Correct7Horse")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = hasher.Verify(hash, "Wrong7Horse")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, hasher.NeedsRehash(hash))
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	weak, err := bcrypt.GenerateFromPassword([]byte("Correct7Horse"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	argon2Hash, err := hashArgon2id("Correct7Horse", testArgon2)
+	assert.NoError(t, err)
+
+	stronger := NewHasher(AlgorithmBcrypt, bcrypt.MinCost+1, testArgon2)
+	argon2Hasher := NewHasher(AlgorithmArgon2id, bcrypt.MinCost, Argon2Params{Memory: 2048, Time: 1, Threads: 1})
+
+	// Assertions
+	assert.True(t, stronger.NeedsRehash(string(weak)))
+	assert.True(t, stronger.NeedsRehash(argon2Hash))
+	assert.True(t, argon2Hasher.NeedsRehash(string(weak)))
+	assert.True(t, argon2Hasher.NeedsRehash(argon2Hash))
+
+	// Hashes of the other algorithm still verify after switching
+	ok, err := argon2Hasher.Verify(string(weak), "Correct7Horse")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestHasher_Verify_Unsupported(t *testing.T) {
+	hasher := NewHasher(AlgorithmBcrypt, bcrypt.MinCost, testArgon2)
+
+	// Execute test
+	_, err := hasher.Verify("plain", "plain")
+
+	// Assertions
+	assert.ErrorIs(t, err, ErrUnsupportedHash)
+}
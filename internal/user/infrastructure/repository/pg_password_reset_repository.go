@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+const PasswordResetTableName = "password_resets"
+
+type PgPasswordResetRepository struct {
+	DB *sql.DB
+}
+
+func NewPgPasswordResetRepository(db *sql.DB) *PgPasswordResetRepository {
+	return &PgPasswordResetRepository{DB: db}
+}
+
+func (r *PgPasswordResetRepository) Create(ctx context.Context, reset *domain.PasswordReset) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Only the latest reset link of a user stays valid
+	query := fmt.Sprintf(
+		`UPDATE %s SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL`,
+		PasswordResetTableName,
+	)
+	if _, err := tx.ExecContext(ctx, query, reset.CreatedAt, reset.UserId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(`
+		INSERT INTO %s (id, user_id, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, PasswordResetTableName)
+
+	_, err = tx.ExecContext(ctx, query, reset.Id, reset.UserId, reset.TokenHash, reset.CreatedAt, reset.ExpiresAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PgPasswordResetRepository) GetUserId(ctx context.Context, tokenHash string, now uint64) (string, error) {
+	query := fmt.Sprintf(`
+		SELECT user_id FROM %s
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+	`, PasswordResetTableName)
+
+	var userId string
+	err := r.DB.QueryRowContext(ctx, query, tokenHash, now).Scan(&userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", util.NewValidationError(domain.ErrInvalidResetToken)
+		}
+		return "", err
+	}
+
+	return userId, nil
+}
+
+func (r *PgPasswordResetRepository) Consume(ctx context.Context, tokenHash string, now uint64) (string, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET used_at = $1
+		WHERE token_hash = $2 AND used_at IS NULL AND expires_at > $1
+		RETURNING user_id
+	`, PasswordResetTableName)
+
+	var userId string
+	err := r.DB.QueryRowContext(ctx, query, now, tokenHash).Scan(&userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", util.NewValidationError(domain.ErrInvalidResetToken)
+		}
+		return "", err
+	}
+
+	return userId, nil
+}
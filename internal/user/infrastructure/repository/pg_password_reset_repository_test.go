@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time-management/internal/user/domain"
+)
+
+func TestPgPasswordResetRepository_Create(t *testing.T) {
+	mock, repo := setupMockAndPasswordResetRepo(t)
+
+	reset := domain.NewPasswordReset("reset123", "user123", "hash", 1000, 4600)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE password_resets SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL`)).
+		WithArgs(reset.CreatedAt, reset.UserId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO password_resets`)).
+		WithArgs(reset.Id, reset.UserId, reset.TokenHash, reset.CreatedAt, reset.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// Execute test
+	err := repo.Create(context.Background(), reset)
+
+	// Assertions
+	assert.NoError(t, err)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgPasswordResetRepository_GetUserId_Expired(t *testing.T) {
+	mock, repo := setupMockAndPasswordResetRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT user_id FROM password_resets
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2`)).
+		WithArgs("hash", uint64(2000)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	// Execute test
+	_, err := repo.GetUserId(context.Background(), "hash", 2000)
+
+	// Assertions
+	assert.True(t, errors.Is(err, domain.ErrInvalidResetToken))
+	assertMockExpectations(t, mock)
+}
+
+func TestPgPasswordResetRepository_Consume(t *testing.T) {
+	mock, repo := setupMockAndPasswordResetRepo(t)
+
+	query := `
+		UPDATE password_resets SET used_at = $1
+		WHERE token_hash = $2 AND used_at IS NULL AND expires_at > $1
+		RETURNING user_id`
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(uint64(2000), "hash").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user123"))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(uint64(2000), "hash").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	// Execute test
+	ctx := context.Background()
+	userId, err := repo.Consume(ctx, "hash", 2000)
+	_, reuseErr := repo.Consume(ctx, "hash", 2000)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", userId)
+	assert.ErrorIs(t, reuseErr, domain.ErrInvalidResetToken)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndPasswordResetRepo(t *testing.T) (sqlmock.Sqlmock, *PgPasswordResetRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return mock, NewPgPasswordResetRepository(db)
+}
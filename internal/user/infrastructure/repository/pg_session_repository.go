@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+const SessionTableName = "sessions"
+
+type PgSessionRepository struct {
+	DB *sql.DB
+}
+
+func NewPgSessionRepository(db *sql.DB) *PgSessionRepository {
+	return &PgSessionRepository{DB: db}
+}
+
+func (r *PgSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, user_id, refresh_token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, SessionTableName)
+
+	_, err := r.DB.ExecContext(
+		ctx,
+		query,
+		session.Id,
+		session.UserId,
+		session.RefreshTokenHash,
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PgSessionRepository) GetById(ctx context.Context, id string) (*domain.Session, error) {
+	query := fmt.Sprintf(`
+		SELECT id, user_id, refresh_token_hash, created_at, expires_at, revoked_at
+		FROM %s WHERE id = $1
+	`, SessionTableName)
+
+	var session domain.Session
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&session.Id,
+		&session.UserId,
+		&session.RefreshTokenHash,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrSessionNotFound)
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *PgSessionRepository) Rotate(
+	ctx context.Context,
+	id, oldHash, newHash string,
+	expiresAt, now uint64,
+) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET refresh_token_hash = $1, expires_at = $2
+		WHERE id = $3 AND refresh_token_hash = $4 AND revoked_at IS NULL AND expires_at > $5
+	`, SessionTableName)
+
+	result, err := r.DB.ExecContext(ctx, query, newHash, expiresAt, id, oldHash, now)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *PgSessionRepository) IsActive(ctx context.Context, id string, now uint64) (bool, error) {
+	query := fmt.Sprintf(`
+		SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2)
+	`, SessionTableName)
+
+	var active bool
+	if err := r.DB.QueryRowContext(ctx, query, id, now).Scan(&active); err != nil {
+		return false, err
+	}
+
+	return active, nil
+}
+
+func (r *PgSessionRepository) Revoke(ctx context.Context, id string, now uint64) error {
+	query := fmt.Sprintf(`UPDATE %s SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, SessionTableName)
+
+	_, err := r.DB.ExecContext(ctx, query, now, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PgSessionRepository) RevokeAllForUser(ctx context.Context, userId string, now uint64) (int64, error) {
+	query := fmt.Sprintf(
+		`UPDATE %s SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`,
+		SessionTableName,
+	)
+
+	result, err := r.DB.ExecContext(ctx, query, now, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time-management/internal/user/domain"
+)
+
+func TestPgSessionRepository_Create(t *testing.T) {
+	mock, repo := setupMockAndSessionRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO sessions`)).
+		WithArgs(sess.Id, sess.UserId, sess.RefreshTokenHash, sess.CreatedAt, sess.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Execute test
+	err := repo.Create(context.Background(), &sess)
+
+	// Assertions
+	assert.NoError(t, err)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgSessionRepository_GetById_NotFound(t *testing.T) {
+	mock, repo := setupMockAndSessionRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT id, user_id, refresh_token_hash, created_at, expires_at, revoked_at
+		FROM sessions WHERE id = $1`)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "refresh_token_hash", "created_at", "expires_at", "revoked_at",
+		}))
+
+	// Execute test
+	session, err := repo.GetById(context.Background(), "missing")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+	assert.Nil(t, session)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgSessionRepository_Rotate(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		rotated      bool
+	}{
+		{name: "current token", rowsAffected: 1, rotated: true},
+		{name: "reused token", rowsAffected: 0, rotated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, repo := setupMockAndSessionRepo(t)
+
+			mock.ExpectExec(regexp.QuoteMeta(`
+				UPDATE sessions SET refresh_token_hash = $1, expires_at = $2
+				WHERE id = $3 AND refresh_token_hash = $4 AND revoked_at IS NULL AND expires_at > $5`)).
+				WithArgs("new-hash", uint64(2000), sess.Id, sess.RefreshTokenHash, uint64(1000)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			// Execute test
+			rotated, err := repo.Rotate(context.Background(), sess.Id, sess.RefreshTokenHash, "new-hash", 2000, 1000)
+
+			// Assertions
+			assert.NoError(t, err)
+			assert.Equal(t, tt.rotated, rotated)
+			assertMockExpectations(t, mock)
+		})
+	}
+}
+
+func TestPgSessionRepository_IsActive(t *testing.T) {
+	mock, repo := setupMockAndSessionRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT EXISTS(SELECT 1 FROM sessions WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2)`,
+	)).
+		WithArgs(sess.Id, uint64(1000)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	// Execute test
+	active, err := repo.IsActive(context.Background(), sess.Id, 1000)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgSessionRepository_RevokeAllForUser(t *testing.T) {
+	mock, repo := setupMockAndSessionRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`)).
+		WithArgs(uint64(1000), sess.UserId).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// Execute test
+	revoked, err := repo.RevokeAllForUser(context.Background(), sess.UserId, 1000)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), revoked)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndSessionRepo(t *testing.T) (sqlmock.Sqlmock, *PgSessionRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return mock, NewPgSessionRepository(db)
+}
+
+var sess = domain.Session{
+	Id:               "session123",
+	UserId:           "user123",
+	RefreshTokenHash: "hash",
+	CreatedAt:        123456789,
+	ExpiresAt:        124061589,
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+const TwoFactorTableName = "user_two_factor"
+const RecoveryCodeTableName = "user_recovery_codes"
+const TwoFactorPolicyTableName = "two_factor_policies"
+
+type PgTwoFactorRepository struct {
+	DB *sql.DB
+}
+
+func NewPgTwoFactorRepository(db *sql.DB) *PgTwoFactorRepository {
+	return &PgTwoFactorRepository{DB: db}
+}
+
+func (r *PgTwoFactorRepository) GetByUserId(ctx context.Context, userId string) (*domain.TwoFactor, error) {
+	query := fmt.Sprintf(`
+		SELECT user_id, secret, created_at, confirmed_at, last_used_step
+		FROM %s WHERE user_id = $1
+	`, TwoFactorTableName)
+
+	var twoFactor domain.TwoFactor
+	err := r.DB.QueryRowContext(ctx, query, userId).Scan(
+		&twoFactor.UserId,
+		&twoFactor.Secret,
+		&twoFactor.CreatedAt,
+		&twoFactor.ConfirmedAt,
+		&twoFactor.LastUsedStep,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrTwoFactorNotFound)
+		}
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+func (r *PgTwoFactorRepository) SavePending(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at
+		WHERE %[1]s.confirmed_at IS NULL
+	`, TwoFactorTableName)
+
+	result, err := r.DB.ExecContext(ctx, query, twoFactor.UserId, twoFactor.Secret, twoFactor.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return util.NewConflictError(domain.ErrTwoFactorEnabled)
+	}
+
+	return nil
+}
+
+func (r *PgTwoFactorRepository) Confirm(
+	ctx context.Context,
+	userId string,
+	step int64,
+	recoveryCodeHashes []string,
+	now uint64,
+) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s SET confirmed_at = $1, last_used_step = $2
+		WHERE user_id = $3 AND confirmed_at IS NULL
+	`, TwoFactorTableName)
+
+	result, err := tx.ExecContext(ctx, query, now, step, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return util.NewConflictError(domain.ErrTwoFactorEnabled)
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userId, recoveryCodeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userId string, hashes []string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, RecoveryCodeTableName)
+	if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (user_id, code_hash) VALUES ($1, $2)`, RecoveryCodeTableName)
+	for _, hash := range hashes {
+		if _, err := tx.ExecContext(ctx, query, userId, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PgTwoFactorRepository) UseStep(ctx context.Context, userId string, step int64) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET last_used_step = $1
+		WHERE user_id = $2 AND confirmed_at IS NOT NULL AND last_used_step < $1
+	`, TwoFactorTableName)
+
+	result, err := r.DB.ExecContext(ctx, query, step, userId)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *PgTwoFactorRepository) UseRecoveryCode(ctx context.Context, userId, codeHash string, now uint64) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`, RecoveryCodeTableName)
+
+	result, err := r.DB.ExecContext(ctx, query, now, userId, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *PgTwoFactorRepository) Delete(ctx context.Context, userId string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, RecoveryCodeTableName)
+	if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1`, TwoFactorTableName)
+	result, err := tx.ExecContext(ctx, query, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return util.NewNotFoundError(domain.ErrTwoFactorNotFound)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PgTwoFactorRepository) GetPolicies(ctx context.Context) ([]domain.TwoFactorPolicy, error) {
+	query := fmt.Sprintf(`SELECT role, required FROM %s ORDER BY role`, TwoFactorPolicyTableName)
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := make([]domain.TwoFactorPolicy, 0)
+	for rows.Next() {
+		var policy domain.TwoFactorPolicy
+		if err := rows.Scan(&policy.Role, &policy.Required); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+func (r *PgTwoFactorRepository) IsRequired(ctx context.Context, role string) (bool, error) {
+	query := fmt.Sprintf(`SELECT required FROM %s WHERE role = $1`, TwoFactorPolicyTableName)
+
+	var required bool
+	err := r.DB.QueryRowContext(ctx, query, role).Scan(&required)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return required, nil
+}
+
+func (r *PgTwoFactorRepository) SetRequired(ctx context.Context, role string, required bool) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (role, required) VALUES ($1, $2)
+		ON CONFLICT (role) DO UPDATE SET required = EXCLUDED.required
+	`, TwoFactorPolicyTableName)
+
+	_, err := r.DB.ExecContext(ctx, query, role, required)
+	return err
+}
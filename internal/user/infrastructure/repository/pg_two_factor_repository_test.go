@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time-management/internal/user/domain"
+)
+
+func TestPgTwoFactorRepository_SavePending_AlreadyEnabled(t *testing.T) {
+	mock, repo := setupMockAndTwoFactorRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_two_factor`)).
+		WithArgs("user123", "SECRET", uint64(123456789)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute test
+	err := repo.SavePending(context.Background(), domain.NewTwoFactor("user123", "SECRET", 123456789))
+
+	// Assertions
+	assert.True(t, errors.Is(err, domain.ErrTwoFactorEnabled))
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTwoFactorRepository_Confirm(t *testing.T) {
+	mock, repo := setupMockAndTwoFactorRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`
+		UPDATE user_two_factor SET confirmed_at = $1, last_used_step = $2
+		WHERE user_id = $3 AND confirmed_at IS NULL`)).
+		WithArgs(uint64(123456789), int64(4115226), "user123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM user_recovery_codes WHERE user_id = $1`)).
+		WithArgs("user123").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, hash := range []string{"hash1", "hash2"} {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`)).
+			WithArgs("user123", hash).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	// Execute test
+	err := repo.Confirm(context.Background(), "user123", 4115226, []string{"hash1", "hash2"}, 123456789)
+
+	// Assertions
+	assert.NoError(t, err)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTwoFactorRepository_UseStep_Replay(t *testing.T) {
+	mock, repo := setupMockAndTwoFactorRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`
+		UPDATE user_two_factor SET last_used_step = $1
+		WHERE user_id = $2 AND confirmed_at IS NOT NULL AND last_used_step < $1`)).
+		WithArgs(int64(4115226), "user123").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute test
+	used, err := repo.UseStep(context.Background(), "user123", 4115226)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, used)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgTwoFactorRepository_IsRequired_NoPolicy(t *testing.T) {
+	mock, repo := setupMockAndTwoFactorRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT required FROM two_factor_policies WHERE role = $1`)).
+		WithArgs("manager").
+		WillReturnRows(sqlmock.NewRows([]string{"required"}))
+
+	// Execute test
+	required, err := repo.IsRequired(context.Background(), "manager")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, required)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndTwoFactorRepo(t *testing.T) (sqlmock.Sqlmock, *PgTwoFactorRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return mock, NewPgTwoFactorRepository(db)
+}
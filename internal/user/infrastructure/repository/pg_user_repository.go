@@ -6,9 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
-	"os"
+	"log/slog"
+	"strings"
 	"time"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
 	"time-management/internal/user/domain"
 	"time-management/internal/user/role"
@@ -16,54 +17,45 @@ import (
 
 const TableName = "users"
 
+// columns lists the user columns in the order ScanUserRow and ScanUserRows expect them
+const columns = `id, first_name, last_name, email, role, password_hashed, created_at, active,
+	failed_login_attempts, locked_until`
+
+// sortColumns maps the sortable user fields to their columns
+var sortColumns = map[string]string{
+	"first_name": "first_name",
+	"last_name":  "last_name",
+	"email":      "email",
+	"created_at": "created_at",
+}
+
 type PgUserRepository struct {
 	DB *sql.DB
 }
 
 func NewPgUsersRepository(db *sql.DB) *PgUserRepository {
-	repository := &PgUserRepository{DB: db}
-
-	err := repository.createUsersTable()
-	if err != nil {
-		panic(err)
-		return nil
-	}
-
-	return repository
-}
-
-func (r *PgUserRepository) createUsersTable() error {
-	query := fmt.Sprintf(`create table if not exists %s (
-		id varchar(50) primary key,
-		first_name varchar(50),
-		last_name varchar(50),
-		email varchar(50),
-    	role varchar(50),
-		password_hashed varchar,
-		created_at serial,
-		active boolean
-	)`, TableName)
-
-	_, err := r.DB.Exec(query)
-
-	err = r.createSuperAdmin()
-
-	return err
+	return &PgUserRepository{DB: db}
 }
 
-func (r *PgUserRepository) createSuperAdmin() error {
+// CreateSuperAdmin seeds the super admin account from the configuration unless one already exists.
+// The password is only hashed when the account is created.
+func (r *PgUserRepository) CreateSuperAdmin(
+	ctx context.Context,
+	email, password string,
+	hasher domain.PasswordHasher,
+) error {
 	// First, check if a super-admin already exists
 	checkQuery := fmt.Sprintf(`SELECT id FROM %s WHERE role = $1 LIMIT 1`, TableName)
 
 	var superAdminId string
-	err := r.DB.QueryRow(checkQuery, role.SuperAdmin.String()).Scan(&superAdminId)
+	err := r.DB.QueryRowContext(ctx, checkQuery, role.SuperAdmin.String()).Scan(&superAdminId)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) { // If there's an error that's not "no rows"
 		return fmt.Errorf("failed to check for existing super admin: %v", err)
 	}
 
 	// If super-admin already exists, return without doing anything
 	if superAdminId != "" {
-		fmt.Println("Super admin already exists. Skipping creation.")
+		slog.InfoContext(ctx, "super admin already exists, skipping creation")
 		return nil
 	}
 
@@ -73,25 +65,22 @@ func (r *PgUserRepository) createSuperAdmin() error {
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
 	`, TableName)
 
-	// Fetch email and password from environment variables
-	password := os.Getenv("SUPER_ADMIN_PASSWORD")
-	email := os.Getenv("SUPER_ADMIN_EMAIL")
-
 	// Hash the password
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	hash, err := hasher.Hash(password)
 	if err != nil {
 		return domain.ErrFailedToHashPassword
 	}
 
 	// Insert the new super admin user
-	_, err = r.DB.Exec(
+	_, err = r.DB.ExecContext(
+		ctx,
 		query,
 		uuid.New().String(),
 		"Super",
 		"Admin",
 		email,
 		role.SuperAdmin.String(),
-		string(hash),
+		hash,
 		uint64(time.Now().Unix()),
 		true,
 	)
@@ -99,7 +88,7 @@ func (r *PgUserRepository) createSuperAdmin() error {
 		return fmt.Errorf("failed to create super admin: %v", err)
 	}
 
-	fmt.Println("Super admin created successfully")
+	slog.InfoContext(ctx, "super admin created")
 	return nil
 }
 
@@ -113,8 +102,8 @@ func (r *PgUserRepository) Create(ctx context.Context, user *domain.User) (*doma
 	query := fmt.Sprintf(`
 		INSERT INTO %s (id, first_name, last_name, email, role, password_hashed, created_at, active)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
-		RETURNING id, first_name, last_name, email, role, password_hashed, created_at, active
-	`, TableName)
+		RETURNING %s
+	`, TableName, columns)
 
 	row := r.DB.QueryRowContext(
 		ctx,
@@ -137,31 +126,80 @@ func (r *PgUserRepository) Create(ctx context.Context, user *domain.User) (*doma
 	return savedUser, nil
 }
 
-func (r *PgUserRepository) GetAllWithRole(ctx context.Context, role string) ([]domain.User, error) {
-	query := fmt.Sprintf(`SELECT * FROM %s WHERE role = $1`, TableName)
+func (r *PgUserRepository) GetAllWithRole(
+	ctx context.Context,
+	role string,
+	filter domain.UserFilter,
+	page pagination.Request,
+) ([]domain.User, int, error) {
+	conditions := []string{"role = $1"}
+	args := []any{role}
+	if filter.Active != nil {
+		args = append(args, *filter.Active)
+		conditions = append(conditions, fmt.Sprintf("active = $%d", len(args)))
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf(
+			"(first_name ILIKE $%[1]d OR last_name ILIKE $%[1]d OR email ILIKE $%[1]d)",
+			len(args),
+		))
+	}
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	orderBy, err := page.OrderBy(sortColumns, "last_name ASC, first_name ASC", "id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, TableName, where)
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
 
-	rows, err := r.DB.QueryContext(ctx, query, role)
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s %s %s LIMIT $%d OFFSET $%d`,
+		columns, TableName, where, orderBy, len(args)+1, len(args)+2,
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, page.Limit, page.Offset)...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
 	users, err := ScanUserRows(rows)
 	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *PgUserRepository) GetById(ctx context.Context, id string) (*domain.User, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, columns, TableName)
+
+	row := r.DB.QueryRowContext(ctx, query, id)
+	user, err := ScanUserRow(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrUserNotFound)
+		}
 		return nil, err
 	}
 
-	return users, nil
+	return user, nil
 }
 
 func (r *PgUserRepository) GetByIdWithRole(ctx context.Context, id, role string) (*domain.User, error) {
-	query := fmt.Sprintf(`SELECT * FROM %s WHERE id = $1 AND role = $2`, TableName)
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1 AND role = $2`, columns, TableName)
 
 	row := r.DB.QueryRowContext(ctx, query, id, role)
 	user, err := ScanUserRow(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, util.NewValidationError(domain.ErrUserNotFound)
+			return nil, util.NewNotFoundError(domain.ErrUserNotFound)
 		}
 		return nil, err
 	}
@@ -170,7 +208,7 @@ func (r *PgUserRepository) GetByIdWithRole(ctx context.Context, id, role string)
 }
 
 func (r *PgUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := fmt.Sprintf(`SELECT * FROM %s WHERE email = $1`, TableName)
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE email = $1`, columns, TableName)
 
 	row := r.DB.QueryRowContext(ctx, query, email)
 	user, err := ScanUserRow(row)
@@ -185,8 +223,8 @@ func (r *PgUserRepository) Update(ctx context.Context, id, firstName, lastName s
 	query := fmt.Sprintf(`
 		UPDATE %s SET first_name = $1, last_name = $2 
 	 	WHERE id = $3 
-		RETURNING id, first_name, last_name, email, role, password_hashed, created_at, active
-	`, TableName)
+		RETURNING %s
+	`, TableName, columns)
 
 	row := r.DB.QueryRowContext(ctx, query, firstName, lastName, id)
 	user, err := ScanUserRow(row)
@@ -228,17 +266,81 @@ func (r *PgUserRepository) ChangeEmail(ctx context.Context, id, email string) er
 }
 
 func (r *PgUserRepository) ToggleStatus(ctx context.Context, id string, status bool) (bool, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
 	query := fmt.Sprintf(`UPDATE %s SET active = $1 WHERE id = $2 RETURNING active`, TableName)
 
 	var newStatus bool
-	err := r.DB.QueryRowContext(ctx, query, status, id).Scan(&newStatus)
+	err = tx.QueryRowContext(ctx, query, status, id).Scan(&newStatus)
 	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	// Deactivated users are logged out everywhere so their tokens stop working at once
+	if !newStatus {
+		query := fmt.Sprintf(
+			`UPDATE %s SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`,
+			SessionTableName,
+		)
+		if _, err := tx.ExecContext(ctx, query, uint64(time.Now().Unix()), id); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
 		return false, err
 	}
 
 	return newStatus, nil
 }
 
+func (r *PgUserRepository) RecordFailedLogin(ctx context.Context, id string, lockUntil uint64) (*uint64, error) {
+	// The count starts over once the account is locked, so the user gets the full
+	// number of attempts again after the lock ends
+	query := fmt.Sprintf(`
+		UPDATE %s SET
+			locked_until = CASE WHEN failed_login_attempts + 1 >= $1 THEN $2 ELSE locked_until END,
+			failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= $1 THEN 0 ELSE failed_login_attempts + 1 END
+		WHERE id = $3
+		RETURNING locked_until
+	`, TableName)
+
+	var lockedUntil *uint64
+	err := r.DB.QueryRowContext(ctx, query, domain.MaxFailedLogins, lockUntil, id).Scan(&lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.NewNotFoundError(domain.ErrUserNotFound)
+		}
+		return nil, err
+	}
+
+	return lockedUntil, nil
+}
+
+func (r *PgUserRepository) Unlock(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`UPDATE %s SET failed_login_attempts = 0, locked_until = NULL WHERE id = $1`, TableName)
+
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return util.NewNotFoundError(domain.ErrUserNotFound)
+	}
+
+	return nil
+}
+
 func (r *PgUserRepository) Delete(ctx context.Context, id string) error {
 	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, TableName)
 
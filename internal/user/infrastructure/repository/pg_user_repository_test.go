@@ -1 +1,209 @@
 package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/user/domain"
+	"time-management/internal/user/role"
+)
+
+func TestPgUserRepository_GetAllWithRole(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE role = $1`, TableName)
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs(role.Employee.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s WHERE role = $1 ORDER BY last_name ASC, first_name ASC, id LIMIT $2 OFFSET $3`,
+		columns, TableName,
+	)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(role.Employee.String(), 50, 0).
+		WillReturnRows(userRows(usr))
+
+	// Execute test
+	ctx := context.Background()
+	users, total, err := repo.GetAllWithRole(ctx, role.Employee.String(), domain.UserFilter{}, pagination.Request{Limit: 50})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, users, 1)
+	assert.Equal(t, usr.Id, users[0].Id)
+	assert.Equal(t, usr.Email, users[0].Email)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgUserRepository_GetAllWithRole_Filter(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	where := `WHERE role = $1 AND active = $2 AND (first_name ILIKE $3 OR last_name ILIKE $3 OR email ILIKE $3)`
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, TableName, where)
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs(role.Manager.String(), true, "%doe%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	query := fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY email DESC, id LIMIT $4 OFFSET $5`, columns, TableName, where)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(role.Manager.String(), true, "%doe%", 20, 0).
+		WillReturnRows(userRows(usr))
+
+	// Execute test
+	ctx := context.Background()
+	active := true
+	filter := domain.UserFilter{Active: &active, Search: "doe"}
+	page := pagination.Request{Limit: 20, Sort: "email", Desc: true}
+	users, total, err := repo.GetAllWithRole(ctx, role.Manager.String(), filter, page)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, users, 1)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgUserRepository_GetById(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, columns, TableName)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(usr.Id).
+		WillReturnRows(userRows(usr))
+
+	// Execute test
+	user, err := repo.GetById(context.Background(), usr.Id)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, usr.Role, user.Role)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgUserRepository_ToggleStatus_Deactivate(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE users SET active = $1 WHERE id = $2 RETURNING active`)).
+		WithArgs(false, usr.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), usr.Id).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	// Execute test
+	active, err := repo.ToggleStatus(context.Background(), usr.Id, false)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgUserRepository_ToggleStatus_Activate(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE users SET active = $1 WHERE id = $2 RETURNING active`)).
+		WithArgs(true, usr.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(true))
+	mock.ExpectCommit()
+
+	// Execute test
+	active, err := repo.ToggleStatus(context.Background(), usr.Id, true)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgUserRepository_RecordFailedLogin(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	query := fmt.Sprintf(`
+		UPDATE %s SET
+			locked_until = CASE WHEN failed_login_attempts + 1 >= $1 THEN $2 ELSE locked_until END,
+			failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= $1 THEN 0 ELSE failed_login_attempts + 1 END
+		WHERE id = $3
+		RETURNING locked_until
+	`, TableName)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.MaxFailedLogins, uint64(1700000900), usr.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"locked_until"}).AddRow(uint64(1700000900)))
+
+	// Execute test
+	lockedUntil, err := repo.RecordFailedLogin(context.Background(), usr.Id, 1700000900)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1700000900), *lockedUntil)
+	assertMockExpectations(t, mock)
+}
+
+func TestPgUserRepository_Unlock(t *testing.T) {
+	mock, repo := setupMockAndRepo(t)
+
+	query := fmt.Sprintf(`UPDATE %s SET failed_login_attempts = 0, locked_until = NULL WHERE id = $1`, TableName)
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(usr.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute test
+	err := repo.Unlock(context.Background(), usr.Id)
+
+	// Assertions
+	assert.NoError(t, err)
+	assertMockExpectations(t, mock)
+}
+
+func setupMockAndRepo(t *testing.T) (sqlmock.Sqlmock, *PgUserRepository) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	repo := NewPgUsersRepository(db)
+	return mock, repo
+}
+
+// userRows builds the rows returned when selecting the user columns
+func userRows(users ...domain.User) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "first_name", "last_name", "email", "role", "password_hashed", "created_at", "active",
+		"failed_login_attempts", "locked_until",
+	})
+	for _, u := range users {
+		rows.AddRow(
+			u.Id, u.FirstName, u.LastName, u.Email, u.Role, u.PasswordHash, u.CreatedAt, u.Active,
+			u.FailedLoginAttempts, u.LockedUntil,
+		)
+	}
+
+	return rows
+}
+
+// assertMockExpectations is a helper to ensure all expectations of the mock are met
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+var usr = domain.User{
+	Id:           "user123",
+	FirstName:    "John",
+	LastName:     "Doe",
+	Email:        "john@example.com",
+	Role:         role.Employee.String(),
+	PasswordHash: "hash",
+	CreatedAt:    123456789,
+	Active:       true,
+}
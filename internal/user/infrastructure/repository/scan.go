@@ -16,6 +16,8 @@ func ScanUserRow(row *sql.Row) (*domain.User, error) {
 		&user.PasswordHash,
 		&user.CreatedAt,
 		&user.Active,
+		&user.FailedLoginAttempts,
+		&user.LockedUntil,
 	)
 	if err != nil {
 		return nil, err
@@ -38,6 +40,8 @@ func ScanUserRows(rows *sql.Rows) ([]domain.User, error) {
 			&user.PasswordHash,
 			&user.CreatedAt,
 			&user.Active,
+			&user.FailedLoginAttempts,
+			&user.LockedUntil,
 		)
 		if err != nil {
 			return nil, err
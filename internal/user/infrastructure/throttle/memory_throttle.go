@@ -0,0 +1,102 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// FreeAttempts is the number of failures allowed before any delay applies
+	FreeAttempts = 3
+	// BaseDelay is the delay after the first failure past the free attempts, doubled on each further one
+	BaseDelay = time.Second
+	// MaxDelay caps the delay between attempts
+	MaxDelay = 15 * time.Minute
+	// Window is how long failures are remembered after the last one
+	Window = time.Hour
+)
+
+type entry struct {
+	failures int
+	last     time.Time
+}
+
+// MemoryThrottle is an in-process LoginThrottle with exponential backoff.
+// State is lost on restart and not shared between instances.
+type MemoryThrottle struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+func NewMemoryThrottle() *MemoryThrottle {
+	return &MemoryThrottle{entries: make(map[string]*entry)}
+}
+
+func (t *MemoryThrottle) RetryAfter(key string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.get(key, now)
+	if e == nil || e.failures < FreeAttempts {
+		return 0
+	}
+
+	wait := e.last.Add(delay(e.failures)).Sub(now)
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+func (t *MemoryThrottle) Fail(key string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.get(key, now)
+	if e == nil {
+		t.prune(now)
+		e = &entry{}
+		t.entries[key] = e
+	}
+	e.failures++
+	e.last = now
+}
+
+func (t *MemoryThrottle) Reset(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, key)
+}
+
+func (t *MemoryThrottle) get(key string, now time.Time) *entry {
+	e, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	if now.Sub(e.last) > Window {
+		delete(t.entries, key)
+		return nil
+	}
+	return e
+}
+
+// prune drops expired entries so that the map does not grow with every guessed email
+func (t *MemoryThrottle) prune(now time.Time) {
+	for key, e := range t.entries {
+		if now.Sub(e.last) > Window {
+			delete(t.entries, key)
+		}
+	}
+}
+
+func delay(failures int) time.Duration {
+	d := BaseDelay
+	for i := FreeAttempts; i < failures; i++ {
+		d *= 2
+		if d >= MaxDelay {
+			return MaxDelay
+		}
+	}
+	return d
+}
@@ -0,0 +1,56 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryThrottle_Backoff(t *testing.T) {
+	th := NewMemoryThrottle()
+	now := time.Unix(1700000000, 0)
+
+	for i := 0; i < FreeAttempts; i++ {
+		assert.Zero(t, th.RetryAfter("ip:1.2.3.4", now))
+		th.Fail("ip:1.2.3.4", now)
+	}
+
+	// Assertions
+	assert.Equal(t, BaseDelay, th.RetryAfter("ip:1.2.3.4", now))
+	assert.Zero(t, th.RetryAfter("ip:1.2.3.4", now.Add(BaseDelay)))
+
+	th.Fail("ip:1.2.3.4", now)
+	assert.Equal(t, 2*BaseDelay, th.RetryAfter("ip:1.2.3.4", now))
+	assert.Zero(t, th.RetryAfter("ip:5.6.7.8", now))
+}
+
+func TestMemoryThrottle_MaxDelay(t *testing.T) {
+	th := NewMemoryThrottle()
+	now := time.Unix(1700000000, 0)
+
+	for i := 0; i < 30; i++ {
+		th.Fail("email:a@b.c", now)
+	}
+
+	// Assertions
+	assert.Equal(t, MaxDelay, th.RetryAfter("email:a@b.c", now))
+}
+
+func TestMemoryThrottle_ResetAndExpiry(t *testing.T) {
+	th := NewMemoryThrottle()
+	now := time.Unix(1700000000, 0)
+
+	for i := 0; i < FreeAttempts+1; i++ {
+		th.Fail("email:a@b.c", now)
+	}
+	th.Reset("email:a@b.c")
+
+	// Assertions
+	assert.Zero(t, th.RetryAfter("email:a@b.c", now))
+
+	for i := 0; i < FreeAttempts+1; i++ {
+		th.Fail("email:a@b.c", now)
+	}
+	assert.Zero(t, th.RetryAfter("email:a@b.c", now.Add(Window+time.Second)))
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+)
+
+var ErrInvalidActiveFilter = errors.New("invalid active filter: expected true or false")
+
+var ErrorCodes = map[error]string{
+	ErrInvalidActiveFilter: "active_filter_invalid",
+}
+
+// ParseUserFilter reads the active and search query parameters used by the user list endpoints
+func ParseUserFilter(r *http.Request) (domain.UserFilter, error) {
+	values := r.URL.Query()
+	filter := domain.UserFilter{Search: values.Get("search")}
+
+	if active := values.Get("active"); active != "" {
+		parsed, err := strconv.ParseBool(active)
+		if err != nil {
+			return domain.UserFilter{}, util.NewFieldError("active", ErrInvalidActiveFilter)
+		}
+		filter.Active = &parsed
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time-management/internal/shared/util"
+	userCommand "time-management/internal/user/application/command"
+	"time-management/internal/user/domain"
+)
+
+// VerifyTwoFactor is the second login step, it exchanges the pre-auth token and a code for the session cookies
+func (h *UserHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		PreAuthToken string `json:"pre_auth_token"`
+		Code         string `json:"code"`
+		RecoveryCode string `json:"recovery_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := userCommand.VerifyTwoFactorCommand{
+		PreAuthToken: req.PreAuthToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+	}
+	tokens, err := h.VerifyTwoFactorHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return writeLoginError(w, r, err)
+	}
+
+	setTokenCookies(w, tokens)
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+// EnrollTwoFactorOnLogin starts the setup for a user whose role requires two-factor authentication
+func (h *UserHandler) EnrollTwoFactorOnLogin(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		PreAuthToken string `json:"pre_auth_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := userCommand.EnrollTwoFactorCommand{PreAuthToken: req.PreAuthToken}
+	enrollment, err := h.EnrollTwoFactorHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return writeLoginError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, enrollment)
+}
+
+// ConfirmTwoFactorOnLogin enables two-factor authentication and completes the login
+func (h *UserHandler) ConfirmTwoFactorOnLogin(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		PreAuthToken string `json:"pre_auth_token"`
+		Code         string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := userCommand.ConfirmTwoFactorCommand{PreAuthToken: req.PreAuthToken, Code: req.Code}
+	confirmation, err := h.ConfirmTwoFactorHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return writeLoginError(w, r, err)
+	}
+
+	setTokenCookies(w, confirmation.Tokens)
+
+	return util.WriteJson(w, http.StatusOK, map[string][]string{"recovery_codes": confirmation.RecoveryCodes})
+}
+
+// EnrollTwoFactor starts the setup for the logged in user
+func (h *UserHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	cmd := userCommand.EnrollTwoFactorCommand{UserId: user.Id}
+	enrollment, err := h.EnrollTwoFactorHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, enrollment)
+}
+
+// ConfirmTwoFactor enables two-factor authentication for the logged in user
+func (h *UserHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) error {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
+	}
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := userCommand.ConfirmTwoFactorCommand{UserId: user.Id, Code: req.Code}
+	confirmation, err := h.ConfirmTwoFactorHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		// Too many wrong codes answer 429 with Retry-After like on login
+		var throttled *domain.TooManyAttemptsError
+		if errors.As(err, &throttled) {
+			return writeLoginError(w, r, err)
+		}
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, map[string][]string{"recovery_codes": confirmation.RecoveryCodes})
+}
+
+// ResetTwoFactor removes the two-factor authentication of a user who lost their device
+func (h *UserHandler) ResetTwoFactor(w http.ResponseWriter, r *http.Request) error {
+	userId := chi.URLParam(r, "user_id")
+
+	cmd := userCommand.ResetTwoFactorCommand{UserId: userId}
+	if err := h.ResetTwoFactorHandler.Handle(r.Context(), cmd); err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+func (h *UserHandler) GetTwoFactorPolicies(w http.ResponseWriter, r *http.Request) error {
+	policies, err := h.GetTwoFactorPoliciesHandler.Handle(r.Context())
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, policies)
+}
+
+func (h *UserHandler) SetTwoFactorPolicy(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		Required bool `json:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := userCommand.SetTwoFactorPolicyCommand{Role: chi.URLParam(r, "role"), Required: req.Required}
+	if err := h.SetTwoFactorPolicyHandler.Handle(r.Context(), cmd); err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, domain.TwoFactorPolicy{Role: cmd.Role, Required: cmd.Required})
+}
@@ -2,22 +2,105 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
+	"time-management/internal/shared/config"
+	"time-management/internal/shared/mail"
 	"time-management/internal/shared/util"
 	userCommand "time-management/internal/user/application/command"
+	userQuery "time-management/internal/user/application/query"
 	"time-management/internal/user/domain"
 )
 
 const CookieAuthName = "auth_token"
+const CookieRefreshName = "refresh_token"
 
 type UserHandler struct {
-	LoginUserHandler userCommand.LoginUserHandler
+	LoginUserHandler            userCommand.LoginUserHandler
+	RefreshTokenHandler         userCommand.RefreshTokenHandler
+	LogoutUserHandler           userCommand.LogoutUserHandler
+	RevokeUserSessionsHandler   userCommand.RevokeUserSessionsHandler
+	ForgotPasswordHandler       userCommand.ForgotPasswordHandler
+	ResetPasswordHandler        userCommand.ResetPasswordHandler
+	UnlockUserHandler           userCommand.UnlockUserHandler
+	EnrollTwoFactorHandler      userCommand.EnrollTwoFactorHandler
+	ConfirmTwoFactorHandler     userCommand.ConfirmTwoFactorHandler
+	VerifyTwoFactorHandler      userCommand.VerifyTwoFactorHandler
+	ResetTwoFactorHandler       userCommand.ResetTwoFactorHandler
+	SetTwoFactorPolicyHandler   userCommand.SetTwoFactorPolicyHandler
+	GetTwoFactorPoliciesHandler userQuery.GetTwoFactorPoliciesHandler
 }
 
-func NewUserHandler(repository domain.UserRepository) *UserHandler {
+func NewUserHandler(
+	repository domain.UserRepository,
+	sessions domain.SessionRepository,
+	resets domain.PasswordResetRepository,
+	mailer mail.Mailer,
+	twoFactor domain.TwoFactorRepository,
+	throttle domain.LoginThrottle,
+	policy domain.PasswordPolicy,
+	hasher domain.PasswordHasher,
+	cfg *config.Config,
+) *UserHandler {
+	jwtSecret := []byte(cfg.Jwt.Secret)
+
 	return &UserHandler{
-		LoginUserHandler: userCommand.LoginUserHandler{Repo: repository},
+		LoginUserHandler: userCommand.LoginUserHandler{
+			Repo:      repository,
+			Sessions:  sessions,
+			Throttle:  throttle,
+			TwoFactor: twoFactor,
+			Hasher:    hasher,
+			JwtSecret: jwtSecret,
+		},
+		RefreshTokenHandler: userCommand.RefreshTokenHandler{
+			Repo:      repository,
+			Sessions:  sessions,
+			JwtSecret: jwtSecret,
+		},
+		LogoutUserHandler:         userCommand.LogoutUserHandler{Sessions: sessions},
+		RevokeUserSessionsHandler: userCommand.RevokeUserSessionsHandler{Repo: repository, Sessions: sessions},
+		ForgotPasswordHandler: userCommand.ForgotPasswordHandler{
+			Repo:     repository,
+			Resets:   resets,
+			Mailer:   mailer,
+			ResetUrl: cfg.PasswordResetUrl,
+		},
+		ResetPasswordHandler: userCommand.ResetPasswordHandler{
+			Repo:     repository,
+			Resets:   resets,
+			Sessions: sessions,
+			Policy:   policy,
+			Hasher:   hasher,
+		},
+		UnlockUserHandler: userCommand.UnlockUserHandler{Repo: repository, Throttle: throttle},
+		EnrollTwoFactorHandler: userCommand.EnrollTwoFactorHandler{
+			Repo:      repository,
+			TwoFactor: twoFactor,
+			JwtSecret: jwtSecret,
+		},
+		ConfirmTwoFactorHandler: userCommand.ConfirmTwoFactorHandler{
+			Repo:      repository,
+			Sessions:  sessions,
+			TwoFactor: twoFactor,
+			Throttle:  throttle,
+			JwtSecret: jwtSecret,
+		},
+		VerifyTwoFactorHandler: userCommand.VerifyTwoFactorHandler{
+			Repo:      repository,
+			Sessions:  sessions,
+			TwoFactor: twoFactor,
+			Throttle:  throttle,
+			JwtSecret: jwtSecret,
+		},
+		ResetTwoFactorHandler:       userCommand.ResetTwoFactorHandler{Repo: repository, TwoFactor: twoFactor},
+		SetTwoFactorPolicyHandler:   userCommand.SetTwoFactorPolicyHandler{TwoFactor: twoFactor},
+		GetTwoFactorPoliciesHandler: userQuery.GetTwoFactorPoliciesHandler{TwoFactor: twoFactor},
 	}
 }
 
@@ -28,41 +111,198 @@ func (h *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := userCommand.LoginUserCommand{
 		Email:    req.Email,
 		Password: req.Password,
+		IP:       clientIP(r),
+	}
+	result, err := h.LoginUserHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return writeLoginError(w, r, err)
+	}
+
+	// The session only starts after the second step, the client continues with the pre-auth token
+	if result.Tokens == nil {
+		return util.WriteJson(w, http.StatusOK, map[string]any{
+			"pre_auth_token":                 result.PreAuthToken,
+			"two_factor_required":            result.TwoFactorRequired,
+			"two_factor_enrollment_required": result.TwoFactorEnrollmentRequired,
+		})
+	}
+
+	setTokenCookies(w, result.Tokens)
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+// writeLoginError maps the errors of the login steps to their responses
+func writeLoginError(w http.ResponseWriter, r *http.Request, err error) error {
+	var throttled *domain.TooManyAttemptsError
+	if errors.As(err, &throttled) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds()+0.5)))
+		code := util.ErrorCode(err, util.CodeUnauthorized)
+		return util.WriteProblem(w, r, util.NewProblem(http.StatusTooManyRequests, code, err.Error()))
+	}
+	if errors.Is(err, domain.ErrUserInactive) {
+		return util.WriteError(w, r, util.NewForbiddenError(err))
+	}
+	if errors.Is(err, domain.ErrInvalidEmailOrPassword) ||
+		errors.Is(err, domain.ErrInvalidPreAuthToken) ||
+		errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+		return util.WriteError(w, r, util.NewUnauthorizedError(err))
+	}
+	return util.WriteError(w, r, err)
+}
+
+func (h *UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) error {
+	cookie, err := r.Cookie(CookieRefreshName)
+	if err != nil {
+		return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrInvalidRefreshToken))
+	}
+
+	cmd := userCommand.RefreshTokenCommand{RefreshToken: cookie.Value}
+	tokens, err := h.RefreshTokenHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			clearTokenCookies(w)
+			return util.WriteError(w, r, util.NewUnauthorizedError(err))
+		}
+		if errors.Is(err, domain.ErrUserInactive) {
+			clearTokenCookies(w)
+			return util.WriteError(w, r, util.NewForbiddenError(err))
+		}
+		return util.WriteError(w, r, err)
+	}
+
+	setTokenCookies(w, tokens)
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+func (h *UserHandler) LogoutUser(w http.ResponseWriter, r *http.Request) error {
+	var refreshToken string
+	if cookie, err := r.Cookie(CookieRefreshName); err == nil {
+		refreshToken = cookie.Value
+	}
+
+	// The cookies are cleared even when the token is rejected, it cannot be used anyway
+	clearTokenCookies(w)
+
+	err := h.LogoutUserHandler.Handle(r.Context(), userCommand.LogoutUserCommand{RefreshToken: refreshToken})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+// UnlockUser lifts a lockout caused by too many failed logins
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) error {
+	userId := chi.URLParam(r, "user_id")
+
+	cmd := userCommand.UnlockUserCommand{UserId: userId}
+	if err := h.UnlockUserHandler.Handle(r.Context(), cmd); err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+// RevokeUserSessions logs the user out on every device
+func (h *UserHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) error {
+	userId := chi.URLParam(r, "user_id")
+
+	cmd := userCommand.RevokeUserSessionsCommand{UserId: userId}
+	revoked, err := h.RevokeUserSessionsHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, map[string]int64{"revoked": revoked})
+}
+
+// ForgotPassword always answers 202 for well formed emails, whether or not a reset link was sent
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		Email string `json:"email"`
 	}
-	token, err := h.LoginUserHandler.Handle(r.Context(), cmd)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	err := h.ForgotPasswordHandler.Handle(r.Context(), userCommand.ForgotPasswordCommand{Email: req.Email})
 	if err != nil {
-		return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: err.Error()})
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			return util.WriteError(w, r, util.InvalidBody(err))
+		}
+		slog.ErrorContext(r.Context(), "password reset failed", "error", err)
 	}
 
+	return util.WriteJson(w, http.StatusAccepted, nil)
+}
+
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := userCommand.ResetPasswordCommand{Token: req.Token, Password: req.Password}
+	if err := h.ResetPasswordHandler.Handle(r.Context(), cmd); err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, nil)
+}
+
+func setTokenCookies(w http.ResponseWriter, tokens *domain.Tokens) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     CookieAuthName,
-		Value:    *token,
+		Value:    tokens.AccessToken,
 		HttpOnly: true,
 		Secure:   true,
 		Path:     "/",
-		Expires:  time.Now().Add(userCommand.JwtExpirationTime),
+		Expires:  time.Unix(int64(tokens.AccessExpiresAt), 0),
 		SameSite: http.SameSiteStrictMode,
 	})
-
-	return util.WriteJson(w, http.StatusOK, nil)
-}
-
-func (h *UserHandler) LogoutUser(w http.ResponseWriter, r *http.Request) error {
 	http.SetCookie(w, &http.Cookie{
-		Name:     CookieAuthName,
-		Value:    "",
+		Name:     CookieRefreshName,
+		Value:    tokens.RefreshToken,
 		HttpOnly: true,
 		Secure:   true,
 		Path:     "/",
-		Expires:  time.Now().Add(-userCommand.JwtExpirationTime),
+		Expires:  time.Unix(int64(tokens.RefreshExpiresAt), 0),
 		SameSite: http.SameSiteStrictMode,
 	})
+}
 
-	return util.WriteJson(w, http.StatusOK, nil)
+func clearTokenCookies(w http.ResponseWriter) {
+	for _, name := range []string{CookieAuthName, CookieRefreshName} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			HttpOnly: true,
+			Secure:   true,
+			Path:     "/",
+			Expires:  time.Unix(0, 0),
+			MaxAge:   -1,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+}
+
+// clientIP returns the address of the direct peer; forwarded headers are not trusted
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
@@ -3,7 +3,6 @@ package command
 import (
 	"context"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 	"net/mail"
 	"time"
 	sharedUtil "time-management/internal/shared/util"
@@ -19,24 +18,26 @@ type CreateAdminCommand struct {
 }
 
 type CreateAdminHandler struct {
-	Repo domain.UserRepository
+	Repo   domain.UserRepository
+	Policy domain.PasswordPolicy
+	Hasher domain.PasswordHasher
 }
 
 func (h *CreateAdminHandler) Handle(ctx context.Context, cmd CreateAdminCommand) (*adminDomain.Admin, error) {
 	if cmd.FirstName == "" {
-		return nil, sharedUtil.NewValidationError(domain.ErrFirstNameTooShort)
+		return nil, sharedUtil.NewFieldError("first_name", domain.ErrFirstNameTooShort)
 	}
 	if cmd.LastName == "" {
-		return nil, sharedUtil.NewValidationError(domain.ErrLastNameTooShort)
+		return nil, sharedUtil.NewFieldError("last_name", domain.ErrLastNameTooShort)
 	}
 	if _, err := mail.ParseAddress(cmd.Email); err != nil {
-		return nil, sharedUtil.NewValidationError(domain.ErrEmailWrongFormat)
+		return nil, sharedUtil.NewFieldError("email", domain.ErrEmailWrongFormat)
 	}
-	if len(cmd.Password) < 6 {
-		return nil, sharedUtil.NewValidationError(domain.ErrPasswordTooShort)
+	if err := h.Policy.Validate(cmd.Password, cmd.Email); err != nil {
+		return nil, sharedUtil.NewFieldError("password", err)
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(cmd.Password), bcrypt.MinCost)
+	hash, err := h.Hasher.Hash(cmd.Password)
 	if err != nil {
 		return nil, domain.ErrInternalServer
 	}
@@ -46,7 +47,7 @@ func (h *CreateAdminHandler) Handle(ctx context.Context, cmd CreateAdminCommand)
 		cmd.FirstName,
 		cmd.LastName,
 		cmd.Email,
-		string(hash),
+		hash,
 		uint64(time.Now().Unix()),
 		true,
 	)
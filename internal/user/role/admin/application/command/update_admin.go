@@ -18,10 +18,10 @@ type UpdateAdminHandler struct {
 
 func (h *UpdateAdminHandler) Handle(ctx context.Context, cmd UpdateAdminCommand) (*adminDomain.Admin, error) {
 	if cmd.FirstName == "" {
-		return nil, sharedUtil.NewValidationError(domain.ErrFirstNameTooShort)
+		return nil, sharedUtil.NewFieldError("first_name", domain.ErrFirstNameTooShort)
 	}
 	if cmd.LastName == "" {
-		return nil, sharedUtil.NewValidationError(domain.ErrLastNameTooShort)
+		return nil, sharedUtil.NewFieldError("last_name", domain.ErrLastNameTooShort)
 	}
 
 	updatedUser, err := h.Repo.Update(ctx, cmd.Id, cmd.FirstName, cmd.LastName)
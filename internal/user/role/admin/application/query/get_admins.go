@@ -2,17 +2,26 @@ package query
 
 import (
 	"context"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/user/domain"
 	"time-management/internal/user/role"
 	adminDomain "time-management/internal/user/role/admin/domain"
 )
 
+type GetAdminsQuery struct {
+	Filter domain.UserFilter
+	Page   pagination.Request
+}
+
 type GetAdminsHandler struct {
 	Repo domain.UserRepository
 }
 
-func (h *GetAdminsHandler) Handle(ctx context.Context) ([]adminDomain.Admin, error) {
-	users, err := h.Repo.GetAllWithRole(ctx, role.Admin.String())
+func (h *GetAdminsHandler) Handle(
+	ctx context.Context,
+	query GetAdminsQuery,
+) (*pagination.Page[adminDomain.Admin], error) {
+	users, total, err := h.Repo.GetAllWithRole(ctx, role.Admin.String(), query.Filter, query.Page)
 	if err != nil {
 		return nil, err
 	}
@@ -23,5 +32,7 @@ func (h *GetAdminsHandler) Handle(ctx context.Context) ([]adminDomain.Admin, err
 		admins = append(admins, *admin)
 	}
 
-	return admins, nil
+	page := pagination.NewPage(admins, total, query.Page)
+
+	return &page, nil
 }
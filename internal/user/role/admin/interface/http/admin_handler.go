@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
 	"time-management/internal/user/domain"
+	userHttp "time-management/internal/user/interface/http"
 	"time-management/internal/user/role/admin/application/command"
 	"time-management/internal/user/role/admin/application/query"
 )
@@ -18,9 +20,13 @@ type AdminHandler struct {
 	DeleteAdminHandler command.DeleteAdminHandler
 }
 
-func NewAdminHandler(repository domain.UserRepository) *AdminHandler {
+func NewAdminHandler(
+	repository domain.UserRepository,
+	policy domain.PasswordPolicy,
+	hasher domain.PasswordHasher,
+) *AdminHandler {
 	return &AdminHandler{
-		CreateAdminHandler: command.CreateAdminHandler{Repo: repository},
+		CreateAdminHandler: command.CreateAdminHandler{Repo: repository, Policy: policy, Hasher: hasher},
 		GetAdminsHandler:   query.GetAdminsHandler{Repo: repository},
 		GetAdminHandler:    query.GetAdminHandler{Repo: repository},
 		UpdateAdminHandler: command.UpdateAdminHandler{Repo: repository},
@@ -36,7 +42,7 @@ func (h *AdminHandler) CreateAdmin(w http.ResponseWriter, r *http.Request) error
 		Password  string `json:"password"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.CreateAdminCommand{
@@ -47,16 +53,25 @@ func (h *AdminHandler) CreateAdmin(w http.ResponseWriter, r *http.Request) error
 	}
 	admin, err := h.CreateAdminHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusCreated, admin)
 }
 
 func (h *AdminHandler) GetAdmins(w http.ResponseWriter, r *http.Request) error {
-	admins, err := h.GetAdminsHandler.Handle(r.Context())
+	page, err := pagination.FromRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
+	}
+	filter, err := userHttp.ParseUserFilter(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	admins, err := h.GetAdminsHandler.Handle(r.Context(), query.GetAdminsQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, admins)
@@ -67,7 +82,7 @@ func (h *AdminHandler) GetAdminById(w http.ResponseWriter, r *http.Request) erro
 
 	admin, err := h.GetAdminHandler.Handle(r.Context(), query.GetAdminQuery{Id: id})
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, admin)
@@ -80,7 +95,7 @@ func (h *AdminHandler) UpdateAdmin(w http.ResponseWriter, r *http.Request) error
 		LastName  string `json:"last_name"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.UpdateAdminCommand{
@@ -90,7 +105,7 @@ func (h *AdminHandler) UpdateAdmin(w http.ResponseWriter, r *http.Request) error
 	}
 	updatedAdmin, err := h.UpdateAdminHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, updatedAdmin)
@@ -101,7 +116,7 @@ func (h *AdminHandler) DeleteAdmin(w http.ResponseWriter, r *http.Request) error
 
 	err := h.DeleteAdminHandler.Handle(r.Context(), command.DeleteAdminCommand{Id: id})
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusNoContent, nil)
@@ -18,7 +18,7 @@ type UpdateEmailHandler struct {
 
 func (h *UpdateEmailHandler) Handle(ctx context.Context, cmd UpdateEmailCommand) error {
 	if _, err := mail.ParseAddress(cmd.Email); err != nil {
-		return sharedUtil.NewValidationError(domain.ErrEmailWrongFormat)
+		return sharedUtil.NewFieldError("email", domain.ErrEmailWrongFormat)
 	}
 
 	err := h.Repo.ChangeEmail(ctx, cmd.Id, cmd.Email)
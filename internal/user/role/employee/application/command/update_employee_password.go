@@ -2,7 +2,6 @@ package command
 
 import (
 	"context"
-	"golang.org/x/crypto/bcrypt"
 	sharedUtil "time-management/internal/shared/util"
 	"time-management/internal/user/domain"
 )
@@ -13,20 +12,27 @@ type UpdatePasswordCommand struct {
 }
 
 type UpdatePasswordHandler struct {
-	Repo domain.UserRepository
+	Repo   domain.UserRepository
+	Policy domain.PasswordPolicy
+	Hasher domain.PasswordHasher
 }
 
 func (h *UpdatePasswordHandler) Handle(ctx context.Context, cmd UpdatePasswordCommand) error {
-	if len(cmd.Password) < 6 {
-		return sharedUtil.NewValidationError(domain.ErrPasswordTooShort)
+	user, err := h.Repo.GetById(ctx, cmd.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Policy.Validate(cmd.Password, user.Email); err != nil {
+		return sharedUtil.NewFieldError("password", err)
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(cmd.Password), bcrypt.MinCost)
+	hash, err := h.Hasher.Hash(cmd.Password)
 	if err != nil {
 		return domain.ErrInternalServer
 	}
 
-	err = h.Repo.ChangePassword(ctx, cmd.Id, string(hash))
+	err = h.Repo.ChangePassword(ctx, cmd.Id, hash)
 	if err != nil {
 		return err
 	}
@@ -2,17 +2,26 @@ package query
 
 import (
 	"context"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/user/domain"
 	"time-management/internal/user/role"
 	empDomain "time-management/internal/user/role/employee/domain"
 )
 
+type GetEmployeesQuery struct {
+	Filter domain.UserFilter
+	Page   pagination.Request
+}
+
 type GetEmployeesHandler struct {
 	Repo domain.UserRepository
 }
 
-func (h *GetEmployeesHandler) Handle(ctx context.Context) ([]empDomain.Employee, error) {
-	users, err := h.Repo.GetAllWithRole(ctx, role.Employee.String())
+func (h *GetEmployeesHandler) Handle(
+	ctx context.Context,
+	query GetEmployeesQuery,
+) (*pagination.Page[empDomain.Employee], error) {
+	users, total, err := h.Repo.GetAllWithRole(ctx, role.Employee.String(), query.Filter, query.Page)
 	if err != nil {
 		return nil, err
 	}
@@ -23,5 +32,7 @@ func (h *GetEmployeesHandler) Handle(ctx context.Context) ([]empDomain.Employee,
 		employees = append(employees, *employee)
 	}
 
-	return employees, nil
+	page := pagination.NewPage(employees, total, query.Page)
+
+	return &page, nil
 }
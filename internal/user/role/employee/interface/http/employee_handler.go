@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"time-management/internal/shared/pagination"
 	"time-management/internal/shared/util"
 	"time-management/internal/user/domain"
+	userHttp "time-management/internal/user/interface/http"
 	"time-management/internal/user/role/employee/application/command"
 	"time-management/internal/user/role/employee/application/query"
 )
@@ -21,14 +23,18 @@ type EmployeeHandler struct {
 	DeleteEmployeeHandler command.DeleteEmployeeHandler
 }
 
-func NewEmployeeHandler(repository domain.UserRepository) *EmployeeHandler {
+func NewEmployeeHandler(
+	repository domain.UserRepository,
+	policy domain.PasswordPolicy,
+	hasher domain.PasswordHasher,
+) *EmployeeHandler {
 	return &EmployeeHandler{
-		CreateEmployeeHandler: command.CreateEmployeeHandler{Repo: repository},
+		CreateEmployeeHandler: command.CreateEmployeeHandler{Repo: repository, Policy: policy, Hasher: hasher},
 		GetEmployeesHandler:   query.GetEmployeesHandler{Repo: repository},
 		GetEmployeeHandler:    query.GetEmployeeHandler{Repo: repository},
 		UpdateEmailHandler:    command.UpdateEmailHandler{Repo: repository},
 		UpdateEmployeeHandler: command.UpdateEmployeeHandler{Repo: repository},
-		UpdatePasswordHandler: command.UpdatePasswordHandler{Repo: repository},
+		UpdatePasswordHandler: command.UpdatePasswordHandler{Repo: repository, Policy: policy, Hasher: hasher},
 		ToggleStatusHandler:   command.ToggleStatusHandler{Repo: repository},
 		DeleteEmployeeHandler: command.DeleteEmployeeHandler{Repo: repository},
 	}
@@ -42,7 +48,7 @@ func (h *EmployeeHandler) CreateEmployee(w http.ResponseWriter, r *http.Request)
 		Password  string `json:"password"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.CreateEmployeeCommand{
@@ -53,16 +59,25 @@ func (h *EmployeeHandler) CreateEmployee(w http.ResponseWriter, r *http.Request)
 	}
 	employee, err := h.CreateEmployeeHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusCreated, employee)
 }
 
 func (h *EmployeeHandler) GetEmployees(w http.ResponseWriter, r *http.Request) error {
-	employees, err := h.GetEmployeesHandler.Handle(r.Context())
+	page, err := pagination.FromRequest(r)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
+	}
+	filter, err := userHttp.ParseUserFilter(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	employees, err := h.GetEmployeesHandler.Handle(r.Context(), query.GetEmployeesQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, employees)
@@ -73,7 +88,7 @@ func (h *EmployeeHandler) GetEmployee(w http.ResponseWriter, r *http.Request) er
 
 	employee, err := h.GetEmployeeHandler.Handle(r.Context(), query.GetEmployeeQuery{Id: id})
 	if err != nil {
-		return util.HandleError(w, err, http.StatusNotFound)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, employee)
@@ -86,7 +101,7 @@ func (h *EmployeeHandler) UpdateEmployee(w http.ResponseWriter, r *http.Request)
 		LastName  string `json:"last_name"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.UpdateEmployeeCommand{
@@ -96,7 +111,7 @@ func (h *EmployeeHandler) UpdateEmployee(w http.ResponseWriter, r *http.Request)
 	}
 	updatedEmployee, err := h.UpdateEmployeeHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, updatedEmployee)
@@ -107,7 +122,7 @@ func (h *EmployeeHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 	if id == "" {
 		user, ok := r.Context().Value("user").(*domain.User)
 		if !ok || user == nil {
-			return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: domain.ErrUserNotFound.Error()})
+			return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 		}
 		id = user.Id
 	}
@@ -116,7 +131,7 @@ func (h *EmployeeHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 		Password string `json:"password"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.UpdatePasswordCommand{
@@ -125,7 +140,7 @@ func (h *EmployeeHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 	}
 	err := h.UpdatePasswordHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, nil)
@@ -136,7 +151,7 @@ func (h *EmployeeHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) er
 	if id == "" {
 		user, ok := r.Context().Value("user").(*domain.User)
 		if !ok || user == nil {
-			return util.WriteJson(w, http.StatusUnauthorized, util.ApiError{Error: "Unauthorized: unable to get user"})
+			return util.WriteError(w, r, util.NewUnauthorizedError(domain.ErrUserNotFound))
 		}
 		id = user.Id
 	}
@@ -145,7 +160,7 @@ func (h *EmployeeHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) er
 		Email string `json:"email"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.UpdateEmailCommand{
@@ -154,7 +169,7 @@ func (h *EmployeeHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) er
 	}
 	err := h.UpdateEmailHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.HandleError(w, err, http.StatusBadRequest)
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, nil)
@@ -166,7 +181,7 @@ func (h *EmployeeHandler) ToggleEmployeeStatus(w http.ResponseWriter, r *http.Re
 		Active bool `json:"active"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return util.WriteJson(w, http.StatusBadRequest, util.ApiError{Error: err.Error()})
+		return util.WriteError(w, r, util.InvalidBody(err))
 	}
 
 	cmd := command.ToggleStatusCommand{
@@ -175,7 +190,7 @@ func (h *EmployeeHandler) ToggleEmployeeStatus(w http.ResponseWriter, r *http.Re
 	}
 	status, err := h.ToggleStatusHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusOK, status)
@@ -186,7 +201,7 @@ func (h *EmployeeHandler) DeleteEmployee(w http.ResponseWriter, r *http.Request)
 
 	err := h.DeleteEmployeeHandler.Handle(r.Context(), command.DeleteEmployeeCommand{Id: id})
 	if err != nil {
-		return util.WriteJson(w, http.StatusInternalServerError, util.ApiError{Error: domain.ErrInternalServer.Error()})
+		return util.WriteError(w, r, err)
 	}
 
 	return util.WriteJson(w, http.StatusNoContent, nil)
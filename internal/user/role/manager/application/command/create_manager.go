@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/mail"
+	"time"
+	sharedUtil "time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+	mgrDomain "time-management/internal/user/role/manager/domain"
+)
+
+type CreateManagerCommand struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Password  string
+}
+
+type CreateManagerHandler struct {
+	Repo   domain.UserRepository
+	Policy domain.PasswordPolicy
+	Hasher domain.PasswordHasher
+}
+
+func (h *CreateManagerHandler) Handle(ctx context.Context, cmd CreateManagerCommand) (*mgrDomain.Manager, error) {
+	if cmd.FirstName == "" {
+		return nil, sharedUtil.NewFieldError("first_name", domain.ErrFirstNameTooShort)
+	}
+	if cmd.LastName == "" {
+		return nil, sharedUtil.NewFieldError("last_name", domain.ErrLastNameTooShort)
+	}
+	if _, err := mail.ParseAddress(cmd.Email); err != nil {
+		return nil, sharedUtil.NewFieldError("email", domain.ErrEmailWrongFormat)
+	}
+	if err := h.Policy.Validate(cmd.Password, cmd.Email); err != nil {
+		return nil, sharedUtil.NewFieldError("password", err)
+	}
+
+	hash, err := h.Hasher.Hash(cmd.Password)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	manager := domain.NewManager(
+		uuid.New().String(),
+		cmd.FirstName,
+		cmd.LastName,
+		cmd.Email,
+		hash,
+		uint64(time.Now().Unix()),
+		true,
+	)
+
+	createdUser, err := h.Repo.Create(ctx, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	createdManager := mgrDomain.MapUserToManager(createdUser)
+
+	return createdManager, nil
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"context"
+	"time-management/internal/user/domain"
+)
+
+type DeleteManagerCommand struct {
+	Id string
+}
+
+type DeleteManagerHandler struct {
+	Repo domain.UserRepository
+}
+
+func (h *DeleteManagerHandler) Handle(ctx context.Context, cmd DeleteManagerCommand) error {
+	err := h.Repo.Delete(ctx, cmd.Id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"context"
+	sharedUtil "time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+	mgrDomain "time-management/internal/user/role/manager/domain"
+)
+
+type UpdateManagerCommand struct {
+	Id        string
+	FirstName string
+	LastName  string
+}
+
+type UpdateManagerHandler struct {
+	Repo domain.UserRepository
+}
+
+func (h *UpdateManagerHandler) Handle(ctx context.Context, cmd UpdateManagerCommand) (*mgrDomain.Manager, error) {
+	if cmd.FirstName == "" {
+		return nil, sharedUtil.NewFieldError("first_name", domain.ErrFirstNameTooShort)
+	}
+	if cmd.LastName == "" {
+		return nil, sharedUtil.NewFieldError("last_name", domain.ErrLastNameTooShort)
+	}
+
+	updatedUser, err := h.Repo.Update(ctx, cmd.Id, cmd.FirstName, cmd.LastName)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedManager := mgrDomain.MapUserToManager(updatedUser)
+
+	return updatedManager, nil
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/user/domain"
+	"time-management/internal/user/role"
+	mgrDomain "time-management/internal/user/role/manager/domain"
+)
+
+type GetManagerQuery struct {
+	Id string
+}
+
+type GetManagerHandler struct {
+	Repo domain.UserRepository
+}
+
+func (h *GetManagerHandler) Handle(ctx context.Context, query GetManagerQuery) (*mgrDomain.Manager, error) {
+	user, err := h.Repo.GetByIdWithRole(ctx, query.Id, role.Manager.String())
+	if err != nil {
+		return nil, err
+	}
+
+	manager := mgrDomain.MapUserToManager(user)
+
+	return manager, nil
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/user/domain"
+	"time-management/internal/user/role"
+	mgrDomain "time-management/internal/user/role/manager/domain"
+)
+
+type GetManagersQuery struct {
+	Filter domain.UserFilter
+	Page   pagination.Request
+}
+
+type GetManagersHandler struct {
+	Repo domain.UserRepository
+}
+
+func (h *GetManagersHandler) Handle(
+	ctx context.Context,
+	query GetManagersQuery,
+) (*pagination.Page[mgrDomain.Manager], error) {
+	users, total, err := h.Repo.GetAllWithRole(ctx, role.Manager.String(), query.Filter, query.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	var managers []mgrDomain.Manager
+	for _, user := range users {
+		manager := mgrDomain.MapUserToManager(&user)
+		managers = append(managers, *manager)
+	}
+
+	page := pagination.NewPage(managers, total, query.Page)
+
+	return &page, nil
+}
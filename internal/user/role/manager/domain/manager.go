@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time-management/internal/user/domain"
+)
+
+type Manager struct {
+	Id        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	CreatedAt uint64 `json:"created_at"`
+	Active    bool   `json:"active"`
+}
+
+func MapUserToManager(user *domain.User) *Manager {
+	return &Manager{
+		Id:        user.Id,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		Active:    user.Active,
+	}
+}
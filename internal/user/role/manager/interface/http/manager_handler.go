@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time-management/internal/shared/pagination"
+	"time-management/internal/shared/util"
+	"time-management/internal/user/domain"
+	userHttp "time-management/internal/user/interface/http"
+	"time-management/internal/user/role/manager/application/command"
+	"time-management/internal/user/role/manager/application/query"
+)
+
+type ManagerHandler struct {
+	CreateManagerHandler command.CreateManagerHandler
+	GetManagersHandler   query.GetManagersHandler
+	GetManagerHandler    query.GetManagerHandler
+	UpdateManagerHandler command.UpdateManagerHandler
+	ToggleStatusHandler  command.ToggleStatusHandler
+	DeleteManagerHandler command.DeleteManagerHandler
+}
+
+func NewManagerHandler(
+	repository domain.UserRepository,
+	policy domain.PasswordPolicy,
+	hasher domain.PasswordHasher,
+) *ManagerHandler {
+	return &ManagerHandler{
+		CreateManagerHandler: command.CreateManagerHandler{Repo: repository, Policy: policy, Hasher: hasher},
+		GetManagersHandler:   query.GetManagersHandler{Repo: repository},
+		GetManagerHandler:    query.GetManagerHandler{Repo: repository},
+		UpdateManagerHandler: command.UpdateManagerHandler{Repo: repository},
+		ToggleStatusHandler:  command.ToggleStatusHandler{Repo: repository},
+		DeleteManagerHandler: command.DeleteManagerHandler{Repo: repository},
+	}
+}
+
+func (h *ManagerHandler) CreateManager(w http.ResponseWriter, r *http.Request) error {
+	var req struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Email     string `json:"email"`
+		Password  string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := command.CreateManagerCommand{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Password:  req.Password,
+	}
+	manager, err := h.CreateManagerHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusCreated, manager)
+}
+
+func (h *ManagerHandler) GetManagers(w http.ResponseWriter, r *http.Request) error {
+	page, err := pagination.FromRequest(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+	filter, err := userHttp.ParseUserFilter(r)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	managers, err := h.GetManagersHandler.Handle(r.Context(), query.GetManagersQuery{Filter: filter, Page: page})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, managers)
+}
+
+func (h *ManagerHandler) GetManager(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	manager, err := h.GetManagerHandler.Handle(r.Context(), query.GetManagerQuery{Id: id})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, manager)
+}
+
+func (h *ManagerHandler) UpdateManager(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+	var req struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := command.UpdateManagerCommand{
+		Id:        id,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	}
+	updatedManager, err := h.UpdateManagerHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, updatedManager)
+}
+
+func (h *ManagerHandler) ToggleManagerStatus(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+	var req struct {
+		Active bool `json:"active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return util.WriteError(w, r, util.InvalidBody(err))
+	}
+
+	cmd := command.ToggleStatusCommand{
+		Id:     id,
+		Active: req.Active,
+	}
+	status, err := h.ToggleStatusHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusOK, status)
+}
+
+func (h *ManagerHandler) DeleteManager(w http.ResponseWriter, r *http.Request) error {
+	id := chi.URLParam(r, "id")
+
+	err := h.DeleteManagerHandler.Handle(r.Context(), command.DeleteManagerCommand{Id: id})
+	if err != nil {
+		return util.WriteError(w, r, err)
+	}
+
+	return util.WriteJson(w, http.StatusNoContent, nil)
+}
@@ -19,6 +19,11 @@ var validRoles = map[Role]bool{
 	Employee:   true,
 }
 
+// All returns every role, from the most to the least privileged
+func All() []Role {
+	return []Role{SuperAdmin, Admin, Manager, Employee}
+}
+
 // String method provides the string representation of a Role (optional, since Role is already a string).
 func (r Role) String() string {
 	return string(r)